@@ -0,0 +1,72 @@
+package semver
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Semver{}
+	_ encoding.BinaryUnmarshaler = (*Semver)(nil)
+)
+
+// binaryFormat is the first byte of the binary encoding, so the format can
+// change without misreading cached values.
+const binaryFormat byte = 1
+
+const maxInt = int(^uint(0) >> 1)
+
+// MarshalBinary encodes the version compactly: a format byte, then major,
+// minor and patch as uvarints, then the prerelease and build metadata, each as
+// a uvarint length followed by that many bytes. gob uses it in place of
+// struct reflection.
+func (ver Semver) MarshalBinary() ([]byte, error) {
+	if err := ver.Validate(); err != nil {
+		return nil, err
+	}
+	b := make([]byte, 0, 8+len(ver.Prerelease)+len(ver.Build))
+	b = append(b, binaryFormat)
+	b = binary.AppendUvarint(b, uint64(ver.Major))
+	b = binary.AppendUvarint(b, uint64(ver.Minor))
+	b = binary.AppendUvarint(b, uint64(ver.Patch))
+	b = binary.AppendUvarint(b, uint64(len(ver.Prerelease)))
+	b = append(b, ver.Prerelease...)
+	b = binary.AppendUvarint(b, uint64(len(ver.Build)))
+	b = append(b, ver.Build...)
+	return b, nil
+}
+
+// UnmarshalBinary decodes the output of MarshalBinary and validates it,
+// leaving ver unchanged on error.
+func (ver *Semver) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryFormat {
+		return fmt.Errorf("Invalid semver binary: unknown format")
+	}
+	data = data[1:]
+
+	var v Semver
+	for _, p := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		n, size := binary.Uvarint(data)
+		if size <= 0 || n > uint64(maxInt) {
+			return fmt.Errorf("Invalid semver binary: bad number")
+		}
+		*p, data = int(n), data[size:]
+	}
+	for _, p := range []*string{&v.Prerelease, &v.Build} {
+		n, size := binary.Uvarint(data)
+		if size <= 0 || n > uint64(len(data)-size) {
+			return fmt.Errorf("Invalid semver binary: bad length")
+		}
+		data = data[size:]
+		*p, data = string(data[:n]), data[n:]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("Invalid semver binary: trailing data")
+	}
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	*ver = v
+	return nil
+}
@@ -0,0 +1,59 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+	v := MustParse("1.2.300-rc.1+build.5")
+	b, err := v.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []byte("\x01\x01\x02\xac\x02\x04rc.1\x07build.5"); !bytes.Equal(b, exp) {
+		t.Errorf("%q != %q", b, exp)
+	}
+	var ver Semver
+	if err := ver.UnmarshalBinary(b); err != nil || ver != v {
+		t.Errorf("round trip: %+v, %v", ver, err)
+	}
+
+	if _, err := (Semver{Major: -1}).MarshalBinary(); err == nil {
+		t.Errorf("expected error marshaling an invalid version")
+	}
+	bad := [][]byte{
+		nil,
+		[]byte("\x02\x01\x02\x03\x00\x00"),
+		[]byte("\x01\x01\x02"),
+		[]byte("\x01\x01\x02\x03\x05rc.1\x00"),
+		[]byte("\x01\x01\x02\x03\x00\x00\x00"),
+		[]byte("\x01\x01\x02\x03\x03a b\x00"),
+	}
+	for _, data := range bad {
+		if err := ver.UnmarshalBinary(data); err == nil {
+			t.Errorf("%q: expected error", data)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type release struct {
+		Name    string
+		Version Semver
+	}
+	in := release{"x", MustParse("1.2.3-rc.1+build.5")}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out release
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("%+v != %+v", out, in)
+	}
+}
@@ -0,0 +1,178 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// BSON element types, from the BSON spec.
+const (
+	bsonString   byte = 0x02
+	bsonDocument byte = 0x03
+	bsonInt32    byte = 0x10
+	bsonInt64    byte = 0x12
+)
+
+// MarshalBSONValue encodes the version as a BSON string. It implements
+// bson.ValueMarshaler from go.mongodb.org/mongo-driver/v2, which uses the plain
+// byte type for BSON types so this package needn't import the driver.
+func (ver Semver) MarshalBSONValue() (byte, []byte, error) {
+	return bsonString, appendBSONString(nil, ver.String()), nil
+}
+
+// UnmarshalBSONValue decodes a version stored either as a string or as the
+// sub-document written by BSONDocument, and validates it.
+func (ver *Semver) UnmarshalBSONValue(typ byte, data []byte) error {
+	var v Semver
+	switch typ {
+	case bsonString:
+		s, rest, err := readBSONString(data)
+		if err != nil {
+			return err
+		}
+		if len(rest) != 0 {
+			return fmt.Errorf("Invalid semver BSON: trailing data")
+		}
+		if v, err = Parse(s); err != nil {
+			return err
+		}
+	case bsonDocument:
+		var err error
+		if v, err = readBSONDocument(data); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Invalid semver BSON: expected a string or a document, got type 0x%02x", typ)
+	}
+	*ver = v
+	return nil
+}
+
+// BSONDocument is a Semver that is stored in MongoDB as an ordered
+// sub-document, {major, minor, patch, prerelease, build}, rather than a
+// string. Unlike strings, such documents sort by precedence of the core
+// version in queries. Empty prerelease and build fields are omitted.
+type BSONDocument Semver
+
+// MarshalBSONValue encodes the version as a sub-document.
+func (d BSONDocument) MarshalBSONValue() (byte, []byte, error) {
+	var b []byte
+	b = append(b, 0, 0, 0, 0) // length, filled in below
+	b = appendBSONInt(b, "major", d.Major)
+	b = appendBSONInt(b, "minor", d.Minor)
+	b = appendBSONInt(b, "patch", d.Patch)
+	if d.Prerelease != "" {
+		b = appendBSONElement(b, bsonString, "prerelease")
+		b = appendBSONString(b, d.Prerelease)
+	}
+	if d.Build != "" {
+		b = appendBSONElement(b, bsonString, "build")
+		b = appendBSONString(b, d.Build)
+	}
+	b = append(b, 0)
+	binary.LittleEndian.PutUint32(b, uint32(len(b)))
+	return bsonDocument, b, nil
+}
+
+// UnmarshalBSONValue decodes a version stored either as a string or as a
+// sub-document, and validates it.
+func (d *BSONDocument) UnmarshalBSONValue(typ byte, data []byte) error {
+	return (*Semver)(d).UnmarshalBSONValue(typ, data)
+}
+
+func appendBSONElement(b []byte, typ byte, key string) []byte {
+	b = append(b, typ)
+	b = append(b, key...)
+	return append(b, 0)
+}
+
+func appendBSONInt(b []byte, key string, n int) []byte {
+	if n >= math.MinInt32 && n <= math.MaxInt32 {
+		b = appendBSONElement(b, bsonInt32, key)
+		return binary.LittleEndian.AppendUint32(b, uint32(int32(n)))
+	}
+	b = appendBSONElement(b, bsonInt64, key)
+	return binary.LittleEndian.AppendUint64(b, uint64(int64(n)))
+}
+
+func appendBSONString(b []byte, s string) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s)+1))
+	b = append(b, s...)
+	return append(b, 0)
+}
+
+func readBSONString(data []byte) (s string, rest []byte, err error) {
+	if len(data) < 5 {
+		return "", nil, fmt.Errorf("Invalid semver BSON: short string")
+	}
+	n := int(binary.LittleEndian.Uint32(data))
+	if n < 1 || len(data)-4 < n || data[4+n-1] != 0 {
+		return "", nil, fmt.Errorf("Invalid semver BSON: bad string length")
+	}
+	return string(data[4 : 4+n-1]), data[4+n:], nil
+}
+
+func readBSONDocument(data []byte) (v Semver, err error) {
+	if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data) || data[len(data)-1] != 0 {
+		return v, fmt.Errorf("Invalid semver BSON: bad document length")
+	}
+	elems := data[4 : len(data)-1]
+	seen := make(map[string]bool)
+	for len(elems) > 0 {
+		typ := elems[0]
+		end := bytes.IndexByte(elems[1:], 0)
+		if end < 0 {
+			return v, fmt.Errorf("Invalid semver BSON: unterminated key")
+		}
+		key := string(elems[1 : 1+end])
+		elems = elems[2+end:]
+		if seen[key] {
+			return v, fmt.Errorf("Invalid semver BSON: repeated key %q", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "major", "minor", "patch":
+			var n int64
+			switch {
+			case typ == bsonInt32 && len(elems) >= 4:
+				n, elems = int64(int32(binary.LittleEndian.Uint32(elems))), elems[4:]
+			case typ == bsonInt64 && len(elems) >= 8:
+				n, elems = int64(binary.LittleEndian.Uint64(elems)), elems[8:]
+			default:
+				return v, fmt.Errorf("Invalid semver BSON: %s must be an integer", key)
+			}
+			switch key {
+			case "major":
+				v.Major = int(n)
+			case "minor":
+				v.Minor = int(n)
+			case "patch":
+				v.Patch = int(n)
+			}
+		case "prerelease", "build":
+			if typ != bsonString {
+				return v, fmt.Errorf("Invalid semver BSON: %s must be a string", key)
+			}
+			var s string
+			if s, elems, err = readBSONString(elems); err != nil {
+				return v, err
+			}
+			if key == "prerelease" {
+				v.Prerelease = s
+			} else {
+				v.Build = s
+			}
+		default:
+			return v, fmt.Errorf("Invalid semver BSON: unknown key %q", key)
+		}
+	}
+	for _, key := range []string{"major", "minor", "patch"} {
+		if !seen[key] {
+			return v, fmt.Errorf("Invalid semver BSON: missing %s", key)
+		}
+	}
+	return v, v.Validate()
+}
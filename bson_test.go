@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBSONString(t *testing.T) {
+	typ, data, err := MustParse("1.2.3-rc.1").MarshalBSONValue()
+	if err != nil || typ != bsonString {
+		t.Fatalf("unexpected type 0x%02x, %v", typ, err)
+	}
+	if exp := []byte("\x0b\x00\x00\x001.2.3-rc.1\x00"); !bytes.Equal(data, exp) {
+		t.Errorf("%q != %q", data, exp)
+	}
+
+	var ver Semver
+	if err := ver.UnmarshalBSONValue(typ, data); err != nil || ver != MustParse("1.2.3-rc.1") {
+		t.Errorf("round trip: %+v, %v", ver, err)
+	}
+	if err := ver.UnmarshalBSONValue(bsonString, []byte("\x04\x00\x00\x001.2\x00")); err == nil {
+		t.Errorf("expected validation error")
+	}
+	if err := ver.UnmarshalBSONValue(bsonString, []byte("\x09\x00\x00\x001.2")); err == nil {
+		t.Errorf("expected length error")
+	}
+}
+
+func TestBSONDocument(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	typ, data, err := BSONDocument(v).MarshalBSONValue()
+	if err != nil || typ != bsonDocument {
+		t.Fatalf("unexpected type 0x%02x, %v", typ, err)
+	}
+	exp := []byte("\x4e\x00\x00\x00" +
+		"\x10major\x00\x01\x00\x00\x00" +
+		"\x10minor\x00\x02\x00\x00\x00" +
+		"\x10patch\x00\x03\x00\x00\x00" +
+		"\x02prerelease\x00\x05\x00\x00\x00rc.1\x00" +
+		"\x02build\x00\x08\x00\x00\x00build.5\x00" +
+		"\x00")
+	if !bytes.Equal(data, exp) {
+		t.Errorf("%q != %q", data, exp)
+	}
+
+	// either form decodes into either type
+	var ver Semver
+	if err := ver.UnmarshalBSONValue(typ, data); err != nil || ver != v {
+		t.Errorf("document into Semver: %+v, %v", ver, err)
+	}
+	var doc BSONDocument
+	typ, data, _ = v.MarshalBSONValue()
+	if err := doc.UnmarshalBSONValue(typ, data); err != nil || Semver(doc) != v {
+		t.Errorf("string into BSONDocument: %+v, %v", doc, err)
+	}
+
+	bad := [][]byte{
+		[]byte("\x05\x00\x00\x00\x00"),
+		[]byte("\x13\x00\x00\x00\x10major\x00\x01\x00\x00\x00\x10minor\x00"),
+		[]byte("\x2c\x00\x00\x00\x10major\x00\x01\x00\x00\x00\x10minor\x00\x02\x00\x00\x00\x02patch\x00\x02\x00\x00\x003\x00\x00"),
+		[]byte("\x30\x00\x00\x00\x10major\x00\x01\x00\x00\x00\x10minor\x00\x02\x00\x00\x00\x10patch\x00\x03\x00\x00\x00\x10extra\x00\x03\x00\x00\x00\x00"),
+	}
+	for _, data := range bad {
+		if err := ver.UnmarshalBSONValue(bsonDocument, data); err == nil {
+			t.Errorf("expected error for %q", data)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NextMajor returns the next major version. Build metadata is dropped, and a
+// prerelease of a major version, such as 2.0.0-rc.1, bumps to that release.
+func (v Semver) NextMajor() Semver {
+	if v.Prerelease != "" && v.Minor == 0 && v.Patch == 0 {
+		return Semver{Major: v.Major}
+	}
+	return Semver{Major: v.Major + 1}
+}
+
+// NextMinor returns the next minor version. Build metadata is dropped, and a
+// prerelease of a minor version, such as 1.2.0-rc.1, bumps to that release.
+func (v Semver) NextMinor() Semver {
+	if v.Prerelease != "" && v.Patch == 0 {
+		return Semver{Major: v.Major, Minor: v.Minor}
+	}
+	return Semver{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// NextPatch returns the next patch version. Build metadata is dropped, and a
+// prerelease bumps to its release.
+func (v Semver) NextPatch() Semver {
+	if v.Prerelease != "" {
+		return Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	}
+	return Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+// NextPrerelease returns the next prerelease with the identifier id, such as
+// "rc", or a plain number if id is empty:
+//
+//	1.2.3         -> 1.2.4-rc.0
+//	1.2.4-rc.0    -> 1.2.4-rc.1
+//	1.2.4-beta.3  -> 1.2.4-rc.0
+//	1.2.4-rc      -> 1.2.4-rc.0
+//
+// A prerelease that doesn't start with id has its last numeric identifier
+// incremented if id is empty. Build metadata is dropped.
+func (v Semver) NextPrerelease(id string) Semver {
+	next := Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if v.Prerelease == "" {
+		next.Patch++
+		next.Prerelease = joinIdentifiers(id, "0")
+		return next
+	}
+
+	ids := strings.Split(v.Prerelease, ".")
+	if id != "" && ids[0] != id {
+		next.Prerelease = joinIdentifiers(id, "0")
+		return next
+	}
+	for i := len(ids) - 1; i >= 0; i-- {
+		if isDigits(ids[i]) {
+			if n, err := strconv.Atoi(ids[i]); err == nil {
+				ids[i] = strconv.Itoa(n + 1)
+				next.Prerelease = strings.Join(ids, ".")
+				return next
+			}
+		}
+	}
+	next.Prerelease = v.Prerelease + ".0"
+	return next
+}
+
+func joinIdentifiers(id, n string) string {
+	if id == "" {
+		return n
+	}
+	return id + "." + n
+}
@@ -0,0 +1,41 @@
+package semver
+
+import "testing"
+
+type bumpTest struct {
+	given  string
+	bump   func(Semver) Semver
+	exp    string
+	reason string
+}
+
+func TestBump(t *testing.T) {
+	pre := func(id string) func(Semver) Semver {
+		return func(v Semver) Semver { return v.NextPrerelease(id) }
+	}
+	tests := []bumpTest{
+		{"1.2.3", Semver.NextMajor, "2.0.0", "major"},
+		{"1.2.3+build", Semver.NextMajor, "2.0.0", "major drops build"},
+		{"2.0.0-rc.1", Semver.NextMajor, "2.0.0", "major prerelease"},
+		{"2.1.0-rc.1", Semver.NextMajor, "3.0.0", "minor prerelease, major bump"},
+		{"1.2.3", Semver.NextMinor, "1.3.0", "minor"},
+		{"1.3.0-rc.1", Semver.NextMinor, "1.3.0", "minor prerelease"},
+		{"1.3.1-rc.1", Semver.NextMinor, "1.4.0", "patch prerelease, minor bump"},
+		{"1.2.3", Semver.NextPatch, "1.2.4", "patch"},
+		{"1.2.4-rc.1", Semver.NextPatch, "1.2.4", "patch prerelease"},
+		{"1.2.3", pre("rc"), "1.2.4-rc.0", "first prerelease"},
+		{"1.2.3", pre(""), "1.2.4-0", "first numeric prerelease"},
+		{"1.2.4-rc.0", pre("rc"), "1.2.4-rc.1", "next prerelease"},
+		{"1.2.4-rc.9", pre(""), "1.2.4-rc.10", "next prerelease without id"},
+		{"1.2.4-beta.3", pre("rc"), "1.2.4-rc.0", "new identifier"},
+		{"1.2.4-rc", pre("rc"), "1.2.4-rc.0", "no number yet"},
+		{"1.2.4-rc.1.foo", pre(""), "1.2.4-rc.2.foo", "last numeric identifier"},
+		{"1.2.4-rc.1+build", pre("rc"), "1.2.4-rc.2", "prerelease drops build"},
+	}
+
+	for _, test := range tests {
+		if v := test.bump(MustParse(test.given)); v.String() != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, v, test.exp)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package semver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf8"
+)
+
+// cborText is the CBOR major type for text strings.
+const cborText = 3
+
+// MarshalCBOR encodes the version as a CBOR text string. It implements
+// cbor.Marshaler from github.com/fxamacker/cbor, which needs no import.
+//
+// The encoding is deterministic in the sense of RFC 8949 section 4.2: the
+// length uses the shortest possible head and the string is the canonical
+// String form, so equal versions always encode to identical bytes, as COSE
+// signatures over device manifests require. Build metadata is kept, so
+// versions differing only in build metadata encode differently.
+func (ver Semver) MarshalCBOR() ([]byte, error) {
+	s := ver.String()
+	b := appendCBORHead(make([]byte, 0, len(s)+9), cborText, uint64(len(s)))
+	return append(b, s...), nil
+}
+
+// UnmarshalCBOR decodes a definite-length CBOR text string and validates it.
+func (ver *Semver) UnmarshalCBOR(data []byte) error {
+	s, err := readCBORText(data)
+	if err != nil {
+		return err
+	}
+	return ver.UnmarshalText(s)
+}
+
+// readCBORText returns the contents of the definite-length text string that
+// makes up data.
+func readCBORText(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0]>>5 != cborText {
+		return nil, fmt.Errorf("Invalid semver CBOR: expected a text string")
+	}
+	n, head, err := readCBORHead(data)
+	if err != nil {
+		return nil, err
+	}
+	if n != uint64(len(data)-head) {
+		return nil, fmt.Errorf("Invalid semver CBOR: bad string length")
+	}
+	s := data[head:]
+	if !utf8.Valid(s) {
+		return nil, fmt.Errorf("Invalid semver CBOR: string is not UTF-8")
+	}
+	return s, nil
+}
+
+// appendCBORHead appends the shortest head for the given major type and
+// argument.
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= 0xff:
+		return append(b, major|24, byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), n)
+}
+
+// readCBORHead returns the argument of the head at the start of data and the
+// head's size.
+func readCBORHead(data []byte) (n uint64, size int, err error) {
+	ai := data[0] & 0x1f
+	switch {
+	case ai < 24:
+		return uint64(ai), 1, nil
+	case ai <= 27:
+		size = 1 << (ai - 24)
+		if len(data) < 1+size {
+			return 0, 0, fmt.Errorf("Invalid semver CBOR: truncated head")
+		}
+		for _, c := range data[1 : 1+size] {
+			n = n<<8 | uint64(c)
+		}
+		return n, 1 + size, nil
+	}
+	return 0, 0, fmt.Errorf("Invalid semver CBOR: indefinite or reserved length")
+}
@@ -0,0 +1,50 @@
+package semver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	tests := []struct {
+		given Semver
+		exp   []byte
+	}{
+		{MustParse("1.2.3"), []byte("\x651.2.3")},
+		{MustParse("v1.2.3-rc.1+build.5"), []byte("\x721.2.3-rc.1+build.5")},
+		{MustParse("1.2.3-" + strings.Repeat("a", 30)), append([]byte("\x78\x24"), "1.2.3-"+strings.Repeat("a", 30)...)},
+	}
+
+	for _, test := range tests {
+		b, err := test.given.MarshalCBOR()
+		if err != nil || !bytes.Equal(b, test.exp) {
+			t.Errorf("%s: %q, %v", test.given, b, err)
+		}
+		var ver Semver
+		if err := ver.UnmarshalCBOR(b); err != nil || ver != test.given {
+			t.Errorf("%s: round trip %+v, %v", test.given, ver, err)
+		}
+	}
+
+	// non-shortest heads still decode
+	var ver Semver
+	if err := ver.UnmarshalCBOR([]byte("\x78\x051.2.3")); err != nil || ver != MustParse("1.2.3") {
+		t.Errorf("long head: %+v, %v", ver, err)
+	}
+
+	bad := [][]byte{
+		nil,
+		[]byte("\x451.2.3"),
+		[]byte("\x661.2.3"),
+		[]byte("\x7f\x651.2.3\xff"),
+		[]byte("\x631.2"),
+		[]byte("\x79\x00"),
+		[]byte("\x65\xff.2.3"),
+	}
+	for _, data := range bad {
+		if err := ver.UnmarshalCBOR(data); err == nil {
+			t.Errorf("%q: expected error", data)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Package changelog assembles a changelog in the Keep a Changelog style
+// (https://keepachangelog.com) from entries tagged with the version that
+// introduced them, putting versions in order of precedence and prereleases
+// under the release they lead up to.
+package changelog
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beatgammit/semver"
+)
+
+// Categories are the standard Keep a Changelog categories, in the order they
+// are written. Other categories follow them in alphabetical order, and
+// entries without a category come first.
+var Categories = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// Changelog collects entries. The zero Changelog is empty and ready to use.
+type Changelog struct {
+	// Title is the top-level heading, "Changelog" if empty.
+	Title string
+
+	unreleased section
+	versions   map[semver.Semver]*section
+}
+
+// section is the entries and date of a version, or of the unreleased
+// changes.
+type section struct {
+	date    time.Time
+	entries map[string][]string // by category
+}
+
+func (s *section) add(category, text string) {
+	if s.entries == nil {
+		s.entries = make(map[string][]string)
+	}
+	s.entries[category] = append(s.entries[category], text)
+}
+
+// key drops build metadata, which doesn't affect precedence, so that
+// versions differing only in build metadata share a section.
+func key(v semver.Semver) semver.Semver {
+	v.Build = ""
+	return v
+}
+
+func (c *Changelog) section(v semver.Semver) *section {
+	if c.versions == nil {
+		c.versions = make(map[semver.Semver]*section)
+	}
+	s, ok := c.versions[key(v)]
+	if !ok {
+		s = new(section)
+		c.versions[key(v)] = s
+	}
+	return s
+}
+
+// Add adds an entry for version v, in category, which may be empty.
+func (c *Changelog) Add(v semver.Semver, category, text string) {
+	c.section(v).add(category, text)
+}
+
+// AddUnreleased adds an entry to the Unreleased section.
+func (c *Changelog) AddUnreleased(category, text string) {
+	c.unreleased.add(category, text)
+}
+
+// SetDate sets the release date of version v, shown in its heading.
+func (c *Changelog) SetDate(v semver.Semver, date time.Time) {
+	c.section(v).date = date
+}
+
+// Markdown renders the changelog.
+func (c *Changelog) Markdown() string {
+	var b strings.Builder
+	c.WriteTo(&b)
+	return b.String()
+}
+
+// WriteTo writes the changelog as Markdown: the Unreleased section if it has
+// entries, then a section for each release line, newest first. A release
+// line is a release and the prereleases before it, so 1.2.0 comes first,
+// followed by 1.2.0-rc.2 and 1.2.0-rc.1 a level down. A line with only
+// prereleases so far is headed by its release marked as upcoming.
+func (c *Changelog) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	title := c.Title
+	if title == "" {
+		title = "Changelog"
+	}
+	fmt.Fprintf(cw, "# %s\n", title)
+	if len(c.unreleased.entries) > 0 {
+		fmt.Fprintf(cw, "\n## [Unreleased]\n")
+		c.unreleased.write(cw, "###")
+	}
+
+	var vs semver.Versions
+	for v := range c.versions {
+		vs = append(vs, v)
+	}
+	sort.Sort(sort.Reverse(vs))
+	for i, v := range vs {
+		release := semver.Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+		if v.Prerelease == "" {
+			fmt.Fprintf(cw, "\n## [%s]%s\n", v, c.versions[v].dated())
+			c.versions[v].write(cw, "###")
+			continue
+		}
+		if i == 0 || vs[i-1].Major != v.Major || vs[i-1].Minor != v.Minor || vs[i-1].Patch != v.Patch {
+			// the first prerelease of a line without a release yet
+			fmt.Fprintf(cw, "\n## [%s] - Upcoming\n", release)
+		}
+		fmt.Fprintf(cw, "\n### [%s]%s\n", v, c.versions[v].dated())
+		c.versions[v].write(cw, "####")
+	}
+	return cw.n, cw.err
+}
+
+// dated returns the date suffix of a section's heading.
+func (s *section) dated() string {
+	if s.date.IsZero() {
+		return ""
+	}
+	return " - " + s.date.Format("2006-01-02")
+}
+
+// write writes the entries of a section, with category headings at the
+// given level.
+func (s *section) write(w io.Writer, level string) {
+	for _, cat := range s.categories() {
+		fmt.Fprintln(w)
+		if cat != "" {
+			fmt.Fprintf(w, "%s %s\n\n", level, cat)
+		}
+		for _, text := range s.entries[cat] {
+			fmt.Fprintf(w, "- %s\n", text)
+		}
+	}
+}
+
+// categories returns the section's categories in the order they're written.
+func (s *section) categories() []string {
+	rank := func(cat string) int {
+		if cat == "" {
+			return -1
+		}
+		for i, c := range Categories {
+			if c == cat {
+				return i
+			}
+		}
+		return len(Categories)
+	}
+	var cats []string
+	for cat := range s.entries {
+		cats = append(cats, cat)
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		if ri, rj := rank(cats[i]), rank(cats[j]); ri != rj {
+			return ri < rj
+		}
+		return cats[i] < cats[j]
+	})
+	return cats
+}
+
+// countingWriter counts the bytes written to w and keeps the first error.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
@@ -0,0 +1,94 @@
+package changelog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/beatgammit/semver"
+)
+
+func TestMarkdown(t *testing.T) {
+	var c Changelog
+	add := func(v, category, text string) {
+		c.Add(semver.MustParse(v), category, text)
+	}
+	add("1.2.0-rc.1", "Added", "Streaming API")
+	add("1.1.0", "Fixed", "Crash on empty input")
+	add("1.2.0", "Fixed", "Timeout in streaming")
+	add("1.10.0-beta.1", "Changed", "New config format")
+	add("1.1.0", "Added", "Retry option")
+	add("1.1.0", "Performance", "Faster parsing")
+	add("1.1.0", "", "Thanks to all contributors")
+	add("1.2.0-rc.2+build.7", "Fixed", "Leak in streaming")
+	c.AddUnreleased("Removed", "Old config format")
+	c.SetDate(semver.MustParse("1.2.0"), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	c.SetDate(semver.MustParse("1.2.0-rc.1"), time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC))
+
+	exp := `# Changelog
+
+## [Unreleased]
+
+### Removed
+
+- Old config format
+
+## [1.10.0] - Upcoming
+
+### [1.10.0-beta.1]
+
+#### Changed
+
+- New config format
+
+## [1.2.0] - 2024-05-01
+
+### Fixed
+
+- Timeout in streaming
+
+### [1.2.0-rc.2]
+
+#### Fixed
+
+- Leak in streaming
+
+### [1.2.0-rc.1] - 2024-04-20
+
+#### Added
+
+- Streaming API
+
+## [1.1.0]
+
+- Thanks to all contributors
+
+### Added
+
+- Retry option
+
+### Fixed
+
+- Crash on empty input
+
+### Performance
+
+- Faster parsing
+`
+	if got := c.Markdown(); got != exp {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, exp)
+	}
+
+	var buf bytes.Buffer
+	n, err := c.WriteTo(&buf)
+	if err != nil || n != int64(len(exp)) {
+		t.Errorf("WriteTo wrote %d bytes, %v", n, err)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	c := Changelog{Title: "Release notes"}
+	if got, exp := c.Markdown(), "# Release notes\n"; got != exp {
+		t.Errorf("%q != %q", got, exp)
+	}
+}
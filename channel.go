@@ -0,0 +1,96 @@
+package semver
+
+import "strings"
+
+// Channel is the release channel a version belongs to, ordered from least to
+// most stable, so ch >= Beta means "beta or better".
+type Channel int
+
+const (
+	Dev Channel = iota
+	Alpha
+	Beta
+	RC
+	Stable
+)
+
+var channelNames = [...]string{"dev", "alpha", "beta", "rc", "stable"}
+
+func (ch Channel) String() string {
+	if ch < Dev || ch > Stable {
+		return "unknown"
+	}
+	return channelNames[ch]
+}
+
+// Classifier maps prerelease keywords to channels.
+type Classifier struct {
+	// Keywords maps lower-case keywords, such as "rc", to channels. A
+	// prerelease is classified by its first identifier, lower-cased and
+	// with any trailing digits removed, so rc.1, RC1 and rc are all rc.
+	Keywords map[string]Channel
+	// Default is the channel of prereleases with no known keyword.
+	Default Channel
+}
+
+// DefaultClassifier is the Classifier used by Semver.Channel and
+// MinChannel.
+var DefaultClassifier = &Classifier{
+	Keywords: map[string]Channel{
+		"dev":      Dev,
+		"nightly":  Dev,
+		"snapshot": Dev,
+		"canary":   Dev,
+		"alpha":    Alpha,
+		"beta":     Beta,
+		"rc":       RC,
+		"pre":      RC,
+		"preview":  RC,
+	},
+	Default: Dev,
+}
+
+// Channel classifies v. Releases are always Stable.
+func (c *Classifier) Channel(v Semver) Channel {
+	if v.Prerelease == "" {
+		return Stable
+	}
+	id := strings.ToLower(v.Prerelease)
+	if i := strings.IndexAny(id, ".-"); i >= 0 {
+		id = id[:i]
+	}
+	id = strings.TrimRight(id, "0123456789")
+	if ch, ok := c.Keywords[id]; ok {
+		return ch
+	}
+	return c.Default
+}
+
+// MinChannel returns a FilterOption that skips versions less stable than
+// min, as classified by c.
+func (c *Classifier) MinChannel(min Channel) FilterOption {
+	return func(o *filterOptions) {
+		o.predicates = append(o.predicates, func(v Semver) bool {
+			return c.Channel(v) >= min
+		})
+	}
+}
+
+// Channel classifies v with DefaultClassifier.
+func (v Semver) Channel() Channel {
+	return DefaultClassifier.Channel(v)
+}
+
+// MinChannel skips versions less stable than min, as classified by
+// DefaultClassifier, so MinChannel(Beta) allows betas, release candidates
+// and releases.
+func MinChannel(min Channel) FilterOption {
+	return DefaultClassifier.MinChannel(min)
+}
+
+// FilterChannel returns the versions at least as stable as min, in their
+// original order, as classified by DefaultClassifier. FilterChannel(vs,
+// Stable) keeps only releases.
+func FilterChannel(versions []Semver, min Channel) []Semver {
+	return filter(versions, newFilterOptions([]FilterOption{MinChannel(min)}))
+}
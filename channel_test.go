@@ -0,0 +1,65 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+type channelTest struct {
+	given  string
+	exp    Channel
+	reason string
+}
+
+func TestChannel(t *testing.T) {
+	tests := []channelTest{
+		{"1.0.0", Stable, "release"},
+		{"1.0.0+build", Stable, "release with build metadata"},
+		{"1.0.0-rc.1", RC, "rc"},
+		{"1.0.0-RC1", RC, "upper case, trailing digits"},
+		{"1.0.0-beta", Beta, "beta"},
+		{"1.0.0-beta2.x", Beta, "trailing digits in the first identifier"},
+		{"1.0.0-alpha.3", Alpha, "alpha"},
+		{"1.0.0-preview-2", RC, "hyphenated"},
+		{"1.0.0-nightly.20240101", Dev, "nightly"},
+		{"1.0.0-0.20240101120000-abcdef123456", Dev, "pseudo-version"},
+		{"1.0.0-foo", Dev, "unknown keyword"},
+	}
+
+	for _, test := range tests {
+		if ch := MustParse(test.given).Channel(); ch != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, ch, test.exp)
+		}
+	}
+	if !(Beta > Alpha && RC > Beta && Stable > RC && Alpha > Dev) {
+		t.Errorf("channels should be ordered by stability")
+	}
+	if Channel(42).String() != "unknown" {
+		t.Errorf("unexpected name for an unknown channel")
+	}
+
+	custom := &Classifier{Keywords: map[string]Channel{"milestone": Beta, "m": Beta}, Default: Alpha}
+	if ch := custom.Channel(MustParse("1.0.0-M3")); ch != Beta {
+		t.Errorf("custom keyword: %s", ch)
+	}
+	if ch := custom.Channel(MustParse("1.0.0-rc.1")); ch != Alpha {
+		t.Errorf("custom default: %s", ch)
+	}
+}
+
+func TestFilterChannel(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.0.0", "1.1.0-alpha.1", "1.1.0-beta.1", "1.1.0-rc.1", "1.1.0-nightly", "1.0.1"})
+
+	if got := Versions(FilterChannel(vs, Stable)).Strings(); !reflect.DeepEqual(got, []string{"1.0.0", "1.0.1"}) {
+		t.Errorf("stable: %q", got)
+	}
+	if got := Versions(FilterChannel(vs, Beta)).Strings(); !reflect.DeepEqual(got, []string{"1.0.0", "1.1.0-beta.1", "1.1.0-rc.1", "1.0.1"}) {
+		t.Errorf("beta or better: %q", got)
+	}
+	if v, ok := Latest(vs, MinChannel(Beta)); !ok || v != MustParse("1.1.0-rc.1") {
+		t.Errorf("latest beta or better: %s", v)
+	}
+	if v, ok := Latest(vs, MinChannel(Dev), WithConstraint(MustParseConstraint("<1.1.0-beta", IncludePrerelease()))); !ok || v != MustParse("1.1.0-alpha.1") {
+		t.Errorf("latest below beta: %s", v)
+	}
+}
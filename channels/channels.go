@@ -0,0 +1,229 @@
+// Package channels keeps track of release channels such as nightly, beta and
+// stable: which version each one currently serves, and which versions have
+// ever been in it, so that a version can only be promoted to a channel after
+// it has been through the one before.
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/beatgammit/semver"
+)
+
+// Manager maintains a fixed list of channels, ordered from least to most
+// stable. A version enters the first channel by being published, and each
+// other channel only by promotion from the channel before it. A Manager is
+// safe for concurrent use.
+type Manager struct {
+	mu       sync.Mutex
+	channels []*channel
+}
+
+type channel struct {
+	name    string
+	head    *semver.Semver
+	history []semver.Semver // every version that has been the head, in order
+}
+
+// passed reports whether v has ever been in the channel.
+func (ch *channel) passed(v semver.Semver) bool {
+	for _, h := range ch.history {
+		if h == v {
+			return true
+		}
+	}
+	return false
+}
+
+// New returns a Manager for the named channels, given least stable first, as
+// in New("nightly", "beta", "stable"). It panics if no names are given or a
+// name is repeated.
+func New(names ...string) *Manager {
+	m, err := newManager(names)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func newManager(names []string) (*Manager, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("No channels given")
+	}
+	m := &Manager{}
+	for _, name := range names {
+		if m.lookup(name) != nil {
+			return nil, fmt.Errorf("Repeated channel %q", name)
+		}
+		m.channels = append(m.channels, &channel{name: name})
+	}
+	return m, nil
+}
+
+// lookup returns the named channel, or nil if there isn't one.
+func (m *Manager) lookup(name string) *channel {
+	for _, ch := range m.channels {
+		if ch.name == name {
+			return ch
+		}
+	}
+	return nil
+}
+
+func (m *Manager) index(name string) (int, error) {
+	for i, ch := range m.channels {
+		if ch.name == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("Unknown channel %q", name)
+}
+
+// Channels returns the names of the channels, least stable first.
+func (m *Manager) Channels() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, len(m.channels))
+	for i, ch := range m.channels {
+		names[i] = ch.name
+	}
+	return names
+}
+
+// Head returns the version the named channel serves, or false if it has
+// none yet or there's no such channel.
+func (m *Manager) Head(name string) (semver.Semver, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if ch := m.lookup(name); ch != nil && ch.head != nil {
+		return *ch.head, true
+	}
+	return semver.Semver{}, false
+}
+
+// Passed reports whether v has ever been in the named channel.
+func (m *Manager) Passed(name string, v semver.Semver) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ch := m.lookup(name)
+	return ch != nil && ch.passed(v)
+}
+
+// Publish makes v the head of the first, least stable, channel.
+func (m *Manager) Publish(v semver.Semver) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.promote(0, v)
+}
+
+// Promote makes v the head of the named channel. v must have been in the
+// channel before it, and be newer than the channel's current head; use
+// Rollback to go back to an older version.
+func (m *Manager) Promote(name string, v semver.Semver) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i, err := m.index(name)
+	if err != nil {
+		return err
+	}
+	return m.promote(i, v)
+}
+
+func (m *Manager) promote(i int, v semver.Semver) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	ch := m.channels[i]
+	if i > 0 && !m.channels[i-1].passed(v) {
+		return fmt.Errorf("Cannot promote %s to %s: it has not been in %s", v, ch.name, m.channels[i-1].name)
+	}
+	if ch.head != nil && v.Cmp(*ch.head) <= 0 {
+		return fmt.Errorf("Cannot promote %s to %s: it is not newer than %s", v, ch.name, *ch.head)
+	}
+	ch.head = &v
+	ch.history = append(ch.history, v)
+	return nil
+}
+
+// Rollback makes v, which must have been in the named channel before, its
+// head again.
+func (m *Manager) Rollback(name string, v semver.Semver) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i, err := m.index(name)
+	if err != nil {
+		return err
+	}
+	ch := m.channels[i]
+	if !ch.passed(v) {
+		return fmt.Errorf("Cannot roll %s back to %s: it has not been in %s", ch.name, v, ch.name)
+	}
+	ch.head = &v
+	return nil
+}
+
+// state is the JSON form of a Manager.
+type state struct {
+	Channels []channelState `json:"channels"`
+}
+
+type channelState struct {
+	Name    string          `json:"name"`
+	Head    *semver.Semver  `json:"head,omitempty"`
+	History []semver.Semver `json:"history"`
+}
+
+// MarshalJSON encodes the channels, their heads and their histories.
+func (m *Manager) MarshalJSON() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var s state
+	for _, ch := range m.channels {
+		history := ch.history
+		if history == nil {
+			history = []semver.Semver{}
+		}
+		s.Channels = append(s.Channels, channelState{ch.name, ch.head, history})
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON decodes the state written by MarshalJSON, checking that it
+// follows the promotion rules, and leaves m unchanged on error.
+func (m *Manager) UnmarshalJSON(data []byte) error {
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	names := make([]string, len(s.Channels))
+	for i, ch := range s.Channels {
+		names[i] = ch.Name
+	}
+	loaded, err := newManager(names)
+	if err != nil {
+		return fmt.Errorf("Invalid channel state: %s", err)
+	}
+	for i, cs := range s.Channels {
+		ch := loaded.channels[i]
+		for _, v := range cs.History {
+			if i > 0 && !loaded.channels[i-1].passed(v) {
+				return fmt.Errorf("Invalid channel state: %s in %s has not been in %s", v, ch.name, loaded.channels[i-1].name)
+			}
+		}
+		ch.history = cs.History
+		if cs.Head != nil {
+			if !ch.passed(*cs.Head) {
+				return fmt.Errorf("Invalid channel state: head %s of %s is not in its history", *cs.Head, ch.name)
+			}
+			head := *cs.Head
+			ch.head = &head
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.channels = loaded.channels
+	return nil
+}
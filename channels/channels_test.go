@@ -0,0 +1,119 @@
+package channels
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+func TestPromote(t *testing.T) {
+	m := New("nightly", "beta", "stable")
+	v1, v2 := semver.MustParse("1.0.0"), semver.MustParse("1.1.0-rc.1")
+
+	if err := m.Promote("beta", v1); err == nil {
+		t.Errorf("expected an error promoting an unpublished version")
+	}
+	if err := m.Publish(v1); err != nil {
+		t.Fatalf("error publishing %s: %s", v1, err)
+	}
+	if err := m.Promote("stable", v1); err == nil {
+		t.Errorf("expected an error promoting to stable before beta")
+	}
+	if err := m.Promote("beta", v1); err != nil {
+		t.Fatalf("error promoting %s to beta: %s", v1, err)
+	}
+	if err := m.Promote("stable", v1); err != nil {
+		t.Fatalf("error promoting %s to stable: %s", v1, err)
+	}
+	if err := m.Publish(v1); err == nil {
+		t.Errorf("expected an error republishing the head")
+	}
+	if err := m.Publish(v2); err != nil {
+		t.Fatalf("error publishing %s: %s", v2, err)
+	}
+	if err := m.Promote("beta", v2); err != nil {
+		t.Fatalf("error promoting %s to beta: %s", v2, err)
+	}
+	if err := m.Promote("beta", v1); err == nil {
+		t.Errorf("expected an error promoting an older version")
+	}
+	if err := m.Promote("canary", v1); err == nil {
+		t.Errorf("expected an error for an unknown channel")
+	}
+
+	heads := map[string]semver.Semver{"nightly": v2, "beta": v2, "stable": v1}
+	for name, exp := range heads {
+		if v, ok := m.Head(name); !ok || v != exp {
+			t.Errorf("%s head %s, %t != %s", name, v, ok, exp)
+		}
+	}
+	if !m.Passed("beta", v1) || m.Passed("stable", v2) {
+		t.Errorf("unexpected history")
+	}
+
+	if err := m.Rollback("beta", v1); err != nil {
+		t.Errorf("error rolling back beta: %s", err)
+	} else if v, _ := m.Head("beta"); v != v1 {
+		t.Errorf("beta head %s != %s after rollback", v, v1)
+	}
+	if err := m.Rollback("stable", v2); err == nil {
+		t.Errorf("expected an error rolling back to a version that wasn't in the channel")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	m := New("beta", "stable")
+	v1, v2 := semver.MustParse("1.0.0"), semver.MustParse("2.0.0")
+	for _, err := range []error{m.Publish(v1), m.Promote("stable", v1), m.Publish(v2)} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("error marshaling: %s", err)
+	}
+	exp := `{"channels":[{"name":"beta","head":"2.0.0","history":["1.0.0","2.0.0"]},{"name":"stable","head":"1.0.0","history":["1.0.0"]}]}`
+	if string(data) != exp {
+		t.Errorf("%s != %s", data, exp)
+	}
+
+	loaded := new(Manager)
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("error unmarshaling: %s", err)
+	}
+	if !reflect.DeepEqual(loaded.Channels(), []string{"beta", "stable"}) {
+		t.Errorf("channels %v", loaded.Channels())
+	}
+	if err := loaded.Promote("stable", v2); err != nil {
+		t.Errorf("error promoting after loading: %s", err)
+	}
+
+	empty, _ := json.Marshal(New("nightly"))
+	if exp := `{"channels":[{"name":"nightly","history":[]}]}`; string(empty) != exp {
+		t.Errorf("%s != %s", empty, exp)
+	}
+
+	bad := []struct {
+		given  string
+		reason string
+	}{
+		{`{"channels":[]}`, "no channels"},
+		{`{"channels":[{"name":"a","history":[]},{"name":"a","history":[]}]}`, "repeated channel"},
+		{`{"channels":[{"name":"beta","history":[]},{"name":"stable","history":["1.0.0"]}]}`, "skipped beta"},
+		{`{"channels":[{"name":"beta","head":"2.0.0","history":["1.0.0"]}]}`, "head not in history"},
+		{`{"channels":[{"name":"beta","history":["1.0"]}]}`, "invalid version"},
+	}
+
+	for _, test := range bad {
+		m := New("x")
+		if err := json.Unmarshal([]byte(test.given), m); err == nil {
+			t.Errorf("%s: expected error", test.reason)
+		} else if !reflect.DeepEqual(m.Channels(), []string{"x"}) {
+			t.Errorf("%s: manager changed on error", test.reason)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package cli helps command-line tools built on github.com/spf13/pflag and
+// github.com/spf13/cobra accept semantic versions as flags and arguments.
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beatgammit/semver"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Value is a pflag.Value that parses into a semver.Semver.
+type Value semver.Semver
+
+var _ pflag.Value = (*Value)(nil)
+
+// NewValue sets *p to def and returns it as a pflag.Value.
+func NewValue(def semver.Semver, p *semver.Semver) *Value {
+	*p = def
+	return (*Value)(p)
+}
+
+func (v *Value) String() string {
+	return (*semver.Semver)(v).String()
+}
+
+// Set parses s, leaving the value unchanged on error.
+func (v *Value) Set(s string) error {
+	return (*semver.Semver)(v).Set(s)
+}
+
+// Type names the flag's type in usage messages.
+func (v *Value) Type() string {
+	return "semver"
+}
+
+// VersionVarP defines a version flag with a shorthand, like
+// pflag.FlagSet.StringVarP.
+func VersionVarP(fs *pflag.FlagSet, p *semver.Semver, name, shorthand string, def semver.Semver, usage string) {
+	fs.VarP(NewValue(def, p), name, shorthand, usage)
+}
+
+// VersionArgs is a cobra.PositionalArgs that requires every argument to be a
+// valid version.
+func VersionArgs(cmd *cobra.Command, args []string) error {
+	for i, arg := range args {
+		if _, err := semver.Parse(arg); err != nil {
+			return fmt.Errorf("argument %d: %s", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ExactVersionArgs requires exactly n arguments, all valid versions.
+func ExactVersionArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != n {
+			return fmt.Errorf("accepts %d version arg(s), received %d", n, len(args))
+		}
+		return VersionArgs(cmd, args)
+	}
+}
+
+// Suggest returns the versions that start with toComplete, newest first.
+// Strings that aren't valid versions are left out.
+func Suggest(versions []string, toComplete string) []string {
+	type candidate struct {
+		s string
+		v semver.Semver
+	}
+	var cs []candidate
+	for _, s := range versions {
+		if !strings.HasPrefix(s, toComplete) {
+			continue
+		}
+		if v, err := semver.Parse(s); err == nil {
+			cs = append(cs, candidate{s, v})
+		}
+	}
+	sort.SliceStable(cs, func(i, j int) bool {
+		return cs[i].v.Cmp(cs[j].v) > 0
+	})
+	out := make([]string, len(cs))
+	for i, c := range cs {
+		out[i] = c.s
+	}
+	return out
+}
+
+// CompleteVersions returns a cobra.Command.ValidArgsFunction that completes
+// arguments from versions, newest first.
+func CompleteVersions(versions []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return Suggest(versions, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	}
+}
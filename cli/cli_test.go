@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/beatgammit/semver"
+	"github.com/spf13/cobra"
+)
+
+func TestValue(t *testing.T) {
+	cmd := &cobra.Command{Use: "deploy"}
+	var min semver.Semver
+	VersionVarP(cmd.Flags(), &min, "min-version", "m", semver.MustParse("1.0.0"), "minimum version")
+
+	f := cmd.Flags().Lookup("min-version")
+	if f.DefValue != "1.0.0" || f.Value.Type() != "semver" {
+		t.Errorf("unexpected flag: %+v", f)
+	}
+	if err := f.Value.Set("1.4.0"); err != nil || min != semver.MustParse("1.4.0") {
+		t.Errorf("Set: %s, %v", min, err)
+	}
+	if err := f.Value.Set("1.4"); err == nil || min != semver.MustParse("1.4.0") {
+		t.Errorf("invalid Set: %s, %v", min, err)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	cmd := &cobra.Command{Use: "compare", Args: ExactVersionArgs(2)}
+	if err := cmd.Args(cmd, []string{"1.0.0", "v2.0.0-rc.1"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := cmd.Args(cmd, []string{"1.0.0"}); err == nil {
+		t.Errorf("expected count error")
+	}
+	if err := cmd.Args(cmd, []string{"1.0.0", "latest"}); err == nil {
+		t.Errorf("expected parse error")
+	}
+	if err := VersionArgs(cmd, nil); err != nil {
+		t.Errorf("no arguments are all valid: %s", err)
+	}
+}
+
+func TestCompleteVersions(t *testing.T) {
+	cmd := &cobra.Command{Use: "install"}
+	cmd.ValidArgsFunction = CompleteVersions([]string{"v1.2.0", "v1.10.0", "v1.9.1", "v2.0.0", "nightly"})
+
+	got, directive := cmd.ValidArgsFunction(cmd, nil, "v1.")
+	if exp := []string{"v1.10.0", "v1.9.1", "v1.2.0"}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("%q != %q", got, exp)
+	}
+	if directive&cobra.ShellCompDirectiveNoFileComp == 0 || directive&cobra.ShellCompDirectiveKeepOrder == 0 {
+		t.Errorf("unexpected directive %d", directive)
+	}
+	if got := Suggest([]string{"nightly", "1.0.0"}, ""); !reflect.DeepEqual(got, []string{"1.0.0"}) {
+		t.Errorf("invalid versions should be skipped: %q", got)
+	}
+}
@@ -0,0 +1,13 @@
+module github.com/beatgammit/semver/cli
+
+go 1.25.0
+
+require (
+	github.com/beatgammit/semver v0.0.0-00010101000000-000000000000
+	github.com/spf13/cobra v1.10.2
+	github.com/spf13/pflag v1.0.10
+)
+
+require github.com/inconshreveable/mousetrap v1.1.0 // indirect
+
+replace github.com/beatgammit/semver => ..
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/beatgammit/semver"
+)
+
+func init() {
+	commands["bump"] = command{"print the next version", bump}
+}
+
+// bumpVersion applies a bump level to v. If zeroMinor is set, a 0.x version
+// treats minor as its major and patch as its minor, as Cargo does.
+func bumpVersion(v semver.Semver, level, preid string, zeroMinor bool) (semver.Semver, error) {
+	if zeroMinor && v.Major == 0 {
+		switch level {
+		case "major":
+			level = "minor"
+		case "minor":
+			level = "patch"
+		}
+	}
+	switch level {
+	case "major":
+		return v.NextMajor(), nil
+	case "minor":
+		return v.NextMinor(), nil
+	case "patch":
+		return v.NextPatch(), nil
+	case "prerelease":
+		return v.NextPrerelease(preid), nil
+	}
+	return v, fmt.Errorf("unknown bump level %q", level)
+}
+
+// bumpResult is the -json form of bump's output.
+type bumpResult struct {
+	From  version `json:"from"`
+	Level string  `json:"level"`
+	To    version `json:"to"`
+}
+
+func bump(e *env, args []string) int {
+	fs := e.flags("bump", "[flags] major|minor|patch|prerelease version")
+	preid := fs.String("preid", "", "prerelease identifier, such as rc, for a prerelease bump")
+	build := fs.String("build", "", "build metadata to add to the result")
+	zero := fs.String("zero", "major", "how 0.x versions bump: major goes to 1.0.0 on a major bump; minor treats\nminor as the major version and patch as the minor version")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *zero != "major" && *zero != "minor" {
+		e.errorf("-zero must be major or minor")
+		return exitUsage
+	}
+
+	v, err := semver.Parse(fs.Arg(1))
+	if err != nil {
+		e.errorf("invalid version %q: %s", fs.Arg(1), err)
+		return exitUsage
+	}
+	next, err := bumpVersion(v, fs.Arg(0), *preid, *zero == "minor")
+	if err != nil {
+		e.errorf("%s", err)
+		return exitUsage
+	}
+	next.Build = *build
+	if err := next.Validate(); err != nil {
+		e.errorf("%s", err)
+		return exitUsage
+	}
+	if !e.json {
+		fmt.Fprintln(e.stdout, next)
+	}
+	return e.result(bumpResult{newVersion(fs.Arg(1), v), fs.Arg(0), newVersion("", next)}, exitOK)
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestBump(t *testing.T) {
+	tests := []cliTest{
+		{"", []string{"bump", "major", "1.2.3"}, exitOK, "2.0.0\n", "major"},
+		{"", []string{"bump", "minor", "v1.2.3"}, exitOK, "1.3.0\n", "minor"},
+		{"", []string{"bump", "patch", "1.2.3-rc.1"}, exitOK, "1.2.3\n", "patch of a prerelease"},
+		{"", []string{"bump", "-preid", "rc", "prerelease", "1.2.3"}, exitOK, "1.2.4-rc.0\n", "prerelease"},
+		{"", []string{"bump", "-preid", "rc", "prerelease", "1.2.4-rc.0"}, exitOK, "1.2.4-rc.1\n", "next prerelease"},
+		{"", []string{"bump", "-build", "sha.abc", "patch", "1.2.3"}, exitOK, "1.2.4+sha.abc\n", "build metadata"},
+		{"", []string{"bump", "major", "0.4.2"}, exitOK, "1.0.0\n", "0.x major"},
+		{"", []string{"bump", "-zero", "minor", "major", "0.4.2"}, exitOK, "0.5.0\n", "0.x major as minor"},
+		{"", []string{"bump", "-zero", "minor", "minor", "0.4.2"}, exitOK, "0.4.3\n", "0.x minor as patch"},
+		{"", []string{"bump", "-zero", "minor", "major", "1.4.2"}, exitOK, "2.0.0\n", "policy only affects 0.x"},
+		{"", []string{"bump", "-zero", "sideways", "major", "1.4.2"}, exitUsage, "", "bad policy"},
+		{"", []string{"bump", "epoch", "1.2.3"}, exitUsage, "", "unknown level"},
+		{"", []string{"bump", "major", "1.2"}, exitUsage, "", "invalid version"},
+		{"", []string{"bump", "-build", "a b", "major", "1.2.3"}, exitUsage, "", "invalid build metadata"},
+		{"", []string{"bump", "major"}, exitUsage, "", "missing version"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
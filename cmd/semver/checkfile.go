@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	commands["check-file"] = command{"check the version recorded in a project file", checkFile}
+}
+
+// checkFileResult is the -json form of check-file's output. Satisfied is
+// only set if a range is given.
+type checkFileResult struct {
+	File      string  `json:"file"`
+	Version   version `json:"version"`
+	Range     string  `json:"range,omitempty"`
+	Satisfied *bool   `json:"satisfied,omitempty"`
+}
+
+// checkFile extracts the version from a project file, prints it and, with
+// -range, exits 1 if it doesn't satisfy the range. The file's name picks how
+// the version is found, unless -jsonpath or -regex says otherwise:
+//
+//	package.json, *.json  the top-level "version" field
+//	Cargo.toml            version in [package]
+//	pyproject.toml        version in [project] or [tool.poetry]
+//	anything else         the whole file, as in a VERSION file
+func checkFile(e *env, args []string) int {
+	fs := e.flags("check-file", "[flags] file")
+	rng := fs.String("range", "", "exit 1 unless the version satisfies this constraint")
+	includePre := fs.Bool("include-prerelease", false, "let prereleases in the range match")
+	jsonPath := fs.String("jsonpath", "", "read the version from this JSON path, such as $.tool.version")
+	re := fs.String("regex", "", "read the version from the first submatch of this regular expression")
+	lenient := fs.Bool("lenient", false, "accept loose forms such as v1.2")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 || *jsonPath != "" && *re != "" {
+		fs.Usage()
+		return exitUsage
+	}
+	c, err := parseRange(*rng, *includePre)
+	if err != nil {
+		e.errorf("%s", err)
+		return exitUsage
+	}
+
+	name := fs.Arg(0)
+	data, err := os.ReadFile(name)
+	if err != nil {
+		e.errorf("%s", err)
+		return exitFail
+	}
+	var raw string
+	switch base := filepath.Base(name); {
+	case *re != "":
+		raw, err = regexpVersion(data, *re)
+	case *jsonPath != "":
+		raw, err = jsonVersion(data, *jsonPath)
+	case strings.EqualFold(filepath.Ext(base), ".json"):
+		raw, err = jsonVersion(data, "version")
+	case base == "Cargo.toml":
+		raw, err = tomlVersion(data, "package")
+	case base == "pyproject.toml":
+		raw, err = tomlVersion(data, "project", "tool.poetry")
+	default:
+		raw = string(bytes.TrimSpace(data))
+	}
+	if err != nil {
+		e.errorf("%s: %s", name, err)
+		return exitFail
+	}
+	v, err := parser(*lenient)(raw)
+	if err != nil {
+		e.errorf("%s: invalid version %q: %s", name, raw, err)
+		return exitFail
+	}
+
+	res := checkFileResult{File: name, Version: newVersion(raw, v)}
+	code := exitOK
+	if *rng != "" {
+		ok := c.Check(v)
+		res.Range, res.Satisfied = *rng, &ok
+		if !ok {
+			e.errorf("%s: version %s does not satisfy %s", name, v, *rng)
+			code = exitFail
+		}
+	}
+	if !e.json {
+		fmt.Fprintln(e.stdout, v)
+	}
+	return e.result(res, code)
+}
+
+// jsonVersion returns the string at a dotted path, such as $.tool.version
+// or releases.0.version, in a JSON document.
+func jsonVersion(data []byte, path string) (string, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	for _, key := range strings.Split(path, ".") {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if doc, ok = node[key]; !ok {
+				return "", fmt.Errorf("no %s in %s", key, path)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return "", fmt.Errorf("no element %s in %s", key, path)
+			}
+			doc = node[i]
+		default:
+			return "", fmt.Errorf("no %s in %s", key, path)
+		}
+	}
+	s, ok := doc.(string)
+	if !ok {
+		return "", fmt.Errorf("%s is not a string", path)
+	}
+	return s, nil
+}
+
+var (
+	tomlTableRe   = regexp.MustCompile(`^\[\s*([A-Za-z0-9_.\-"]+)\s*\]\s*(?:#.*)?$`)
+	tomlVersionRe = regexp.MustCompile(`^version\s*=\s*(?:"([^"]*)"|'([^']*)')\s*(?:#.*)?$`)
+)
+
+// tomlVersion returns the version key of the first of the given tables that
+// has one. It understands just enough TOML for project manifests: table
+// headers and version = "..." lines.
+func tomlVersion(data []byte, tables ...string) (string, error) {
+	found := make(map[string]string)
+	table := ""
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if m := tomlTableRe.FindStringSubmatch(line); m != nil {
+			table = strings.Replace(m[1], `"`, "", -1)
+		} else if m := tomlVersionRe.FindStringSubmatch(line); m != nil {
+			if _, ok := found[table]; !ok {
+				found[table] = m[1] + m[2]
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	for _, t := range tables {
+		if v, ok := found[t]; ok {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("no version in [%s]", strings.Join(tables, "] or ["))
+}
+
+// regexpVersion returns the first submatch of expr in data, or the whole
+// match if expr has no groups.
+func regexpVersion(data []byte, expr string) (string, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return "", err
+	}
+	m := re.FindSubmatch(data)
+	if m == nil {
+		return "", fmt.Errorf("no match for %s", expr)
+	}
+	if len(m) > 1 {
+		return string(m[1]), nil
+	}
+	return string(m[0]), nil
+}
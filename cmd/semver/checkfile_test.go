@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json": `{"name": "app", "version": "2.3.1", "engines": {"node": ">=18"}}`,
+		"Cargo.toml": `[package]
+name = "app"
+version = "0.4.0" # bumped by the release script
+
+[dependencies]
+serde = { version = "1.0" }
+
+[dev-dependencies.criterion]
+version = "0.5.1"
+`,
+		"pyproject.toml": `[build-system]
+requires = ["poetry-core"]
+
+[tool.poetry]
+name = 'app'
+version = '1.2.0rc1'
+`,
+		"VERSION":   "v3.0.0-beta.2\n",
+		"app.json":  `{"releases": [{"version": "1.0.0"}, {"version": "1.1.0"}]}`,
+		"Chart.txt": "appVersion: 5.6.7\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []cliTest{
+		{"", []string{"check-file", path("package.json")}, exitOK, "2.3.1\n", "package.json"},
+		{"", []string{"check-file", "--range", "^2", path("package.json")}, exitOK, "2.3.1\n", "satisfied"},
+		{"", []string{"check-file", "--range", "^3", path("package.json")}, exitFail, "2.3.1\n", "violated"},
+		{"", []string{"check-file", "--range", "~0.4", path("Cargo.toml")}, exitOK, "0.4.0\n", "Cargo.toml ignores other tables"},
+		{"", []string{"check-file", path("pyproject.toml")}, exitFail, "", "PEP 440 version"},
+		{"", []string{"check-file", "-regex", `version = '(\d+\.\d+\.\d+)`, path("pyproject.toml")}, exitOK, "1.2.0\n", "regex"},
+		{"", []string{"check-file", path("VERSION")}, exitOK, "3.0.0-beta.2\n", "VERSION"},
+		{"", []string{"check-file", "-range", ">=2", path("VERSION")}, exitFail, "3.0.0-beta.2\n", "prerelease excluded"},
+		{"", []string{"check-file", "-range", ">=2", "-include-prerelease", path("VERSION")}, exitOK, "3.0.0-beta.2\n", "prerelease included"},
+		{"", []string{"check-file", "-jsonpath", "$.releases.1.version", path("app.json")}, exitOK, "1.1.0\n", "jsonpath"},
+		{"", []string{"check-file", path("app.json")}, exitFail, "", "no top-level version"},
+		{"", []string{"check-file", "-jsonpath", "engines.node", path("package.json")}, exitFail, "", "not a version"},
+		{"", []string{"check-file", "-regex", `appVersion: (\S+)`, path("Chart.txt")}, exitOK, "5.6.7\n", "regex on any file"},
+		{"", []string{"check-file", path("missing.json")}, exitFail, "", "missing file"},
+		{"", []string{"check-file", "-range", "^^1", path("VERSION")}, exitUsage, "", "invalid range"},
+		{"", []string{"check-file", "-regex", "x", "-jsonpath", "y", path("VERSION")}, exitUsage, "", "conflicting flags"},
+		{"", []string{"check-file"}, exitUsage, "", "missing file argument"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/beatgammit/semver"
+)
+
+func init() {
+	commands["coerce"] = command{"extract the version from text such as --version output", coerce}
+}
+
+// coerce prints the first version found in each input, in canonical form.
+func coerce(e *env, args []string) int {
+	fs := e.flags("coerce", "[text...]")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	inputs, err := e.inputs(fs.Args())
+	if err != nil {
+		e.errorf("%s", err)
+		return exitFail
+	}
+	code := exitOK
+	res := listResult{Versions: []version{}}
+	for _, s := range inputs {
+		v, err := semver.Extract(s)
+		if err != nil {
+			e.errorf("%s", err)
+			res.Rejected = append(res.Rejected, rejection{s, err.Error()})
+			code = exitFail
+			continue
+		}
+		if e.json {
+			res.Versions = append(res.Versions, newVersion(s, v))
+		} else {
+			fmt.Fprintln(e.stdout, v)
+		}
+	}
+	return e.result(res, code)
+}
@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestCoerce(t *testing.T) {
+	tests := []cliTest{
+		{"", []string{"coerce", "nginx/1.25.4 (ubuntu)"}, exitOK, "1.25.4\n", "argument"},
+		{"", []string{"coerce", "v1.2", "Python 3.12.1"}, exitOK, "1.2.0\n3.12.1\n", "several arguments"},
+		{"go version go1.22.3 linux/amd64\n", []string{"coerce"}, exitOK, "1.22.3\n", "stdin"},
+		{"", []string{"coerce", "unknown"}, exitFail, "", "no version"},
+		{"", []string{"coerce", "none", "1.2"}, exitFail, "1.2.0\n", "keeps going after a failure"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/beatgammit/semver"
+)
+
+func init() {
+	commands["compare"] = command{"compare two versions", compare}
+}
+
+// operators maps comparison operators, and their shell-friendly spellings, to
+// a test of the result of Cmp.
+var operators = map[string]func(int) bool{
+	"<":  func(c int) bool { return c < 0 },
+	"<=": func(c int) bool { return c <= 0 },
+	">":  func(c int) bool { return c > 0 },
+	">=": func(c int) bool { return c >= 0 },
+	"==": func(c int) bool { return c == 0 },
+	"=":  func(c int) bool { return c == 0 },
+	"!=": func(c int) bool { return c != 0 },
+	"lt": func(c int) bool { return c < 0 },
+	"le": func(c int) bool { return c <= 0 },
+	"gt": func(c int) bool { return c > 0 },
+	"ge": func(c int) bool { return c >= 0 },
+	"eq": func(c int) bool { return c == 0 },
+	"ne": func(c int) bool { return c != 0 },
+}
+
+// compareResult is the -json form of compare's output. Holds is only set
+// when an operator is given.
+type compareResult struct {
+	A     version `json:"a"`
+	Op    string  `json:"op,omitempty"`
+	B     version `json:"b"`
+	Cmp   int     `json:"cmp"`
+	Holds *bool   `json:"holds,omitempty"`
+}
+
+// compare runs "semver compare A OP B", which exits 0 if the comparison holds
+// and 1 if not, or "semver compare A B", which prints -1, 0 or 1.
+func compare(e *env, args []string) int {
+	fs := e.flags("compare", "version [op] version")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	args = fs.Args()
+	var a, op, b string
+	switch len(args) {
+	case 2:
+		a, b = args[0], args[1]
+	case 3:
+		a, op, b = args[0], args[1], args[2]
+	default:
+		fs.Usage()
+		return exitUsage
+	}
+
+	test, ok := operators[op]
+	if op != "" && !ok {
+		e.errorf("unknown operator %q", op)
+		return exitUsage
+	}
+	va, err := semver.Parse(a)
+	if err != nil {
+		e.errorf("invalid version %q: %s", a, err)
+		return exitUsage
+	}
+	vb, err := semver.Parse(b)
+	if err != nil {
+		e.errorf("invalid version %q: %s", b, err)
+		return exitUsage
+	}
+
+	c := sign(va.Cmp(vb))
+	res := compareResult{A: newVersion(a, va), Op: op, B: newVersion(b, vb), Cmp: c}
+	if op == "" {
+		if !e.json {
+			fmt.Fprintln(e.stdout, c)
+		}
+		return e.result(res, exitOK)
+	}
+	holds := test(c)
+	res.Holds = &holds
+	if !holds {
+		return e.result(res, exitFail)
+	}
+	return e.result(res, exitOK)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []cliTest{
+		{"", []string{"compare", "1.2.3", "<", "1.3.0"}, exitOK, "", "less"},
+		{"", []string{"compare", "1.3.0", "<", "1.2.3"}, exitFail, "", "not less"},
+		{"", []string{"compare", "1.0.0-rc.1", "lt", "1.0.0"}, exitOK, "", "prerelease"},
+		{"", []string{"compare", "v1.2.3", "==", "1.2.3+build"}, exitOK, "", "equal ignoring build"},
+		{"", []string{"compare", "1.2.3", "!=", "1.2.3"}, exitFail, "", "not unequal"},
+		{"", []string{"compare", "1.10.0", "ge", "1.9.0"}, exitOK, "", "numeric ordering"},
+		{"", []string{"compare", "1.2.3", "1.3.0"}, exitOK, "-1\n", "print less"},
+		{"", []string{"compare", "1.2.3", "1.2.3"}, exitOK, "0\n", "print equal"},
+		{"", []string{"compare", "2.0.0", "1.99.0"}, exitOK, "1\n", "print greater"},
+		{"", []string{"compare", "1.2.3", "~", "1.2.3"}, exitUsage, "", "unknown operator"},
+		{"", []string{"compare", "1.2", "<", "1.2.3"}, exitUsage, "", "invalid version"},
+		{"", []string{"compare", "1.2.3"}, exitUsage, "", "missing argument"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/beatgammit/semver"
+)
+
+func init() {
+	commands["diff"] = command{"print how much two versions differ", diff}
+}
+
+// diffResult is the -json form of diff's output.
+type diffResult struct {
+	From  version      `json:"from"`
+	To    version      `json:"to"`
+	Level semver.Level `json:"level"`
+	Cmp   int          `json:"cmp"`
+}
+
+// diff prints the most significant component that differs between two
+// versions: major, minor, patch, prerelease, build or none.
+func diff(e *env, args []string) int {
+	fs := e.flags("diff", "version version")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	var vs [2]version
+	for i, s := range fs.Args() {
+		v, err := semver.Parse(s)
+		if err != nil {
+			e.errorf("invalid version %q: %s", s, err)
+			return exitUsage
+		}
+		vs[i] = newVersion(s, v)
+	}
+	from, to := vs[0].Version, vs[1].Version
+	level := semver.Diff(from, to)
+	if !e.json {
+		fmt.Fprintln(e.stdout, level)
+	}
+	return e.result(diffResult{vs[0], vs[1], level, sign(from.Cmp(to))}, exitOK)
+}
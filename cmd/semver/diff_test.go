@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []cliTest{
+		{"", []string{"diff", "1.4.2", "2.0.0-rc.1"}, exitOK, "major\n", "major"},
+		{"", []string{"diff", "v1.4.2", "1.4.3"}, exitOK, "patch\n", "patch"},
+		{"", []string{"diff", "1.2.3-rc.1", "1.2.3"}, exitOK, "prerelease\n", "prerelease"},
+		{"", []string{"diff", "1.2.3", "1.2.3"}, exitOK, "none\n", "equal"},
+		{"", []string{"diff", "-json", "1.4.2", "2.0.0-rc.1"}, exitOK, `{
+  "from": {
+    "input": "1.4.2",
+    "version": "1.4.2",
+    "major": 1,
+    "minor": 4,
+    "patch": 2
+  },
+  "to": {
+    "input": "2.0.0-rc.1",
+    "version": "2.0.0-rc.1",
+    "major": 2,
+    "minor": 0,
+    "patch": 0,
+    "prerelease": "rc.1"
+  },
+  "level": "major",
+  "cmp": -1
+}
+`, "json"},
+		{"", []string{"diff", "1.4.2"}, exitUsage, "", "one version"},
+		{"", []string{"diff", "1.4.2", "two"}, exitUsage, "", "invalid version"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
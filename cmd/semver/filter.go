@@ -0,0 +1,55 @@
+package main
+
+import "github.com/beatgammit/semver"
+
+func init() {
+	commands["filter"] = command{"print versions from stdin that satisfy a range", filter}
+}
+
+// parseRange parses a constraint argument, honoring -include-prerelease.
+func parseRange(s string, includePrerelease bool) (semver.Constraint, error) {
+	var opts []semver.ConstraintOption
+	if includePrerelease {
+		opts = append(opts, semver.IncludePrerelease())
+	}
+	return semver.ParseConstraint(s, opts...)
+}
+
+// filter prints the lines of stdin that satisfy the range, in input order.
+// Like grep, it exits 1 if none do.
+func filter(e *env, args []string) int {
+	fs := e.flags("filter", "[-include-prerelease] [-invalid=skip|fail] range < versions")
+	includePre := fs.Bool("include-prerelease", false, "let prereleases in the range match")
+	invalid := fs.String("invalid", "fail", "what to do with invalid lines: skip or fail")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	skip, err := invalidPolicy(*invalid)
+	if err != nil || fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	c, err := parseRange(fs.Arg(0), *includePre)
+	if err != nil {
+		e.errorf("%s", err)
+		return exitUsage
+	}
+
+	lines, vs, rejected, ok := e.readVersions(skip)
+	if !ok {
+		return e.printList(nil, nil, rejected, exitFail)
+	}
+	var ml []string
+	var mv []semver.Semver
+	for i, v := range vs {
+		if c.Check(v) {
+			ml = append(ml, lines[i])
+			mv = append(mv, v)
+		}
+	}
+	code := exitOK
+	if len(mv) == 0 {
+		code = exitFail
+	}
+	return e.printList(ml, mv, rejected, code)
+}
@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestFilter(t *testing.T) {
+	tags := "v1.3.0\nv1.4.0\nv1.5.0-rc.1\nv1.4.2\nv2.0.0\n"
+	tests := []cliTest{
+		{tags, []string{"filter", "^1.4"}, exitOK, "v1.4.0\nv1.4.2\n", "caret"},
+		{tags, []string{"filter", "-include-prerelease", "^1.4"}, exitOK, "v1.4.0\nv1.5.0-rc.1\nv1.4.2\n", "including prereleases"},
+		{tags, []string{"filter", ">=1.4 <1.4.1 || 2.x"}, exitOK, "v1.4.0\nv2.0.0\n", "alternatives"},
+		{tags, []string{"filter", "^3"}, exitFail, "", "nothing matches"},
+		{tags, []string{"filter", ">>1"}, exitUsage, "", "invalid range"},
+		{tags, []string{"filter"}, exitUsage, "", "missing range"},
+		{"v1.4.0\nlatest\n", []string{"filter", "^1"}, exitFail, "", "invalid input"},
+		{"v1.4.0\nlatest\n", []string{"filter", "-invalid=skip", "^1"}, exitOK, "v1.4.0\n", "invalid input skipped"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
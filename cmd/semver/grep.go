@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/beatgammit/semver"
+)
+
+func init() {
+	commands["grep"] = command{"find versions in files or stdin", grep}
+}
+
+// grepMatch is the -json form of a version grep found.
+type grepMatch struct {
+	File    string  `json:"file"`
+	Line    int     `json:"line"`
+	Column  int     `json:"column"` // in bytes, starting at 1
+	Version version `json:"version"`
+}
+
+// grep prints each version found in the files, or stdin, as file:line:text.
+// Like grep, it exits 1 if nothing is found.
+func grep(e *env, args []string) int {
+	fs := e.flags("grep", "[-unique] [-min version] [-max version] [file...]")
+	unique := fs.Bool("unique", false, "print only the first occurrence of each version")
+	minFlag := fs.String("min", "", "only print versions at least this one")
+	maxFlag := fs.String("max", "", "only print versions at most this one")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	var bounds []func(semver.Semver) bool
+	for _, b := range []struct {
+		s    string
+		sign int
+	}{{*minFlag, 1}, {*maxFlag, -1}} {
+		if b.s == "" {
+			continue
+		}
+		limit, err := semver.Parse(b.s)
+		if err != nil {
+			e.errorf("invalid version %q: %s", b.s, err)
+			return exitUsage
+		}
+		sign := b.sign
+		bounds = append(bounds, func(v semver.Semver) bool { return v.Cmp(limit)*sign >= 0 })
+	}
+
+	g := &grepper{e: e, unique: *unique, bounds: bounds, seen: make(map[semver.Key]bool), matches: []grepMatch{}}
+	code := exitOK
+	if fs.NArg() == 0 {
+		if err := g.scan("-", e.stdin); err != nil {
+			e.errorf("%s", err)
+			code = exitFail
+		}
+	}
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			e.errorf("%s", err)
+			code = exitFail
+			continue
+		}
+		err = g.scan(name, f)
+		f.Close()
+		if err != nil {
+			e.errorf("%s: %s", name, err)
+			code = exitFail
+		}
+	}
+	if code == exitOK && len(g.matches) == 0 {
+		code = exitFail
+	}
+	return e.result(g.matches, code)
+}
+
+type grepper struct {
+	e       *env
+	unique  bool
+	bounds  []func(semver.Semver) bool
+	seen    map[semver.Key]bool
+	matches []grepMatch
+}
+
+// scan reports the versions in r, which is called name.
+func (g *grepper) scan(name string, r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+	matches:
+		for _, m := range semver.FindAll(sc.Text()) {
+			for _, ok := range g.bounds {
+				if !ok(m.Version) {
+					continue matches
+				}
+			}
+			if g.unique {
+				if g.seen[m.Version.Key()] {
+					continue
+				}
+				g.seen[m.Version.Key()] = true
+			}
+			g.matches = append(g.matches, grepMatch{name, n, m.Start + 1, newVersion(m.Text, m.Version)})
+			if !g.e.json {
+				fmt.Fprintf(g.e.stdout, "%s:%d:%s\n", name, n, m.Text)
+			}
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGrep(t *testing.T) {
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	content := "FROM golang:1.22 AS build\nRUN go install tool@v0.4.1\nFROM alpine:3.19.1\nLABEL built-with=go1.22\n"
+	if err := os.WriteFile(dockerfile, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []cliTest{
+		{"", []string{"grep", dockerfile}, exitOK, dockerfile + ":1:1.22\n" + dockerfile + ":2:v0.4.1\n" + dockerfile + ":3:3.19.1\n" + dockerfile + ":4:1.22\n", "file"},
+		{"", []string{"grep", "-unique", dockerfile}, exitOK, dockerfile + ":1:1.22\n" + dockerfile + ":2:v0.4.1\n" + dockerfile + ":3:3.19.1\n", "unique"},
+		{"", []string{"grep", "-min", "1.0.0", "-max", "2.0.0", dockerfile}, exitOK, dockerfile + ":1:1.22\n" + dockerfile + ":4:1.22\n", "bounds"},
+		{"", []string{"grep", "-max", "1.0.0", dockerfile}, exitOK, dockerfile + ":2:v0.4.1\n", "inclusive max"},
+		{"see v2.0.0-rc.1 and 2.0.0\n\nnothing here\n", []string{"grep"}, exitOK, "-:1:v2.0.0-rc.1\n-:1:2.0.0\n", "stdin"},
+		{"nothing here\n", []string{"grep"}, exitFail, "", "no versions"},
+		{"", []string{"grep", "-min", "1.2", dockerfile}, exitUsage, "", "invalid bound"},
+		{"", []string{"grep", filepath.Join(dir, "missing"), dockerfile}, exitFail, dockerfile + ":1:1.22\n" + dockerfile + ":2:v0.4.1\n" + dockerfile + ":3:3.19.1\n" + dockerfile + ":4:1.22\n", "missing file"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+
+	_, stdout, _ := runCLI("x = go1.21.5\n", "-json", "grep")
+	var ms []grepMatch
+	if err := json.Unmarshal([]byte(stdout), &ms); err != nil || len(ms) != 1 {
+		t.Fatalf("json: %v, %s", err, stdout)
+	}
+	if m := ms[0]; m.File != "-" || m.Line != 1 || m.Column != 7 || m.Version.Input != "1.21.5" || m.Version.Minor != 21 {
+		t.Errorf("json: %+v", m)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/beatgammit/semver"
+)
+
+// version is the JSON form of a version and its parsed components.
+type version struct {
+	Input      string        `json:"input,omitempty"` // as given, for versions read from input
+	Version    semver.Semver `json:"version"`
+	Major      int           `json:"major"`
+	Minor      int           `json:"minor"`
+	Patch      int           `json:"patch"`
+	Prerelease string        `json:"prerelease,omitempty"`
+	Build      string        `json:"build,omitempty"`
+}
+
+func newVersion(input string, v semver.Semver) version {
+	return version{input, v, v.Major, v.Minor, v.Patch, v.Prerelease, v.Build}
+}
+
+// rejection is the JSON form of an input that isn't a valid version.
+type rejection struct {
+	Input string `json:"input"`
+	Error string `json:"error"`
+}
+
+// listResult is the JSON output of commands that print versions.
+type listResult struct {
+	Versions []version   `json:"versions"`
+	Rejected []rejection `json:"rejected,omitempty"`
+}
+
+// result writes v to stdout as indented JSON if -json was given. It returns
+// code unless writing fails.
+func (e *env) result(v interface{}, code int) int {
+	if !e.json {
+		return code
+	}
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		e.errorf("%s", err)
+		return exitFail
+	}
+	return code
+}
+
+// printList prints the given versions, one line per version as it was
+// written, or with -json as a listResult including the rejected inputs. It
+// returns code unless writing fails.
+func (e *env) printList(lines []string, vs []semver.Semver, rejected []rejection, code int) int {
+	if !e.json {
+		for _, line := range lines {
+			fmt.Fprintln(e.stdout, line)
+		}
+		return code
+	}
+	res := listResult{Versions: make([]version, len(vs)), Rejected: rejected}
+	for i, v := range vs {
+		res.Versions[i] = newVersion(lines[i], v)
+	}
+	return e.result(res, code)
+}
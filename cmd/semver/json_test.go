@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	tests := []cliTest{
+		{"", []string{"-json", "compare", "1.2.3", "<", "1.2.3"}, exitFail, `{
+  "a": {
+    "input": "1.2.3",
+    "version": "1.2.3",
+    "major": 1,
+    "minor": 2,
+    "patch": 3
+  },
+  "op": "<",
+  "b": {
+    "input": "1.2.3",
+    "version": "1.2.3",
+    "major": 1,
+    "minor": 2,
+    "patch": 3
+  },
+  "cmp": 0,
+  "holds": false
+}
+`, "comparison outcome"},
+		{"", []string{"bump", "--json", "minor", "v1.2.3+b"}, exitOK, `{
+  "from": {
+    "input": "v1.2.3+b",
+    "version": "1.2.3+b",
+    "major": 1,
+    "minor": 2,
+    "patch": 3,
+    "build": "b"
+  },
+  "level": "minor",
+  "to": {
+    "version": "1.3.0",
+    "major": 1,
+    "minor": 3,
+    "patch": 0
+  }
+}
+`, "flag after the command"},
+		{"v2.0.0\n", []string{"--json", "latest", "-range", "^1"}, exitFail, `{
+  "versions": []
+}
+`, "empty list"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
+
+func TestJSONRejected(t *testing.T) {
+	tests := []struct {
+		stdin    string
+		args     []string
+		code     int
+		versions []string
+		rejected []string
+		reason   string
+	}{
+		{"", []string{"-json", "validate", "1.0.0", "bogus", "1.2"}, exitFail, []string{"1.0.0"}, []string{"bogus", "1.2"}, "validate reports every input"},
+		{"v1.1.0\nlatest\nv1.0.0\n", []string{"-json", "sort", "-invalid=skip"}, exitOK, []string{"v1.0.0", "v1.1.0"}, []string{"latest"}, "skipped input"},
+		{"v1.1.0\nlatest\nv1.0.0\n", []string{"-json", "filter", "^1"}, exitFail, nil, []string{"latest"}, "failing input"},
+		{"", []string{"-json", "coerce", "nginx/1.25.4", "none"}, exitFail, []string{"nginx/1.25.4"}, []string{"none"}, "coerce"},
+	}
+
+	for _, test := range tests {
+		code, stdout, stderr := runCLI(test.stdin, test.args...)
+		if code != test.code {
+			t.Errorf("%s: exit %d != %d; stderr: %s", test.reason, code, test.code, stderr)
+		}
+		var res listResult
+		if err := json.Unmarshal([]byte(stdout), &res); err != nil {
+			t.Errorf("%s: invalid JSON: %s; stdout: %s", test.reason, err, stdout)
+			continue
+		}
+		if len(res.Versions) != len(test.versions) || len(res.Rejected) != len(test.rejected) {
+			t.Errorf("%s: %+v, expected versions %q and rejected %q", test.reason, res, test.versions, test.rejected)
+			continue
+		}
+		for i, v := range res.Versions {
+			if v.Input != test.versions[i] {
+				t.Errorf("%s: version %d is %q, expected %q", test.reason, i, v.Input, test.versions[i])
+			}
+		}
+		for i, r := range res.Rejected {
+			if r.Input != test.rejected[i] || r.Error == "" {
+				t.Errorf("%s: rejection %d is %+v, expected %q with a reason", test.reason, i, r, test.rejected[i])
+			}
+		}
+	}
+}
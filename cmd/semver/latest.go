@@ -0,0 +1,44 @@
+package main
+
+import "github.com/beatgammit/semver"
+
+func init() {
+	commands["latest"] = command{"print the newest version from stdin in a range", latest}
+}
+
+// latest prints the line of stdin holding the newest version that satisfies
+// -range, or nothing, exiting 1, if none does.
+func latest(e *env, args []string) int {
+	fs := e.flags("latest", "[-range constraint] [-include-prerelease] [-invalid=skip|fail] < versions")
+	rng := fs.String("range", "", "only consider versions satisfying this constraint")
+	includePre := fs.Bool("include-prerelease", false, "consider prereleases")
+	invalid := fs.String("invalid", "fail", "what to do with invalid lines: skip or fail")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	skip, err := invalidPolicy(*invalid)
+	if err != nil || fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	c, err := parseRange(*rng, *includePre)
+	if err != nil {
+		e.errorf("%s", err)
+		return exitUsage
+	}
+
+	lines, vs, rejected, ok := e.readVersions(skip)
+	if !ok {
+		return e.printList(nil, nil, rejected, exitFail)
+	}
+	best, found := semver.Latest(vs, semver.WithConstraint(c))
+	if !found {
+		return e.printList(nil, nil, rejected, exitFail)
+	}
+	for i, v := range vs {
+		if v == best {
+			return e.printList(lines[i:i+1], vs[i:i+1], rejected, exitOK)
+		}
+	}
+	return exitOK
+}
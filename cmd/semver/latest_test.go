@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestLatest(t *testing.T) {
+	tags := "v1.2.0\nv1.10.0\nv2.0.0-rc.1\nv1.9.3\nv3.0.0\n"
+	tests := []cliTest{
+		{tags, []string{"latest"}, exitOK, "v3.0.0\n", "no range"},
+		{tags, []string{"latest", "-range", ">=1.2 <2"}, exitOK, "v1.10.0\n", "range"},
+		{tags, []string{"latest", "--range", ">=1.2 <3"}, exitOK, "v1.10.0\n", "prereleases excluded"},
+		{tags, []string{"latest", "--range", ">=1.2 <3", "--include-prerelease"}, exitOK, "v2.0.0-rc.1\n", "prereleases included"},
+		{"1.0.0+b\n1.0.0+a\n", []string{"latest"}, exitOK, "1.0.0+b\n", "first of equal precedence"},
+		{tags, []string{"latest", "-range", "^4"}, exitFail, "", "nothing matches"},
+		{"", []string{"latest"}, exitFail, "", "no input"},
+		{tags, []string{"latest", "-range", "^x.1"}, exitUsage, "", "invalid range"},
+		{tags, []string{"latest", "1.0.0"}, exitUsage, "", "unexpected argument"},
+		{"v1.0.0\nlatest\n", []string{"latest", "-invalid=skip"}, exitOK, "v1.0.0\n", "invalid input skipped"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
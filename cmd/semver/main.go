@@ -0,0 +1,130 @@
+// Command semver checks, compares and manipulates semantic versions from the
+// shell.
+//
+// Usage:
+//
+//	semver [-json] <command> [flags] [arguments]
+//
+// Run "semver help" for the list of commands. Every command exits 0 on
+// success, 1 when a check fails and 2 on a usage error.
+//
+// With -json, given before or after the command, results are written to
+// stdout as JSON: versions with their parsed components, comparison outcomes
+// and rejected inputs with the reason why. Errors are still reported on
+// stderr.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitFail  = 1
+	exitUsage = 2
+)
+
+// env is what a command reads from and writes to.
+type env struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	json           bool // write results as JSON
+}
+
+type command struct {
+	summary string
+	run     func(e *env, args []string) int
+}
+
+var commands = map[string]command{}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+	if len(args) > 0 && (args[0] == "-json" || args[0] == "--json") {
+		e.json = true
+		args = args[1:]
+	}
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		e.usage()
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "semver: unknown command %q\n", args[0])
+		e.usage()
+		return exitUsage
+	}
+	return cmd.run(e, args[1:])
+}
+
+func (e *env) usage() {
+	fmt.Fprintln(e.stderr, "usage: semver [-json] <command> [flags] [arguments]")
+	fmt.Fprintln(e.stderr, "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(e.stderr, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
+
+// flags returns a FlagSet for the named command that reports errors to
+// stderr. It accepts -json, like the command line before the command does.
+func (e *env) flags(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.BoolVar(&e.json, "json", e.json, "print results as JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: semver %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args, returning false, with the exit code to use, if the
+// command shouldn't continue.
+func parseFlags(fs *flag.FlagSet, args []string) (ok bool, code int) {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return false, exitOK
+		}
+		return false, exitUsage
+	}
+	return true, exitOK
+}
+
+// errorf reports a failure on stderr.
+func (e *env) errorf(format string, args ...interface{}) {
+	fmt.Fprintf(e.stderr, "semver: "+format+"\n", args...)
+}
+
+// inputs returns args, or the non-blank lines of stdin if there are none.
+func (e *env) inputs(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	var lines []string
+	sc := bufio.NewScanner(e.stdin)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, sc.Err()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// runCLI runs the command line with the given stdin, returning the exit code
+// and output.
+func runCLI(stdin string, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+type cliTest struct {
+	stdin  string
+	args   []string
+	code   int
+	stdout string
+	reason string
+}
+
+func (test cliTest) check(t *testing.T) {
+	code, stdout, stderr := runCLI(test.stdin, test.args...)
+	if code != test.code {
+		t.Errorf("%s: exit %d != %d; stderr: %s", test.reason, code, test.code, stderr)
+	}
+	if stdout != test.stdout {
+		t.Errorf("%s: stdout %q != %q", test.reason, stdout, test.stdout)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	if code, _, stderr := runCLI(""); code != exitUsage || !strings.Contains(stderr, "validate") {
+		t.Errorf("no command: %d, %q", code, stderr)
+	}
+	if code, _, _ := runCLI("", "help"); code != exitOK {
+		t.Errorf("help: %d", code)
+	}
+	if code, _, stderr := runCLI("", "frobnicate"); code != exitUsage || !strings.Contains(stderr, "unknown command") {
+		t.Errorf("unknown command: %d, %q", code, stderr)
+	}
+	if code, _, _ := runCLI("", "validate", "-bogus"); code != exitUsage {
+		t.Errorf("unknown flag: %d", code)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/beatgammit/semver"
+)
+
+func init() {
+	commands["next-tag"] = command{"print the next release tag of the current git repository", nextTag}
+}
+
+// gitTags lists the tags of the repository in the working directory that
+// match a glob pattern. Tests replace it.
+var gitTags = func(pattern string) ([]string, error) {
+	out, err := exec.Command("git", "tag", "--list", pattern).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("git tag: %s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("git tag: %s", err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// nextTagResult is the -json form of next-tag's output. Latest is null if
+// there were no version tags.
+type nextTagResult struct {
+	Latest *version `json:"latest"`
+	Tag    string   `json:"tag"`
+	Next   version  `json:"next"`
+}
+
+// nextTag finds the latest version tag with the prefix, bumps it and prints
+// the tag to create. A repository without version tags starts from 0.0.0.
+func nextTag(e *env, args []string) int {
+	fs := e.flags("next-tag", "[flags]")
+	level := fs.String("bump", "patch", "major, minor, patch or prerelease")
+	prefix := fs.String("prefix", "v", "the prefix of version tags")
+	match := fs.String("match", "", "a glob selecting the tags to consider (default prefix*)")
+	preid := fs.String("preid", "", "prerelease identifier, such as rc, for a prerelease bump")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *match == "" {
+		*match = *prefix + "*"
+	}
+
+	tags, err := gitTags(*match)
+	if err != nil {
+		e.errorf("%s", err)
+		return exitFail
+	}
+	var res nextTagResult
+	var latest semver.Semver
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, *prefix) {
+			continue
+		}
+		v, err := semver.Parse(tag[len(*prefix):])
+		if err != nil {
+			continue
+		}
+		if res.Latest == nil || v.Cmp(latest) > 0 {
+			latest = v
+			found := newVersion(tag, v)
+			res.Latest = &found
+		}
+	}
+
+	next, err := bumpVersion(latest, *level, *preid, false)
+	if err != nil {
+		e.errorf("%s", err)
+		return exitUsage
+	}
+	res.Tag = *prefix + next.String()
+	res.Next = newVersion("", next)
+	if !e.json {
+		fmt.Fprintln(e.stdout, res.Tag)
+	}
+	return e.result(res, exitOK)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"testing"
+)
+
+func TestNextTag(t *testing.T) {
+	defer func(f func(string) ([]string, error)) { gitTags = f }(gitTags)
+	repo := []string{"v1.2.0", "v1.10.0", "v1.11.0-rc.1", "latest", "release-2.0.0", "v0.9.0", "tools/v0.3.1"}
+	gitTags = func(pattern string) ([]string, error) {
+		var out []string
+		for _, tag := range repo {
+			if ok, _ := path.Match(pattern, tag); ok {
+				out = append(out, tag)
+			}
+		}
+		return out, nil
+	}
+
+	tests := []cliTest{
+		{"", []string{"next-tag"}, exitOK, "v1.11.0\n", "patch of a prerelease"},
+		{"", []string{"next-tag", "--bump", "minor"}, exitOK, "v1.11.0\n", "minor of a minor prerelease"},
+		{"", []string{"next-tag", "--bump", "major"}, exitOK, "v2.0.0\n", "major"},
+		{"", []string{"next-tag", "-bump", "prerelease", "-preid", "rc"}, exitOK, "v1.11.0-rc.2\n", "prerelease"},
+		{"", []string{"next-tag", "-prefix", "release-", "-bump", "minor"}, exitOK, "release-2.1.0\n", "prefix"},
+		{"", []string{"next-tag", "-prefix", "tools/v"}, exitOK, "tools/v0.3.2\n", "path prefix"},
+		{"", []string{"next-tag", "-match", "v1.2*"}, exitOK, "v1.2.1\n", "match"},
+		{"", []string{"next-tag", "-prefix", "pkg/v", "-bump", "minor"}, exitOK, "pkg/v0.1.0\n", "no tags"},
+		{"", []string{"next-tag", "-bump", "epoch"}, exitUsage, "", "unknown level"},
+		{"", []string{"next-tag", "v1.0.0"}, exitUsage, "", "unexpected argument"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+
+	gitTags = func(string) ([]string, error) { return nil, fmt.Errorf("not a git repository") }
+	if code, _, _ := runCLI("", "next-tag"); code != exitFail {
+		t.Errorf("git failure: exit %d", code)
+	}
+}
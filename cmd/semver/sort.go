@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/beatgammit/semver"
+)
+
+func init() {
+	commands["sort"] = command{"sort versions read from stdin", sortCmd}
+}
+
+// readVersions reads versions from stdin, one per line, keeping the lines as
+// written. Invalid lines are reported on stderr and returned as rejected;
+// the first one fails unless skip is set, in which case they're dropped.
+func (e *env) readVersions(skip bool) (lines []string, vs []semver.Semver, rejected []rejection, ok bool) {
+	inputs, err := e.inputs(nil)
+	if err != nil {
+		e.errorf("%s", err)
+		return nil, nil, nil, false
+	}
+	for _, s := range inputs {
+		v, err := semver.Parse(s)
+		if err != nil {
+			e.errorf("invalid version %q: %s", s, err)
+			rejected = append(rejected, rejection{s, err.Error()})
+			if !skip {
+				return nil, nil, rejected, false
+			}
+			continue
+		}
+		lines = append(lines, s)
+		vs = append(vs, v)
+	}
+	return lines, vs, rejected, true
+}
+
+// invalidPolicy interprets the -invalid flag, returning whether invalid
+// input should be skipped.
+func invalidPolicy(policy string) (skip bool, err error) {
+	switch policy {
+	case "fail":
+		return false, nil
+	case "skip":
+		return true, nil
+	}
+	return false, fmt.Errorf("-invalid must be skip or fail")
+}
+
+func sortCmd(e *env, args []string) int {
+	fs := e.flags("sort", "[-reverse] [-unique] [-invalid=skip|fail] < versions")
+	reverse := fs.Bool("reverse", false, "sort newest first")
+	unique := fs.Bool("unique", false, "print only the first of versions with equal precedence")
+	invalid := fs.String("invalid", "fail", "what to do with invalid lines: skip or fail")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	skip, err := invalidPolicy(*invalid)
+	if err != nil || fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	lines, vs, rejected, ok := e.readVersions(skip)
+	if !ok {
+		return e.printList(nil, nil, rejected, exitFail)
+	}
+	if *unique {
+		seen := make(map[semver.Key]bool, len(vs))
+		var ul []string
+		for i, v := range vs {
+			if !seen[v.Key()] {
+				seen[v.Key()] = true
+				ul = append(ul, lines[i])
+			}
+		}
+		lines = ul
+	}
+
+	if *reverse {
+		err = semver.SortStringsDesc(lines)
+	} else {
+		err = semver.SortStrings(lines)
+	}
+	if err == nil {
+		vs, err = semver.ParseAll(lines)
+	}
+	if err != nil {
+		e.errorf("%s", err)
+		return exitFail
+	}
+	return e.printList(lines, vs, rejected, exitOK)
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestSort(t *testing.T) {
+	tags := "v1.10.0\nv1.2.0\n\nv1.2.0-rc.1\n1.2.0\nv0.9.0\n"
+	tests := []cliTest{
+		{tags, []string{"sort"}, exitOK, "v0.9.0\nv1.2.0-rc.1\nv1.2.0\n1.2.0\nv1.10.0\n", "ascending"},
+		{tags, []string{"sort", "-reverse"}, exitOK, "v1.10.0\nv1.2.0\n1.2.0\nv1.2.0-rc.1\nv0.9.0\n", "descending"},
+		{tags, []string{"sort", "-unique"}, exitOK, "v0.9.0\nv1.2.0-rc.1\nv1.2.0\nv1.10.0\n", "unique"},
+		{"v1.1.0\nlatest\nv1.0.0\n", []string{"sort"}, exitFail, "", "invalid fails"},
+		{"v1.1.0\nlatest\nv1.0.0\n", []string{"sort", "-invalid=skip"}, exitOK, "v1.0.0\nv1.1.0\n", "invalid skipped"},
+		{"", []string{"sort", "-invalid=ignore"}, exitUsage, "", "bad policy"},
+		{"", []string{"sort"}, exitOK, "", "no input"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+}
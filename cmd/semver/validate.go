@@ -0,0 +1,49 @@
+package main
+
+import "github.com/beatgammit/semver"
+
+func init() {
+	commands["validate"] = command{"check that versions are valid", validate}
+}
+
+// parser returns the function that parses versions in strict or lenient
+// mode.
+func parser(lenient bool) func(string) (semver.Semver, error) {
+	if lenient {
+		return semver.Coerce
+	}
+	return semver.Parse
+}
+
+func validate(e *env, args []string) int {
+	fs := e.flags("validate", "[-lenient] [version...]")
+	lenient := fs.Bool("lenient", false, "accept loose forms such as v1.2")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	inputs, err := e.inputs(fs.Args())
+	if err != nil {
+		e.errorf("%s", err)
+		return exitFail
+	}
+	// with -json every input is reported, rather than stopping at the first
+	// invalid one
+	parse := parser(*lenient)
+	code := exitOK
+	res := listResult{Versions: []version{}}
+	for _, s := range inputs {
+		v, err := parse(s)
+		if err != nil {
+			e.errorf("invalid version %q: %s", s, err)
+			res.Rejected = append(res.Rejected, rejection{s, err.Error()})
+			code = exitFail
+			if !e.json {
+				break
+			}
+			continue
+		}
+		res.Versions = append(res.Versions, newVersion(s, v))
+	}
+	return e.result(res, code)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []cliTest{
+		{"", []string{"validate", "1.2.3", "v2.0.0-rc.1"}, exitOK, "", "valid arguments"},
+		{"", []string{"validate", "1.2.3", "1.2"}, exitFail, "", "invalid argument"},
+		{"1.0.0\n\n  v1.1.0  \n", []string{"validate"}, exitOK, "", "valid stdin"},
+		{"1.0.0\nlatest\n", []string{"validate"}, exitFail, "", "invalid stdin"},
+		{"", []string{"validate", "-lenient", "v1.2", "1"}, exitOK, "", "lenient"},
+		{"", []string{"validate", "-lenient", "1.2.3.4"}, exitFail, "", "invalid even when lenient"},
+	}
+
+	for _, test := range tests {
+		test.check(t)
+	}
+
+	_, _, stderr := runCLI("", "validate", "1.0.0", "bogus", "also-bogus")
+	if !strings.Contains(stderr, `"bogus"`) || strings.Contains(stderr, "also-bogus") {
+		t.Errorf("should stop at the first invalid input: %q", stderr)
+	}
+}
@@ -0,0 +1,41 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var coerceReg = regexp.MustCompile(`^[vV]?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z-.]+))?(?:\+([0-9A-Za-z-.]+))?$`)
+
+// Coerce parses the loose forms of versions found in the wild: a missing
+// minor or patch version is taken as 0, leading zeros are allowed and a
+// leading V may be upper case. So "v1", "1.2", "V01.02.3" and "1.2-rc.1"
+// coerce to 1.0.0, 1.2.0, 1.2.3 and 1.2.0-rc.1. Anything Parse accepts
+// coerces to the same version.
+func Coerce(s string) (v Semver, err error) {
+	pieces := coerceReg.FindStringSubmatch(s)
+	if pieces == nil {
+		err = fmt.Errorf("Invalid semver string: %s", s)
+		return
+	}
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range pieces[1:4] {
+		if p == "" {
+			continue
+		}
+		if *nums[i], err = strconv.Atoi(p); err != nil {
+			err = fmt.Errorf("Invalid semver string: %s: %s", s, err)
+			return
+		}
+	}
+	v.Prerelease = pieces[4]
+	v.Build = pieces[5]
+	err = v.Validate()
+	return
+}
+
+// Lenient makes ParseWith and ParseTag accept the loose forms Coerce does.
+func Lenient() ParseOption {
+	return func(o *parseOptions) { o.lenient = true }
+}
@@ -0,0 +1,50 @@
+package semver
+
+import "testing"
+
+func TestCoerce(t *testing.T) {
+	good := []goodParseTest{
+		{"1", Semver{Major: 1}, "major only"},
+		{"v1", Semver{Major: 1}, "v and major"},
+		{"V2.1", Semver{Major: 2, Minor: 1}, "upper-case V"},
+		{"01.02.003", Semver{Major: 1, Minor: 2, Patch: 3}, "leading zeros"},
+		{"1.2-rc.1", Semver{Major: 1, Minor: 2, Prerelease: "rc.1"}, "partial with prerelease"},
+		{"1.2+build.5", Semver{Major: 1, Minor: 2, Build: "build.5"}, "partial with build"},
+		{"1.2.3-rc.1+build.5", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Build: "build.5"}, "already valid"},
+	}
+
+	for _, test := range good {
+		v, err := Coerce(test.given)
+		if err != nil {
+			t.Errorf("%s: %s; given: %s", test.reason, err, test.given)
+		} else if v != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, v, test.exp)
+		}
+	}
+
+	bad := []badParseTest{
+		{"", "empty"},
+		{"v", "no numbers"},
+		{"1.2.3.4", "too many components"},
+		{"1..2", "empty component"},
+		{"release 1.2", "surrounding text"},
+		{"1.2-", "empty prerelease"},
+		{"99999999999999999999", "overflow"},
+	}
+
+	for _, test := range bad {
+		if v, err := Coerce(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, v)
+		}
+	}
+}
+
+func TestLenient(t *testing.T) {
+	v, err := ParseWith(" release-1.2 ", Lenient(), WithPrefixes("release-"), WithWhitespace(Trim))
+	if err != nil || v != (Semver{Major: 1, Minor: 2}) {
+		t.Errorf("lenient: %+v, %v", v, err)
+	}
+	if _, err := ParseWith("1.2"); err == nil {
+		t.Errorf("should be strict by default")
+	}
+}
@@ -0,0 +1,42 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/json"
+	"unicode"
+)
+
+// CompactObject is a Semver that is encoded in JSON as the smallest object
+// that reconstructs it: major is always present, while a zero minor or patch
+// and an empty prerelease or build are left out, e.g. {"major":2,"patch":1}
+// for 2.0.1.
+//
+// It decodes either that object, where only major is required, or a plain
+// version string.
+type CompactObject Semver
+
+type compactObject struct {
+	Major      int    `json:"major"`
+	Minor      int    `json:"minor,omitempty"`
+	Patch      int    `json:"patch,omitempty"`
+	Prerelease string `json:"prerelease,omitempty"`
+	Build      string `json:"build,omitempty"`
+}
+
+// MarshalJSON encodes the version as a compact object.
+func (c CompactObject) MarshalJSON() ([]byte, error) {
+	if err := Semver(c).Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(compactObject(c))
+}
+
+// UnmarshalJSON decodes a compact object or a version string, leaving c
+// unchanged on error.
+func (c *CompactObject) UnmarshalJSON(arr []byte) error {
+	trimmed := bytes.TrimLeftFunc(arr, unicode.IsSpace)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return (*Semver)(c).decodeObject(arr, defaultFields, "major")
+	}
+	return (*Semver)(c).UnmarshalJSON(arr)
+}
@@ -0,0 +1,52 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCompactObject(t *testing.T) {
+	tests := []stringTest{
+		{Semver{}, `{"major":0}`, "0.0.0"},
+		{Semver{Major: 2}, `{"major":2}`, "major only"},
+		{Semver{Major: 2, Patch: 1}, `{"major":2,"patch":1}`, "zero minor"},
+		{Semver{1, 2, 3, "rc.1", ""}, `{"major":1,"minor":2,"patch":3,"prerelease":"rc.1"}`, "prerelease"},
+		{Semver{1, 0, 0, "", "build.5"}, `{"major":1,"build":"build.5"}`, "build"},
+	}
+
+	for _, test := range tests {
+		b, err := json.Marshal(CompactObject(test.given))
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+			continue
+		} else if string(b) != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, b, test.exp)
+		}
+		var c CompactObject
+		if err := json.Unmarshal(b, &c); err != nil || Semver(c) != test.given {
+			t.Errorf("%s: round trip %+v, %v", test.reason, c, err)
+		}
+	}
+
+	var c CompactObject
+	if err := json.Unmarshal([]byte(`"1.2.3-rc.1"`), &c); err != nil || Semver(c) != MustParse("1.2.3-rc.1") {
+		t.Errorf("string form: %+v, %v", c, err)
+	}
+	if _, err := json.Marshal(CompactObject{Major: -1}); err == nil {
+		t.Errorf("expected error marshaling an invalid version")
+	}
+
+	bad := []badJsonTest{
+		{`{}`, "missing major"},
+		{`{"minor": 1}`, "missing major"},
+		{`{"major": 1, "extra": true}`, "unknown key"},
+		{`{"major": 1, "prerelease": "a b"}`, "invalid prerelease"},
+		{`[1, 2, 3]`, "array"},
+	}
+	for _, test := range bad {
+		var c CompactObject
+		if err := json.Unmarshal([]byte(test.given), &c); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, c)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var (
+	_ json.Marshaler   = CompatMatrix{}
+	_ json.Unmarshaler = (*CompatMatrix)(nil)
+)
+
+// CompatMatrix records which versions of two components, such as a server
+// and its client library, work together. Each entry says that versions of A
+// in one range are compatible with versions of B in another; a pair of
+// versions is compatible if any entry covers it.
+//
+// In JSON and YAML a matrix is written with the components' names as the
+// keys of its entries, as published in docs:
+//
+//	{"a": "server", "b": "client", "entries": [
+//		{"server": ">=2.1 <2.4", "client": "^1.6"},
+//		{"server": "^3", "client": "^2"}
+//	]}
+type CompatMatrix struct {
+	A, B    string // names of the components
+	Entries []CompatEntry
+}
+
+// CompatEntry is a range of versions of A compatible with a range of B.
+type CompatEntry struct {
+	A, B Constraint
+}
+
+// Compatible reports whether version a of A works with version b of B.
+func (m CompatMatrix) Compatible(a, b Semver) bool {
+	for _, e := range m.Entries {
+		if e.A.Check(a) && e.B.Check(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompatiblePartners returns the ranges of versions of B that work with
+// version a of A, in the order of the entries, or nil if there are none.
+func (m CompatMatrix) CompatiblePartners(a Semver) []Constraint {
+	var out []Constraint
+	for _, e := range m.Entries {
+		if e.A.Check(a) {
+			out = append(out, e.B)
+		}
+	}
+	return out
+}
+
+// compatFile is the encoded form of a CompatMatrix.
+type compatFile struct {
+	A       string              `json:"a" yaml:"a"`
+	B       string              `json:"b" yaml:"b"`
+	Entries []map[string]string `json:"entries" yaml:"entries"`
+}
+
+func (m CompatMatrix) file() compatFile {
+	f := compatFile{A: m.A, B: m.B, Entries: []map[string]string{}}
+	for _, e := range m.Entries {
+		f.Entries = append(f.Entries, map[string]string{m.A: e.A.String(), m.B: e.B.String()})
+	}
+	return f
+}
+
+// load replaces m with the matrix f describes, leaving m unchanged on error.
+func (m *CompatMatrix) load(f compatFile) error {
+	if f.A == "" || f.B == "" || f.A == f.B {
+		return fmt.Errorf("Invalid compatibility matrix: components must have two different names, got %q and %q", f.A, f.B)
+	}
+	loaded := CompatMatrix{A: f.A, B: f.B}
+	for i, entry := range f.Entries {
+		ra, okA := entry[f.A]
+		rb, okB := entry[f.B]
+		if !okA || !okB || len(entry) != 2 {
+			return fmt.Errorf("Invalid compatibility matrix: entry %d must give ranges for exactly %s and %s", i, f.A, f.B)
+		}
+		var e CompatEntry
+		var err error
+		if e.A, err = ParseConstraint(ra); err != nil {
+			return fmt.Errorf("Invalid compatibility matrix: entry %d: %s", i, err)
+		}
+		if e.B, err = ParseConstraint(rb); err != nil {
+			return fmt.Errorf("Invalid compatibility matrix: entry %d: %s", i, err)
+		}
+		loaded.Entries = append(loaded.Entries, e)
+	}
+	*m = loaded
+	return nil
+}
+
+// MarshalJSON encodes the matrix in the form shown above.
+func (m CompatMatrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.file())
+}
+
+// UnmarshalJSON decodes a matrix in the form shown above, leaving m
+// unchanged on error.
+func (m *CompatMatrix) UnmarshalJSON(data []byte) error {
+	var f compatFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	return m.load(f)
+}
+
+// MarshalYAML encodes the matrix in the form shown above.
+func (m CompatMatrix) MarshalYAML() (interface{}, error) {
+	return m.file(), nil
+}
+
+// UnmarshalYAML decodes a matrix in the form shown above, using the same
+// callback form of the interface as Semver.UnmarshalYAML.
+func (m *CompatMatrix) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var f compatFile
+	if err := unmarshal(&f); err != nil {
+		return err
+	}
+	return m.load(f)
+}
@@ -0,0 +1,96 @@
+package semver
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testMatrix = `{"a": "server", "b": "client", "entries": [
+	{"server": ">=2.1 <2.4", "client": "^1.6"},
+	{"server": "^2.3", "client": "^2"},
+	{"server": "^3", "client": "^2.2"}
+]}`
+
+func TestCompatMatrix(t *testing.T) {
+	var m CompatMatrix
+	if err := json.Unmarshal([]byte(testMatrix), &m); err != nil {
+		t.Fatalf("error loading matrix: %s", err)
+	}
+	tests := []struct {
+		a, b   string
+		exp    bool
+		reason string
+	}{
+		{"2.2.0", "1.7.3", true, "first entry"},
+		{"2.3.1", "2.0.0", true, "second entry"},
+		{"2.3.1", "1.6.0", true, "overlapping entries"},
+		{"2.4.0", "1.9.0", false, "server too new for client 1.x"},
+		{"3.0.0", "2.1.0", false, "client too old for server 3"},
+		{"1.0.0", "1.6.0", false, "server not in the matrix"},
+	}
+
+	for _, test := range tests {
+		if ok := m.Compatible(MustParse(test.a), MustParse(test.b)); ok != test.exp {
+			t.Errorf("%s: Compatible(%s, %s) = %t", test.reason, test.a, test.b, ok)
+		}
+	}
+
+	var partners []string
+	for _, c := range m.CompatiblePartners(MustParse("2.3.0")) {
+		partners = append(partners, c.String())
+	}
+	if exp := []string{"^1.6", "^2"}; !reflect.DeepEqual(partners, exp) {
+		t.Errorf("partners %v != %v", partners, exp)
+	}
+	if p := m.CompatiblePartners(MustParse("4.0.0")); p != nil {
+		t.Errorf("expected no partners, got %v", p)
+	}
+}
+
+func TestCompatMatrixEncoding(t *testing.T) {
+	var m CompatMatrix
+	if err := json.Unmarshal([]byte(testMatrix), &m); err != nil {
+		t.Fatalf("error loading matrix: %s", err)
+	}
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("error encoding matrix: %s", err)
+	}
+	exp := `{"a":"server","b":"client","entries":[{"client":"^1.6","server":"\u003e=2.1 \u003c2.4"},{"client":"^2","server":"^2.3"},{"client":"^2.2","server":"^3"}]}`
+	if string(out) != exp {
+		t.Errorf("%s != %s", out, exp)
+	}
+
+	// YAML decoders fill in the same structure through the callback
+	var fromYAML CompatMatrix
+	err = fromYAML.UnmarshalYAML(func(v interface{}) error {
+		return json.Unmarshal([]byte(testMatrix), v)
+	})
+	if err != nil {
+		t.Fatalf("error loading YAML: %s", err)
+	}
+	if !reflect.DeepEqual(fromYAML, m) {
+		t.Errorf("YAML matrix %+v != %+v", fromYAML, m)
+	}
+	if f, err := m.MarshalYAML(); err != nil || !reflect.DeepEqual(f, m.file()) {
+		t.Errorf("MarshalYAML: %v, %v", f, err)
+	}
+
+	bad := []badParseTest{
+		{`{"a": "server", "entries": []}`, "missing component"},
+		{`{"a": "x", "b": "x", "entries": []}`, "same component twice"},
+		{`{"a": "server", "b": "client", "entries": [{"server": "^1"}]}`, "missing range"},
+		{`{"a": "server", "b": "client", "entries": [{"server": "^1", "client": "^1", "proxy": "^1"}]}`, "extra component"},
+		{`{"a": "server", "b": "client", "entries": [{"server": "^1", "client": "1.2.3.4"}]}`, "invalid range"},
+	}
+
+	for _, test := range bad {
+		m := CompatMatrix{A: "unchanged"}
+		if err := json.Unmarshal([]byte(test.given), &m); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, m)
+		} else if m.A != "unchanged" {
+			t.Errorf("%s: matrix changed on error", test.reason)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package semver
+
+import (
+	"encoding"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ encoding.TextMarshaler   = Constraint{}
+	_ encoding.TextUnmarshaler = (*Constraint)(nil)
+)
+
+// Constraint is a range of versions, written in the syntax of npm's
+// node-semver:
+//
+//	1.2.3, =1.2.3      exactly 1.2.3
+//	>1.2.3, >=1.2.3    comparisons; < and <= work the same way
+//	1.2.x, 1.2, 1.*    any version with the given prefix; * or "" is anything
+//	~1.2.3             >=1.2.3 <1.3.0-0, patch updates (~> is the same)
+//	^1.2.3             >=1.2.3 <2.0.0-0, updates that don't change the
+//	                   leftmost non-zero component
+//	1.2.3 - 2.3        >=1.2.3 <2.4.0-0, an inclusive hyphen range
+//
+// Comparators separated by spaces or commas must all match, and sets of
+// comparators separated by || are alternatives.
+//
+// As in npm, a prerelease only satisfies a set of comparators if one of
+// them names a prerelease of the same major, minor and patch version, so
+// ^1.2.3-rc.1 matches 1.2.3-rc.2 but not 1.3.0-rc.1. IncludePrerelease
+// turns that rule off.
+//
+// The zero Constraint is equivalent to "*".
+type Constraint struct {
+	raw               string
+	sets              [][]comparator
+	includePrerelease bool
+}
+
+// A ConstraintOption configures how ParseConstraint interprets a constraint.
+type ConstraintOption func(*Constraint)
+
+// IncludePrerelease lets prerelease versions satisfy the constraint whenever
+// they fall in its range, as npm's includePrerelease option does.
+func IncludePrerelease() ConstraintOption {
+	return func(c *Constraint) { c.includePrerelease = true }
+}
+
+type op int
+
+const (
+	opEQ op = iota
+	opGT
+	opGTE
+	opLT
+	opLTE
+)
+
+type comparator struct {
+	op op
+	v  Semver
+}
+
+func (c comparator) check(v Semver) bool {
+	n := v.Cmp(c.v)
+	switch c.op {
+	case opGT:
+		return n > 0
+	case opGTE:
+		return n >= 0
+	case opLT:
+		return n < 0
+	case opLTE:
+		return n <= 0
+	}
+	return n == 0
+}
+
+var (
+	anyVersion = comparator{opGTE, Semver{}}
+	noVersion  = comparator{opLT, Semver{Prerelease: "0"}}
+	operatorRe = regexp.MustCompile(`^(<=|>=|~>|<|>|=|\^|~)?\s*(.*)$`)
+)
+
+// ParseConstraint parses a constraint such as "^1.2.3" or ">=1.2 <2 || 3.x".
+func ParseConstraint(s string, opts ...ConstraintOption) (Constraint, error) {
+	c := Constraint{raw: strings.TrimSpace(s)}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	for _, set := range strings.Split(s, "||") {
+		comps, err := parseComparatorSet(set)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("Invalid constraint %q: %s", s, err)
+		}
+		c.sets = append(c.sets, comps)
+	}
+	return c, nil
+}
+
+// MustParseConstraint is like ParseConstraint, but panics if s is invalid.
+func MustParseConstraint(s string, opts ...ConstraintOption) Constraint {
+	if c, err := ParseConstraint(s, opts...); err != nil {
+		panic(err)
+	} else {
+		return c
+	}
+}
+
+// Check reports whether v satisfies the constraint.
+func (c Constraint) Check(v Semver) bool {
+	if c.sets == nil {
+		return v.Prerelease == "" || c.includePrerelease
+	}
+	for _, set := range c.sets {
+		if c.checkSet(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Constraint) checkSet(set []comparator, v Semver) bool {
+	for _, comp := range set {
+		if !comp.check(v) {
+			return false
+		}
+	}
+	if v.Prerelease == "" || c.includePrerelease {
+		return true
+	}
+	for _, comp := range set {
+		if comp.v.Prerelease != "" && comp.v.Major == v.Major && comp.v.Minor == v.Minor && comp.v.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was written.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// MarshalText encodes the constraint as it was written.
+func (c Constraint) MarshalText() ([]byte, error) {
+	return []byte(c.raw), nil
+}
+
+// UnmarshalText parses a constraint with ParseConstraint, leaving c unchanged
+// on error. Options set on c, such as IncludePrerelease, are kept.
+func (c *Constraint) UnmarshalText(arr []byte) error {
+	var opts []ConstraintOption
+	if c.includePrerelease {
+		opts = append(opts, IncludePrerelease())
+	}
+	parsed, err := ParseConstraint(string(arr), opts...)
+	if err == nil {
+		*c = parsed
+	}
+	return err
+}
+
+// parseComparatorSet parses comparators that must all match.
+func parseComparatorSet(s string) ([]comparator, error) {
+	tokens := strings.Fields(strings.Replace(s, ",", " ", -1))
+
+	// join operators written apart from their versions, e.g. ">= 1.2.3"
+	var joined []string
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if operatorRe.FindStringSubmatch(t)[2] == "" && t != "-" && i+1 < len(tokens) {
+			i++
+			t += tokens[i]
+		}
+		joined = append(joined, t)
+	}
+
+	if len(joined) == 0 {
+		return []comparator{anyVersion}, nil
+	}
+	if len(joined) == 3 && joined[1] == "-" {
+		return parseHyphenRange(joined[0], joined[2])
+	}
+
+	var comps []comparator
+	for _, t := range joined {
+		cs, err := parseComparator(t)
+		if err != nil {
+			return nil, err
+		}
+		comps = append(comps, cs...)
+	}
+	return comps, nil
+}
+
+// partial is a version that may have missing or wildcard components, as in
+// 1.2, 1.x or *. n is the number of components given.
+type partial struct {
+	v Semver
+	n int
+}
+
+func parsePartial(s string) (p partial, err error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		// build metadata doesn't affect precedence
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, p.v.Prerelease = s[:i], s[i+1:]
+		if !identifierChars(p.v.Prerelease) {
+			return p, fmt.Errorf("invalid prerelease in %q", s)
+		}
+	}
+	if s == "" {
+		return p, fmt.Errorf("missing version")
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return p, fmt.Errorf("too many components in %q", s)
+	}
+	nums := []*int{&p.v.Major, &p.v.Minor, &p.v.Patch}
+	wild := false
+	for i, part := range parts {
+		switch {
+		case part == "x" || part == "X" || part == "*":
+			wild = true
+		case wild || !isDigits(part):
+			return p, fmt.Errorf("invalid version %q", s)
+		default:
+			if *nums[i], err = strconv.Atoi(part); err != nil {
+				return p, fmt.Errorf("invalid version %q: %s", s, err)
+			}
+			p.n++
+		}
+	}
+	if p.n < 3 && p.v.Prerelease != "" {
+		return p, fmt.Errorf("prerelease on a partial version %q", s)
+	}
+	return p, nil
+}
+
+// next returns the smallest version that doesn't share p's given components.
+func (p partial) next() Semver {
+	v := Semver{Major: p.v.Major, Minor: p.v.Minor, Patch: p.v.Patch}
+	switch p.n {
+	case 1:
+		v.Major, v.Minor, v.Patch = v.Major+1, 0, 0
+	case 2:
+		v.Minor, v.Patch = v.Minor+1, 0
+	default:
+		v.Patch++
+	}
+	return v
+}
+
+// lowest returns the version below every prerelease of v.
+func lowest(v Semver) Semver {
+	v.Prerelease = "0"
+	return v
+}
+
+// parseComparator expands a single comparator, which may be a range such as
+// ^1.2.3, into plain comparisons.
+func parseComparator(s string) ([]comparator, error) {
+	m := operatorRe.FindStringSubmatch(s)
+	p, err := parsePartial(m[2])
+	if err != nil {
+		return nil, err
+	}
+	lo, next := p.v, p.next()
+
+	switch m[1] {
+	case "", "=":
+		if p.n == 3 {
+			return []comparator{{opEQ, lo}}, nil
+		} else if p.n == 0 {
+			return []comparator{anyVersion}, nil
+		}
+		return []comparator{{opGTE, lo}, {opLT, lowest(next)}}, nil
+	case ">":
+		if p.n == 3 {
+			return []comparator{{opGT, lo}}, nil
+		} else if p.n == 0 {
+			return []comparator{noVersion}, nil
+		}
+		return []comparator{{opGTE, next}}, nil
+	case ">=":
+		return []comparator{{opGTE, lo}}, nil
+	case "<":
+		if p.n == 3 {
+			return []comparator{{opLT, lo}}, nil
+		} else if p.n == 0 {
+			return []comparator{noVersion}, nil
+		}
+		return []comparator{{opLT, lowest(lo)}}, nil
+	case "<=":
+		if p.n == 3 {
+			return []comparator{{opLTE, lo}}, nil
+		} else if p.n == 0 {
+			return []comparator{anyVersion}, nil
+		}
+		return []comparator{{opLT, lowest(next)}}, nil
+	case "~", "~>":
+		if p.n == 0 {
+			return []comparator{anyVersion}, nil
+		}
+		upper := partial{lo, 2}
+		if p.n == 1 {
+			upper.n = 1
+		}
+		return []comparator{{opGTE, lo}, {opLT, lowest(upper.next())}}, nil
+	case "^":
+		if p.n == 0 {
+			return []comparator{anyVersion}, nil
+		}
+		upper := partial{lo, 3}
+		if lo.Major > 0 || p.n == 1 {
+			upper.n = 1
+		} else if lo.Minor > 0 || p.n == 2 {
+			upper.n = 2
+		}
+		return []comparator{{opGTE, lo}, {opLT, lowest(upper.next())}}, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", m[1])
+}
+
+// parseHyphenRange expands an inclusive range such as 1.2.3 - 2.3.
+func parseHyphenRange(from, to string) ([]comparator, error) {
+	lo, err := parsePartial(from)
+	if err != nil {
+		return nil, err
+	}
+	hi, err := parsePartial(to)
+	if err != nil {
+		return nil, err
+	}
+	comps := []comparator{{opGTE, lo.v}}
+	switch hi.n {
+	case 0:
+	case 3:
+		comps = append(comps, comparator{opLTE, hi.v})
+	default:
+		comps = append(comps, comparator{opLT, lowest(hi.next())})
+	}
+	return comps, nil
+}
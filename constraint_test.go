@@ -0,0 +1,130 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type constraintTest struct {
+	constraint string
+	version    string
+	exp        bool
+	reason     string
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []constraintTest{
+		{"1.2.3", "1.2.3", true, "exact"},
+		{"=1.2.3", "1.2.3+build", true, "exact ignores build"},
+		{"1.2.3", "1.2.4", false, "exact mismatch"},
+		{">1.2.3", "1.2.4", true, "greater"},
+		{">1.2.3", "1.2.3", false, "not greater"},
+		{">= 1.2.3", "1.2.3", true, "operator apart from version"},
+		{"<1.2.3", "1.2.2", true, "less"},
+		{"<=1.2.3", "1.2.3", true, "less or equal"},
+		{">1.2", "1.2.9", false, "greater than a partial"},
+		{">1.2", "1.3.0", true, "greater than a partial"},
+		{"<1.2", "1.1.9", true, "less than a partial"},
+		{"<1.2", "1.2.0", false, "less than a partial"},
+		{"<=1.2", "1.2.9", true, "less or equal to a partial"},
+		{"*", "3.4.5", true, "wildcard"},
+		{"", "3.4.5", true, "empty"},
+		{"1.x", "1.9.0", true, "x-range"},
+		{"1.x", "2.0.0", false, "x-range"},
+		{"1.2.*", "1.2.7", true, "x-range with *"},
+		{"1.2", "1.3.0", false, "partial"},
+		{"~1.2.3", "1.2.9", true, "tilde"},
+		{"~1.2.3", "1.3.0", false, "tilde"},
+		{"~1", "1.9.9", true, "tilde major"},
+		{"~>1.2", "1.2.5", true, "~>"},
+		{"^1.2.3", "1.9.9", true, "caret"},
+		{"^1.2.3", "2.0.0", false, "caret"},
+		{"^1.2.3", "1.2.2", false, "caret lower bound"},
+		{"^0.2.3", "0.2.9", true, "caret 0.x"},
+		{"^0.2.3", "0.3.0", false, "caret 0.x"},
+		{"^0.0.3", "0.0.4", false, "caret 0.0.x"},
+		{"^0.0", "0.0.9", true, "caret partial 0.0"},
+		{"^0.x", "0.9.0", true, "caret 0.x wildcard"},
+		{"^0.x", "1.0.0", false, "caret 0.x wildcard"},
+		{"1.2.3 - 2.3.4", "2.3.4", true, "hyphen inclusive"},
+		{"1.2.3 - 2.3.4", "2.3.5", false, "hyphen upper"},
+		{"1.2.3 - 2.3", "2.3.9", true, "hyphen partial upper"},
+		{"1.2 - 2", "1.2.0", true, "hyphen partial lower"},
+		{">=1.2 <2", "1.5.0", true, "and"},
+		{">=1.2, <2", "2.0.0", false, "and with comma"},
+		{"^1 || ^3", "3.1.0", true, "or"},
+		{"^1 || ^3", "2.1.0", false, "or"},
+		{"v1.2.3", "1.2.3", true, "v prefix"},
+
+		// prereleases
+		{"^1.2.3", "1.5.0-rc.1", false, "prerelease excluded"},
+		{"^1.2.3", "2.0.0-rc.1", false, "prerelease of the upper bound"},
+		{"^1.2.3-rc.1", "1.2.3-rc.2", true, "prerelease of the same version"},
+		{"^1.2.3-rc.1", "1.2.3", true, "release after prerelease"},
+		{"^1.2.3-rc.1", "1.3.0-rc.1", false, "prerelease of another version"},
+		{">1.2.3-alpha.3", "1.2.3-alpha.7", true, "greater prerelease"},
+		{"*", "1.0.0-rc.1", false, "wildcard excludes prereleases"},
+	}
+
+	for _, test := range tests {
+		c, err := ParseConstraint(test.constraint)
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+			continue
+		}
+		if ok := c.Check(MustParse(test.version)); ok != test.exp {
+			t.Errorf("%s: %q.Check(%s) = %v", test.reason, test.constraint, test.version, ok)
+		}
+	}
+}
+
+func TestConstraintIncludePrerelease(t *testing.T) {
+	c := MustParseConstraint("^1.2.3", IncludePrerelease())
+	if !c.Check(MustParse("1.5.0-rc.1")) {
+		t.Errorf("prerelease in range should match")
+	}
+	if c.Check(MustParse("2.0.0-rc.1")) {
+		t.Errorf("prerelease of the upper bound should not match")
+	}
+	if !(Constraint{}).Check(MustParse("1.0.0")) || (Constraint{}).Check(MustParse("1.0.0-rc.1")) {
+		t.Errorf("zero Constraint should be *")
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	tests := []badParseTest{
+		{"1.2.3.4", "too many components"},
+		{"a.b.c", "not a number"},
+		{"1.x.3", "number after wildcard"},
+		{">=", "operator alone"},
+		{"1.2-rc.1", "prerelease on a partial version"},
+		{"!=1.2.3", "unknown operator"},
+		{"1.2.3 - ", "hyphen range without upper bound"},
+		{"^1 || >", "invalid alternative"},
+	}
+
+	for _, test := range tests {
+		if c, err := ParseConstraint(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, c)
+		}
+	}
+}
+
+func TestConstraintText(t *testing.T) {
+	var cfg struct {
+		Requires Constraint `json:"requires"`
+	}
+	if err := json.Unmarshal([]byte(`{"requires": " >=1.2 <2 "}`), &cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !cfg.Requires.Check(MustParse("1.4.0")) || cfg.Requires.String() != ">=1.2 <2" {
+		t.Errorf("unexpected constraint: %q", cfg.Requires)
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil || string(b) != `{"requires":"\u003e=1.2 \u003c2"}` {
+		t.Errorf("unexpected encoding: %s, %v", b, err)
+	}
+	if err := json.Unmarshal([]byte(`{"requires": ">>1"}`), &cfg); err == nil {
+		t.Errorf("expected error for an invalid constraint")
+	}
+}
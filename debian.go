@@ -0,0 +1,162 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ToDebian returns v as a Debian upstream version. The prerelease follows a
+// ~, which dpkg sorts before everything, even the end of the version, so
+// 1.2.3-rc.1 becomes 1.2.3~rc.1 and still sorts before 1.2.3. Build metadata
+// follows a +; unlike in semver, dpkg orders 1.2.3+b1 after 1.2.3.
+//
+// The result has no Debian revision. A version whose prerelease or build
+// metadata contains a hyphen needs one, since dpkg splits the revision off at
+// the last hyphen: append "-1" or similar before using it.
+//
+// dpkg compares runs of digits numerically wherever they appear, so
+// prereleases with alphanumeric identifiers such as rc9 and rc10 sort in
+// numeric order, where semver would sort them lexically.
+func (v Semver) ToDebian() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "~" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// FromDebian parses a Debian version of the form ToDebian produces, such as
+// 1.2.3~rc.1 or 1:1.2.3+b1-2, back into a Semver. The Debian revision, after
+// the last hyphen, describes the packaging and is dropped. A non-zero epoch
+// can't be represented and is an error.
+func FromDebian(s string) (Semver, error) {
+	epoch, upstream, _ := splitDebian(s)
+	if epoch != "" && strings.TrimLeft(epoch, "0") != "" {
+		return Semver{}, fmt.Errorf("Cannot convert Debian version %s: epoch %s has no semver equivalent", s, epoch)
+	}
+	var pre, build string
+	if i := strings.IndexByte(upstream, '+'); i >= 0 {
+		upstream, build = upstream[:i], upstream[i+1:]
+		if build == "" {
+			return Semver{}, fmt.Errorf("Invalid Debian version %s: empty build", s)
+		}
+	}
+	if i := strings.IndexByte(upstream, '~'); i >= 0 {
+		upstream, pre = upstream[:i], upstream[i+1:]
+		if pre == "" {
+			return Semver{}, fmt.Errorf("Invalid Debian version %s: empty prerelease", s)
+		}
+	}
+	if strings.HasPrefix(upstream, "v") {
+		return Semver{}, fmt.Errorf("Invalid Debian version: %s", s)
+	}
+	v, err := Parse(upstream)
+	if err != nil {
+		return Semver{}, fmt.Errorf("Invalid Debian version %s: %s", s, err)
+	}
+	v.Prerelease, v.Build = pre, build
+	if err := v.Validate(); err != nil {
+		return Semver{}, fmt.Errorf("Invalid Debian version %s: %s", s, err)
+	}
+	return v, nil
+}
+
+// CompareDebian compares two Debian versions the way dpkg --compare-versions
+// does, returning -1, 0 or 1. Epochs are compared numerically, then the
+// upstream versions and revisions with dpkg's rules: runs of digits compare
+// numerically, letters sort before other characters and ~ sorts before
+// everything, even the end of the string. Malformed epochs count as 0.
+func CompareDebian(a, b string) int {
+	ea, ua, ra := splitDebian(a)
+	eb, ub, rb := splitDebian(b)
+	na, _ := strconv.Atoi(ea)
+	nb, _ := strconv.Atoi(eb)
+	if na != nb {
+		return sign(na - nb)
+	}
+	if c := verrevcmp(ua, ub); c != 0 {
+		return sign(c)
+	}
+	return sign(verrevcmp(ra, rb))
+}
+
+// splitDebian splits a Debian version into [epoch:]upstream[-revision].
+func splitDebian(s string) (epoch, upstream, revision string) {
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		epoch, s = s[:i], s[i+1:]
+	}
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		s, revision = s[:i], s[i+1:]
+	}
+	return epoch, s, revision
+}
+
+// debianOrder is the weight dpkg gives a non-digit character; 0 is the end
+// of the string.
+func debianOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	switch c := s[i]; {
+	case isDigit(c):
+		return 0
+	case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+		return int(c)
+	case c == '~':
+		return -1
+	default:
+		return int(c) + 256
+	}
+}
+
+// verrevcmp is dpkg's comparison of upstream versions or revisions.
+func verrevcmp(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isDigit(a[i]) || j < len(b) && !isDigit(b[j]) {
+			if oa, ob := debianOrder(a, i), debianOrder(b, j); oa != ob {
+				return oa - ob
+			}
+			i++
+			j++
+		}
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package semver
+
+import "testing"
+
+func TestToDebian(t *testing.T) {
+	tests := []struct {
+		given, exp string
+		reason     string
+	}{
+		{"1.2.3", "1.2.3", "release"},
+		{"1.2.3-rc.1", "1.2.3~rc.1", "prerelease"},
+		{"1.2.3+build.5", "1.2.3+build.5", "build"},
+		{"v1.2.3-beta.2+b1", "1.2.3~beta.2+b1", "prerelease and build"},
+	}
+
+	for _, test := range tests {
+		v := MustParse(test.given)
+		if s := v.ToDebian(); s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+		}
+		back, err := FromDebian(v.ToDebian())
+		if err != nil || back != v {
+			t.Errorf("%s: round trip gave %+v, %v", test.reason, back, err)
+		}
+	}
+}
+
+func TestFromDebian(t *testing.T) {
+	good := []goodParseTest{
+		{"1.2.3~rc.1-2", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, "revision dropped"},
+		{"0:1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}, "zero epoch"},
+		{"1.2.3+dfsg-1ubuntu2", Semver{Major: 1, Minor: 2, Patch: 3, Build: "dfsg"}, "repacked upstream"},
+	}
+
+	for _, test := range good {
+		v, err := FromDebian(test.given)
+		if err != nil {
+			t.Errorf("%s: %s; given: %s", test.reason, err, test.given)
+		} else if v != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, v, test.exp)
+		}
+	}
+
+	bad := []badParseTest{
+		{"2:1.2.3-1", "non-zero epoch"},
+		{"1.2", "partial version"},
+		{"v1.2.3", "leading v"},
+		{"1.2.3~", "empty prerelease"},
+		{"1.2.3+", "empty build"},
+		{"1.2.3~rc_1", "invalid prerelease"},
+	}
+
+	for _, test := range bad {
+		if v, err := FromDebian(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, v)
+		}
+	}
+}
+
+func TestCompareDebian(t *testing.T) {
+	// in ascending order, as dpkg --compare-versions sorts them
+	ordered := []string{
+		"~~",
+		"~~a",
+		"~",
+		"",
+		"1.0~rc1",
+		"1.0~rc9",
+		"1.0~rc10",
+		"1.0",
+		"1.0-1",
+		"1.0-1ubuntu1",
+		"1.0-2",
+		"1.0+b1",
+		"1.0.1",
+		"1.9",
+		"1.10",
+		"1:0.1",
+		"2:0.0.1",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a, b := ordered[i-1], ordered[i]
+		if c := CompareDebian(a, b); c != -1 {
+			t.Errorf("CompareDebian(%q, %q) = %d, expected -1", a, b, c)
+		}
+		if c := CompareDebian(b, a); c != 1 {
+			t.Errorf("CompareDebian(%q, %q) = %d, expected 1", b, a, c)
+		}
+	}
+
+	equal := [][2]string{
+		{"1.0", "1.0"},
+		{"1.01", "1.1"},
+		{"0:1.0", "1.0"},
+		{"1.0-0", "1.0-00"},
+	}
+	if c := CompareDebian("1.0a", "1.0+"); c != -1 {
+		t.Errorf("letters should sort before other characters: %d", c)
+	}
+	for _, pair := range equal {
+		if c := CompareDebian(pair[0], pair[1]); c != 0 {
+			t.Errorf("CompareDebian(%q, %q) = %d, expected 0", pair[0], pair[1], c)
+		}
+	}
+}
+
+func TestCompareDebianMatchesCmp(t *testing.T) {
+	// semver precedence survives the conversion
+	ordered := []string{
+		"1.0.0-1",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a, b := MustParse(ordered[i-1]), MustParse(ordered[i])
+		if c := CompareDebian(a.ToDebian(), b.ToDebian()); c != sign(a.Cmp(b)) {
+			t.Errorf("%s vs %s: CompareDebian gives %d, Cmp %d", a.ToDebian(), b.ToDebian(), c, sign(a.Cmp(b)))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package semver
+
+// A DedupeOption configures Dedupe.
+type DedupeOption func(*dedupeOptions)
+
+type dedupeOptions struct {
+	respectBuild bool
+	sorted       bool
+}
+
+// RespectBuild treats versions that differ only in build metadata as
+// distinct. By default they're duplicates, as they have equal precedence.
+func RespectBuild() DedupeOption {
+	return func(o *dedupeOptions) { o.respectBuild = true }
+}
+
+// SortedOutput returns the result in ascending order rather than in order of
+// first occurrence.
+func SortedOutput() DedupeOption {
+	return func(o *dedupeOptions) { o.sorted = true }
+}
+
+// Dedupe returns versions without duplicates, as a new slice, keeping the
+// first occurrence of each. Versions are duplicates if they are Equal, so
+// 1.2.3 and v1.2.3+build are the same unless RespectBuild is given.
+func Dedupe(versions []Semver, opts ...DedupeOption) []Semver {
+	o := new(dedupeOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	type dedupeKey struct {
+		Key
+		build string
+	}
+	seen := make(map[dedupeKey]bool, len(versions))
+	var out Versions
+	for _, v := range versions {
+		k := dedupeKey{Key: v.Key()}
+		if o.respectBuild {
+			k.build = v.Build
+		}
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, v)
+		}
+	}
+	if o.sorted {
+		out.Sort()
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+type dedupeTest struct {
+	opts   []DedupeOption
+	exp    []string
+	reason string
+}
+
+func TestDedupe(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.2.3", "v1.0.0", "1.2.3+build.1", "v1.2.3", "1.0.0-rc.01", "1.0.0", "1.0.0-rc.1", "1.2.3+build.1"})
+
+	tests := []dedupeTest{
+		{nil, []string{"1.2.3", "1.0.0", "1.0.0-rc.01"}, "first occurrence"},
+		{[]DedupeOption{SortedOutput()}, []string{"1.0.0-rc.01", "1.0.0", "1.2.3"}, "sorted"},
+		{[]DedupeOption{RespectBuild()}, []string{"1.2.3", "1.0.0", "1.2.3+build.1", "1.0.0-rc.01"}, "respect build"},
+		{[]DedupeOption{RespectBuild(), SortedOutput()}, []string{"1.0.0-rc.01", "1.0.0", "1.2.3", "1.2.3+build.1"}, "respect build, sorted"},
+	}
+
+	for _, test := range tests {
+		if got := Versions(Dedupe(vs, test.opts...)).Strings(); !reflect.DeepEqual(got, test.exp) {
+			t.Errorf("%s: %q != %q", test.reason, got, test.exp)
+		}
+	}
+
+	if got := Dedupe(nil); len(got) != 0 {
+		t.Errorf("expected empty result, got %v", got)
+	}
+}
@@ -0,0 +1,74 @@
+package semver
+
+import (
+	"encoding"
+	"fmt"
+)
+
+var (
+	_ encoding.TextMarshaler   = NoChange
+	_ encoding.TextUnmarshaler = (*Level)(nil)
+)
+
+// Level is how significant the difference between two versions is, ordered
+// from least to most significant, so l >= MinorChange means "minor or
+// bigger".
+type Level int
+
+const (
+	NoChange Level = iota
+	BuildChange
+	PrereleaseChange
+	PatchChange
+	MinorChange
+	MajorChange
+)
+
+var levelNames = [...]string{"none", "build", "prerelease", "patch", "minor", "major"}
+
+func (l Level) String() string {
+	if l < NoChange || l > MajorChange {
+		return "unknown"
+	}
+	return levelNames[l]
+}
+
+// MarshalText encodes the level as its String form.
+func (l Level) MarshalText() ([]byte, error) {
+	if l < NoChange || l > MajorChange {
+		return nil, fmt.Errorf("Invalid level: %d", int(l))
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText parses a level as written by String, leaving l unchanged on
+// error.
+func (l *Level) UnmarshalText(arr []byte) error {
+	for i, name := range levelNames {
+		if string(arr) == name {
+			*l = Level(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid level: %q", arr)
+}
+
+// Diff returns the most significant component that differs between a and b,
+// in either direction: 1.4.2 and 2.0.0-rc.1 differ by a MajorChange, and
+// 1.2.3-rc.1 and 1.2.3 by a PrereleaseChange. Versions that differ only in
+// build metadata have the same precedence but differ by a BuildChange.
+func Diff(a, b Semver) Level {
+	switch {
+	case a.Major != b.Major:
+		return MajorChange
+	case a.Minor != b.Minor:
+		return MinorChange
+	case a.Patch != b.Patch:
+		return PatchChange
+	case comparePrerelease(a.Prerelease, b.Prerelease) != 0:
+		return PrereleaseChange
+	case a.Build != b.Build:
+		return BuildChange
+	}
+	return NoChange
+}
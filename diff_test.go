@@ -0,0 +1,48 @@
+package semver
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		exp    Level
+		reason string
+	}{
+		{"1.4.2", "2.0.0-rc.1", MajorChange, "major to a prerelease"},
+		{"2.0.0", "1.0.0", MajorChange, "downgrade"},
+		{"1.4.2", "1.5.0", MinorChange, "minor"},
+		{"1.4.2", "1.4.3", PatchChange, "patch"},
+		{"1.2.3-rc.1", "1.2.3", PrereleaseChange, "prerelease to release"},
+		{"1.2.3-rc.1", "1.2.3-rc.2", PrereleaseChange, "prereleases"},
+		{"1.2.3-rc.01", "1.2.3-rc.1", NoChange, "identifiers of equal precedence"},
+		{"1.2.3+a", "1.2.3+b", BuildChange, "build"},
+		{"1.2.3", "v1.2.3", NoChange, "equal"},
+	}
+
+	for _, test := range tests {
+		if l := Diff(MustParse(test.a), MustParse(test.b)); l != test.exp {
+			t.Errorf("%s: Diff(%s, %s) = %s, expected %s", test.reason, test.a, test.b, l, test.exp)
+		}
+	}
+}
+
+func TestLevelText(t *testing.T) {
+	for l := NoChange; l <= MajorChange; l++ {
+		b, err := l.MarshalText()
+		if err != nil {
+			t.Errorf("%s: %s", l, err)
+			continue
+		}
+		var got Level
+		if err := got.UnmarshalText(b); err != nil || got != l {
+			t.Errorf("%s: round trip gave %s, %v", l, got, err)
+		}
+	}
+	if _, err := Level(42).MarshalText(); err == nil {
+		t.Errorf("should not marshal an unknown level")
+	}
+	l := MinorChange
+	if err := l.UnmarshalText([]byte("huge")); err == nil || l != MinorChange {
+		t.Errorf("unknown name: %s, %v", l, err)
+	}
+}
@@ -0,0 +1,144 @@
+package semver
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Types such as Tag embed a Semver but add to its String form, so the
+// encoders they would otherwise inherit from Semver would drop whatever they
+// add. Instead they implement each encoding Semver does with the helpers
+// below, which encode the type's text form as a string in that format and
+// decode it with the type's UnmarshalText. name is the type's name in
+// errors.
+
+func marshalTextJSON(m encoding.TextMarshaler) ([]byte, error) {
+	b, err := m.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
+// unmarshalTextJSON decodes a JSON string. null leaves u unchanged.
+func unmarshalTextJSON(u encoding.TextUnmarshaler, name string, arr []byte) error {
+	if string(bytes.TrimSpace(arr)) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(arr, &s); err != nil {
+		return fmt.Errorf("Invalid %s JSON: expected a string, got %s", name, arr)
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func marshalTextYAML(m encoding.TextMarshaler) (interface{}, error) {
+	b, err := m.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func unmarshalTextYAML(u encoding.TextUnmarshaler, unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func unmarshalTextTOML(u encoding.TextUnmarshaler, name string, data interface{}) error {
+	s, ok := data.(string)
+	if !ok {
+		return fmt.Errorf("Invalid %s TOML: expected a string, got %T", name, data)
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func marshalTextBSON(m encoding.TextMarshaler) (byte, []byte, error) {
+	b, err := m.MarshalText()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonString, appendBSONString(nil, string(b)), nil
+}
+
+func unmarshalTextBSON(u encoding.TextUnmarshaler, name string, typ byte, data []byte) error {
+	if typ != bsonString {
+		return fmt.Errorf("Invalid %s BSON: expected a string, got type 0x%02x", name, typ)
+	}
+	s, rest, err := readBSONString(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("Invalid %s BSON: trailing data", name)
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func marshalTextMsgpack(m encoding.TextMarshaler) ([]byte, error) {
+	b, err := m.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return appendMsgpackString(nil, string(b)), nil
+}
+
+func unmarshalTextMsgpack(u encoding.TextUnmarshaler, name string, data []byte) error {
+	r := msgpackReader(data)
+	s, err := r.readString()
+	if err != nil {
+		return err
+	}
+	if len(r) != 0 {
+		return fmt.Errorf("Invalid %s msgpack: trailing data", name)
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func marshalTextCBOR(m encoding.TextMarshaler) ([]byte, error) {
+	b, err := m.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return append(appendCBORHead(make([]byte, 0, len(b)+9), cborText, uint64(len(b))), b...), nil
+}
+
+func unmarshalTextCBOR(u encoding.TextUnmarshaler, data []byte) error {
+	s, err := readCBORText(data)
+	if err != nil {
+		return err
+	}
+	return u.UnmarshalText(s)
+}
+
+func scanText(u encoding.TextUnmarshaler, name string, src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return u.UnmarshalText([]byte(src))
+	case []byte:
+		return u.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("Cannot scan NULL into a %s", name)
+	}
+	return fmt.Errorf("Cannot scan %T into a %s", src, name)
+}
+
+func marshalTextGQL(m encoding.TextMarshaler, w io.Writer) {
+	b, _ := m.MarshalText()
+	io.WriteString(w, strconv.Quote(string(b)))
+}
+
+func unmarshalTextGQL(u encoding.TextUnmarshaler, name string, v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("%s must be a string, got %T", name, v)
+	}
+	return u.UnmarshalText([]byte(s))
+}
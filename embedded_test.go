@@ -0,0 +1,153 @@
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// embeddedEncoder is the encoding side of a type that embeds Semver.
+type embeddedEncoder interface {
+	encoding.TextMarshaler
+	json.Marshaler
+	MarshalYAML() (interface{}, error)
+	MarshalBSONValue() (byte, []byte, error)
+	MarshalMsgpack() ([]byte, error)
+	MarshalCBOR() ([]byte, error)
+	MarshalBinary() ([]byte, error)
+	Value() (driver.Value, error)
+	String() string
+}
+
+// embeddedDecoder is the decoding side of a type that embeds Semver.
+type embeddedDecoder interface {
+	encoding.TextUnmarshaler
+	json.Unmarshaler
+	UnmarshalYAML(func(interface{}) error) error
+	UnmarshalTOML(interface{}) error
+	UnmarshalBSONValue(byte, []byte) error
+	UnmarshalMsgpack([]byte) error
+	UnmarshalCBOR([]byte) error
+	UnmarshalBinary([]byte) error
+	Scan(interface{}) error
+	UnmarshalGQL(interface{}) error
+	Set(string) error
+}
+
+// testEmbeddedEncodings checks that v, a value of a type that embeds Semver,
+// is encoded as the string s in every format and decodes back to v in each.
+// fresh returns a pointer to a new zero value of the type.
+func testEmbeddedEncodings(t *testing.T, v embeddedEncoder, s string, fresh func() embeddedDecoder) {
+	t.Helper()
+	check := func(format string, d embeddedDecoder, err error) {
+		t.Helper()
+		if err != nil {
+			t.Errorf("%s: %s: %s", s, format, err)
+		} else if got := reflect.ValueOf(d).Elem().Interface(); got != v {
+			t.Errorf("%s: %s round trip: %#v != %#v", s, format, got, v)
+		}
+	}
+
+	if got := v.String(); got != s {
+		t.Errorf("String: %s != %s", got, s)
+	}
+
+	b, err := v.MarshalText()
+	if string(b) != s || err != nil {
+		t.Errorf("%s: MarshalText: %s, %v", s, b, err)
+	}
+	d := fresh()
+	check("text", d, d.UnmarshalText(b))
+	d = fresh()
+	check("flag", d, d.Set(s))
+
+	b, err = v.MarshalJSON()
+	if string(b) != strconv.Quote(s) || err != nil {
+		t.Errorf("%s: MarshalJSON: %s, %v", s, b, err)
+	}
+	d = fresh()
+	check("JSON", d, d.UnmarshalJSON(b))
+
+	y, err := v.MarshalYAML()
+	if y != s || err != nil {
+		t.Errorf("%s: MarshalYAML: %v, %v", s, y, err)
+	}
+	d = fresh()
+	check("YAML", d, d.UnmarshalYAML(scalar(s)))
+	d = fresh()
+	check("TOML", d, d.UnmarshalTOML(s))
+
+	typ, b, err := v.MarshalBSONValue()
+	if typ != bsonString || !bytes.Equal(b, appendBSONString(nil, s)) || err != nil {
+		t.Errorf("%s: MarshalBSONValue: 0x%02x %q, %v", s, typ, b, err)
+	}
+	d = fresh()
+	check("BSON", d, d.UnmarshalBSONValue(typ, b))
+
+	b, err = v.MarshalMsgpack()
+	if !bytes.Equal(b, appendMsgpackString(nil, s)) || err != nil {
+		t.Errorf("%s: MarshalMsgpack: %q, %v", s, b, err)
+	}
+	d = fresh()
+	check("msgpack", d, d.UnmarshalMsgpack(b))
+
+	b, err = v.MarshalCBOR()
+	if !bytes.Equal(b, append(appendCBORHead(nil, cborText, uint64(len(s))), s...)) || err != nil {
+		t.Errorf("%s: MarshalCBOR: %q, %v", s, b, err)
+	}
+	d = fresh()
+	check("CBOR", d, d.UnmarshalCBOR(b))
+
+	b, err = v.MarshalBinary()
+	if err != nil {
+		t.Errorf("%s: MarshalBinary: %v", s, err)
+	}
+	d = fresh()
+	check("binary", d, d.UnmarshalBinary(b))
+
+	val, err := v.Value()
+	if val != s || err != nil {
+		t.Errorf("%s: Value: %v, %v", s, val, err)
+	}
+	d = fresh()
+	check("SQL", d, d.Scan(val))
+	d = fresh()
+	check("SQL bytes", d, d.Scan([]byte(s)))
+	d = fresh()
+	check("GraphQL", d, d.UnmarshalGQL(s))
+
+	// encoders that find the methods themselves
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		t.Errorf("%s: gob: %s", s, err)
+	}
+	d = fresh()
+	check("gob", d, gob.NewDecoder(&buf).Decode(d))
+	b, err = json.Marshal(map[string]interface{}{"v": v})
+	if string(b) != `{"v":`+strconv.Quote(s)+`}` || err != nil {
+		t.Errorf("%s: json.Marshal: %s, %v", s, b, err)
+	}
+
+	// only strings are accepted
+	d = fresh()
+	for format, err := range map[string]error{
+		"JSON object": d.UnmarshalJSON([]byte(`{"major":1,"minor":2,"patch":3}`)),
+		"TOML table":  d.UnmarshalTOML(map[string]interface{}{"major": int64(1)}),
+		"BSON int":    d.UnmarshalBSONValue(bsonInt32, []byte{1, 0, 0, 0}),
+		"msgpack int": d.UnmarshalMsgpack([]byte{1}),
+		"SQL NULL":    d.Scan(nil),
+		"GraphQL int": d.UnmarshalGQL(1),
+	} {
+		if err == nil {
+			t.Errorf("%s: expected error", format)
+		}
+	}
+	if err := d.UnmarshalJSON([]byte("null")); err != nil || !reflect.ValueOf(d).Elem().IsZero() {
+		t.Errorf("JSON null should leave the value unchanged: %#v, %v", d, err)
+	}
+}
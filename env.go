@@ -0,0 +1,33 @@
+package semver
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FromEnv parses the version in the environment variable key. Surrounding
+// whitespace is trimmed; otherwise the value must be a strictly valid semver
+// string. def is returned if the variable is unset or empty.
+func FromEnv(key string, def Semver) (Semver, error) {
+	s, ok := os.LookupEnv(key)
+	s = strings.TrimSpace(s)
+	if !ok || s == "" {
+		return def, nil
+	}
+	ver, err := Parse(s)
+	if err != nil {
+		return def, fmt.Errorf("Invalid semver in $%s: %s", key, err)
+	}
+	return ver, nil
+}
+
+// MustFromEnv is like FromEnv, but panics if the variable holds an invalid
+// version.
+func MustFromEnv(key string, def Semver) Semver {
+	if ver, err := FromEnv(key, def); err != nil {
+		panic(err)
+	} else {
+		return ver
+	}
+}
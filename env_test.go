@@ -0,0 +1,57 @@
+package semver
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+type envTest struct {
+	value    string
+	set      bool
+	expected Semver
+	reason   string
+}
+
+func TestFromEnv(t *testing.T) {
+	const key = "SEMVER_TEST_FROM_ENV"
+	def := Semver{Major: 1}
+	tests := []envTest{
+		{"", false, def, "unset"},
+		{"", true, def, "empty"},
+		{"  \t", true, def, "blank"},
+		{"2.3.4", true, Semver{Major: 2, Minor: 3, Patch: 4}, "plain"},
+		{" v2.3.4-rc.1\n", true, Semver{Major: 2, Minor: 3, Patch: 4, Prerelease: "rc.1"}, "trimmed"},
+	}
+
+	defer os.Unsetenv(key)
+	for _, test := range tests {
+		if test.set {
+			os.Setenv(key, test.value)
+		} else {
+			os.Unsetenv(key)
+		}
+		ver, err := FromEnv(key, def)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+		} else if ver != test.expected {
+			t.Errorf("%s: %+v != %+v", test.reason, ver, test.expected)
+		}
+	}
+
+	os.Setenv(key, "2.3")
+	if ver, err := FromEnv(key, def); err == nil {
+		t.Errorf("expected error, returned: %+v", ver)
+	} else if !strings.Contains(err.Error(), key) || ver != def {
+		t.Errorf("error should name the variable and return the default: %s, %+v", err, ver)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("MustFromEnv should panic on an invalid version")
+			}
+		}()
+		MustFromEnv(key, def)
+	}()
+}
@@ -0,0 +1,41 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError records a failure to parse one element of a batch.
+type ParseError struct {
+	Index int
+	Input string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d: %q: %s", e.Index, e.Input, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors aggregates every failure in a batch, in input order.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d invalid semver strings: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual *ParseError values, for errors.Is and errors.As.
+func (e ParseErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
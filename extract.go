@@ -0,0 +1,107 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// findReg matches things that look like versions inside larger text. At
+// least a major and minor version are required, so lone numbers aren't
+// mistaken for versions.
+var findReg = regexp.MustCompile(`[vV]?\d+\.\d+(?:\.\d+)?(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?`)
+
+// assetWords are the file extensions and platform names that release assets
+// append to a version, as in "tool-1.2.3-linux-amd64.tar.gz". FindAll ends a
+// prerelease or build at the first of them rather than reading them as part
+// of the version.
+var assetWords = map[string]bool{
+	"tar": true, "gz": true, "tgz": true, "bz2": true, "xz": true, "zst": true,
+	"zip": true, "7z": true, "exe": true, "msi": true, "dmg": true, "pkg": true,
+	"deb": true, "rpm": true, "apk": true, "jar": true, "whl": true,
+	"appimage": true, "sha256": true, "sig": true, "asc": true, "txt": true,
+	"linux": true, "darwin": true, "macos": true, "osx": true, "windows": true,
+	"win32": true, "win64": true, "freebsd": true, "openbsd": true,
+	"netbsd": true, "android": true, "ios": true, "amd64": true, "x86": true,
+	"x64": true, "i386": true, "i686": true, "arm": true, "arm64": true,
+	"armv6": true, "armv7": true, "aarch64": true, "ppc64le": true,
+	"s390x": true, "riscv64": true, "universal": true,
+}
+
+// Match is a version found in text by FindAll.
+type Match struct {
+	Version    Semver
+	Text       string // the match as written, e.g. "v1.25"
+	Start, End int    // byte offsets of Text in the searched string
+}
+
+// FindAll returns every version in text, in order, as Coerce reads them, so
+// "go1.22" and "v1.25.4" are found as 1.22.0 and 1.25.4. Matches followed by
+// another numeric component, such as the 10.0.0 in an address like 10.0.0.1,
+// are skipped along with the rest of the dotted numbers, so nothing is found
+// in 1.2.3.4.5 either. A prerelease or build ends before any file extension
+// or platform name in it, so "tool-1.2.3-linux-amd64.tar.gz" gives 1.2.3.
+func FindAll(text string) []Match {
+	var out []Match
+	for pos := 0; pos < len(text); {
+		loc := findReg.FindStringIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		pos = end
+		if dottedDigit(text, end) {
+			for pos < len(text) && (isDigit(text[pos]) || dottedDigit(text, pos)) {
+				pos++
+			}
+			continue
+		}
+		end = start + trimAsset(text[start:end])
+		v, err := Coerce(text[start:end])
+		if err != nil {
+			continue
+		}
+		out = append(out, Match{v, text[start:end], start, end})
+	}
+	return out
+}
+
+// Extract returns the first version in text, such as 1.25.4 in the output
+// "nginx/1.25.4 (ubuntu)" of a --version flag. See FindAll for what counts
+// as a version.
+func Extract(text string) (Semver, error) {
+	if ms := FindAll(text); len(ms) > 0 {
+		return ms[0].Version, nil
+	}
+	return Semver{}, fmt.Errorf("No version found in %q", text)
+}
+
+// trimAsset returns the length of match, a match of findReg, without any
+// identifier in its prerelease or build that is in assetWords and everything
+// after it.
+func trimAsset(match string) int {
+	i := strings.IndexAny(match, "-+")
+	if i < 0 {
+		return len(match)
+	}
+	for i < len(match) {
+		j := i + 1
+		for j < len(match) && !strings.ContainsRune(".-+", rune(match[j])) {
+			j++
+		}
+		if assetWords[strings.ToLower(match[i+1:j])] {
+			return i
+		}
+		i = j
+	}
+	return len(match)
+}
+
+// dottedDigit reports whether text[i:] starts with a dot and a digit.
+func dottedDigit(text string, i int) bool {
+	return i+1 < len(text) && text[i] == '.' && isDigit(text[i+1])
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,84 @@
+package semver
+
+import "testing"
+
+func TestExtract(t *testing.T) {
+	good := []goodParseTest{
+		{"nginx/1.25.4 (ubuntu)", Semver{Major: 1, Minor: 25, Patch: 4}, "server header"},
+		{"go version go1.22.3 linux/amd64", Semver{Major: 1, Minor: 22, Patch: 3}, "attached to a word"},
+		{"Python 3.12", Semver{Major: 3, Minor: 12}, "partial"},
+		{"git version 2.39.3 (Apple Git-146)", Semver{Major: 2, Minor: 39, Patch: 3}, "first of several numbers"},
+		{"tool v2.0.0-rc.1+build.5, built today", Semver{Major: 2, Prerelease: "rc.1", Build: "build.5"}, "prerelease and build"},
+		{"listening on 10.0.0.1, version 1.4.0.", Semver{Major: 1, Minor: 4}, "address skipped, trailing dot"},
+		{"1.2.3.4.5 then 2.0.0", Semver{Major: 2}, "whole dotted run skipped"},
+		{"build 1.2.3.4-rc.1 of 3.1", Semver{Major: 3, Minor: 1}, "four parts with a prerelease skipped"},
+		{"tool-1.2.3-linux-amd64.tar.gz", Semver{Major: 1, Minor: 2, Patch: 3}, "release asset"},
+		{"tool-2.0.0-rc.1-darwin-arm64.zip", Semver{Major: 2, Prerelease: "rc.1"}, "prerelease asset"},
+		{"tool-1.4.0-beta.tar.gz", Semver{Major: 1, Minor: 4, Prerelease: "beta"}, "prerelease archive"},
+		{"tool-1.4.0+build.5.Windows.exe", Semver{Major: 1, Minor: 4, Build: "build.5"}, "build asset"},
+		{"tool-v3.1.0-x86_64.AppImage", Semver{Major: 3, Minor: 1}, "platform with an underscore"},
+	}
+
+	for _, test := range good {
+		v, err := Extract(test.given)
+		if err != nil {
+			t.Errorf("%s: %s; given: %s", test.reason, err, test.given)
+		} else if v != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, v, test.exp)
+		}
+	}
+
+	bad := []badParseTest{
+		{"", "empty"},
+		{"no version here", "no numbers"},
+		{"build 42", "lone number"},
+		{"192.168.1.1", "address"},
+		{"1.2.3.4", "four parts"},
+		{"1.2.3.4.5", "five parts"},
+		{"v1.2.3.4.5.6", "six parts"},
+		{"10.0.0.1.2, 1.2.3.4", "several runs"},
+	}
+
+	for _, test := range bad {
+		if v, err := Extract(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, v)
+		}
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	text := "FROM golang:1.22 AS build\nFROM alpine:v3.19.1"
+	ms := FindAll(text)
+	exp := []Match{
+		{Semver{Major: 1, Minor: 22}, "1.22", 12, 16},
+		{Semver{Major: 3, Minor: 19, Patch: 1}, "v3.19.1", 38, 45},
+	}
+	if len(ms) != len(exp) {
+		t.Fatalf("found %+v, expected %+v", ms, exp)
+	}
+	for i, m := range ms {
+		if m != exp[i] {
+			t.Errorf("match %d: %+v != %+v", i, m, exp[i])
+		}
+		if text[m.Start:m.End] != m.Text {
+			t.Errorf("match %d: offsets %d:%d don't cover %q", i, m.Start, m.End, m.Text)
+		}
+	}
+}
+
+func TestFindAllAssets(t *testing.T) {
+	text := "https://github.com/o/tool/releases/download/v1.2.3/tool-1.2.3-linux-amd64.tar.gz"
+	ms := FindAll(text)
+	exp := []Match{
+		{Semver{Major: 1, Minor: 2, Patch: 3}, "v1.2.3", 44, 50},
+		{Semver{Major: 1, Minor: 2, Patch: 3}, "1.2.3", 56, 61},
+	}
+	if len(ms) != len(exp) {
+		t.Fatalf("found %+v, expected %+v", ms, exp)
+	}
+	for i, m := range ms {
+		if m != exp[i] {
+			t.Errorf("match %d: %+v != %+v", i, m, exp[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Feature declares the versions of a component, such as a connected client,
+// that have a feature.
+type Feature struct {
+	Name string
+	// Since is the first version with the feature, and Until the first
+	// version without it. Either may be zero, for no lower or upper bound.
+	// They are compared by precedence, so 2.0.0-rc.1 is before Since 2.0.0.
+	Since, Until Semver
+	// Range, if set, is used instead of Since and Until.
+	Range *Constraint
+}
+
+// has reports whether version v has the feature.
+func (f Feature) has(v Semver) bool {
+	if f.Range != nil {
+		return f.Range.Check(v)
+	}
+	if v.Cmp(f.Since) < 0 {
+		return false
+	}
+	return f.Until == (Semver{}) || v.Cmp(f.Until) < 0
+}
+
+// FeatureGate is a registry of features keyed by the versions that have
+// them, so that code which must behave differently for different versions
+// asks one place. The zero FeatureGate is empty and ready to use, and a
+// FeatureGate is safe for concurrent use.
+type FeatureGate struct {
+	mu       sync.RWMutex
+	features map[string]Feature
+}
+
+// Register adds a feature. Names must be unique, and Until, if set, must be
+// after Since.
+func (g *FeatureGate) Register(f Feature) error {
+	if f.Range == nil && f.Until != (Semver{}) && f.Until.Cmp(f.Since) <= 0 {
+		return fmt.Errorf("Invalid feature %s: until %s is not after since %s", f.Name, f.Until, f.Since)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.features[f.Name]; ok {
+		return fmt.Errorf("Feature %s is already registered", f.Name)
+	}
+	if g.features == nil {
+		g.features = make(map[string]Feature)
+	}
+	g.features[f.Name] = f
+	return nil
+}
+
+// MustRegister is like Register, but panics on error, for declaring
+// features at init time.
+func (g *FeatureGate) MustRegister(f Feature) {
+	if err := g.Register(f); err != nil {
+		panic(err)
+	}
+}
+
+// Enabled reports whether the named feature is available in version v.
+// Unknown features are never enabled.
+func (g *FeatureGate) Enabled(feature string, v Semver) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	f, ok := g.features[feature]
+	return ok && f.has(v)
+}
+
+// EnabledFeatures returns the names of the features available in version v,
+// sorted.
+func (g *FeatureGate) EnabledFeatures(v Semver) []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var names []string
+	for name, f := range g.features {
+		if f.has(v) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
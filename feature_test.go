@@ -0,0 +1,53 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFeatureGate(t *testing.T) {
+	var g FeatureGate
+	streaming := MustParseConstraint("^1.4.0 || >=2.1.0")
+	g.MustRegister(Feature{Name: "compression", Since: MustParse("1.2.0")})
+	g.MustRegister(Feature{Name: "legacy-auth", Until: MustParse("2.0.0")})
+	g.MustRegister(Feature{Name: "batch", Since: MustParse("1.5.0"), Until: MustParse("3.0.0")})
+	g.MustRegister(Feature{Name: "streaming", Range: &streaming})
+
+	tests := []struct {
+		feature string
+		version string
+		exp     bool
+		reason  string
+	}{
+		{"compression", "1.2.0", true, "since is inclusive"},
+		{"compression", "1.1.9", false, "before since"},
+		{"compression", "1.2.0-rc.1", false, "prerelease of since"},
+		{"legacy-auth", "0.1.0", true, "no lower bound"},
+		{"legacy-auth", "2.0.0", false, "until is exclusive"},
+		{"legacy-auth", "2.0.0-rc.1", true, "prerelease of until"},
+		{"batch", "2.9.9", true, "between since and until"},
+		{"streaming", "1.6.0", true, "range"},
+		{"streaming", "2.0.0", false, "gap in the range"},
+		{"unknown", "1.0.0", false, "unknown feature"},
+	}
+
+	for _, test := range tests {
+		if ok := g.Enabled(test.feature, MustParse(test.version)); ok != test.exp {
+			t.Errorf("%s: Enabled(%s, %s) = %t", test.reason, test.feature, test.version, ok)
+		}
+	}
+
+	if names, exp := g.EnabledFeatures(MustParse("1.6.0")), []string{"batch", "compression", "legacy-auth", "streaming"}; !reflect.DeepEqual(names, exp) {
+		t.Errorf("%v != %v", names, exp)
+	}
+	if names := g.EnabledFeatures(MustParse("3.0.0")); !reflect.DeepEqual(names, []string{"compression", "streaming"}) {
+		t.Errorf("unexpected features for 3.0.0: %v", names)
+	}
+
+	if err := g.Register(Feature{Name: "batch"}); err == nil {
+		t.Errorf("expected an error registering a feature twice")
+	}
+	if err := g.Register(Feature{Name: "broken", Since: MustParse("2.0.0"), Until: MustParse("1.0.0")}); err == nil {
+		t.Errorf("expected an error for until before since")
+	}
+}
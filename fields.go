@@ -0,0 +1,110 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	_ json.Marshaler   = CamelCaseObject{}
+	_ json.Unmarshaler = (*CamelCaseObject)(nil)
+	_ json.Marshaler   = SnakeCaseObject{}
+	_ json.Unmarshaler = (*SnakeCaseObject)(nil)
+)
+
+// objectFields names the keys of the object form of a Semver.
+type objectFields struct {
+	major, minor, patch, prerelease, build string
+}
+
+var (
+	defaultFields = objectFields{"major", "minor", "patch", "prerelease", "build"}
+	camelFields   = objectFields{"major", "minor", "patch", "preRelease", "build"}
+	snakeFields   = objectFields{"major", "minor", "patch", "pre_release", "build"}
+)
+
+// canonical maps key, matched case-insensitively, to the default field name,
+// or returns "" if it isn't one of fields.
+func (f objectFields) canonical(key string) string {
+	switch {
+	case strings.EqualFold(key, f.major):
+		return "major"
+	case strings.EqualFold(key, f.minor):
+		return "minor"
+	case strings.EqualFold(key, f.patch):
+		return "patch"
+	case strings.EqualFold(key, f.prerelease):
+		return "prerelease"
+	case strings.EqualFold(key, f.build):
+		return "build"
+	}
+	return ""
+}
+
+// marshal encodes v as an object with major, minor and patch, and prerelease
+// and build if they're set, in that order.
+func (f objectFields) marshal(v Semver) ([]byte, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	b := []byte{'{'}
+	b = appendJSONField(b, f.major, strconv.Itoa(v.Major))
+	b = appendJSONField(append(b, ','), f.minor, strconv.Itoa(v.Minor))
+	b = appendJSONField(append(b, ','), f.patch, strconv.Itoa(v.Patch))
+	if v.Prerelease != "" {
+		b = appendJSONField(append(b, ','), f.prerelease, strconv.Quote(v.Prerelease))
+	}
+	if v.Build != "" {
+		b = appendJSONField(append(b, ','), f.build, strconv.Quote(v.Build))
+	}
+	return append(b, '}'), nil
+}
+
+// unmarshal decodes an object with all of major, minor and patch, or a
+// version string.
+func (f objectFields) unmarshal(v *Semver, arr []byte) error {
+	trimmed := bytes.TrimLeftFunc(arr, unicode.IsSpace)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return v.decodeObject(arr, f, "major", "minor", "patch")
+	}
+	return v.UnmarshalJSON(arr)
+}
+
+// appendJSONField appends "key":value. Keys and validated prerelease and
+// build strings are plain ASCII, for which strconv.Quote matches JSON.
+func appendJSONField(b []byte, key, value string) []byte {
+	b = strconv.AppendQuote(b, key)
+	b = append(b, ':')
+	return append(b, value...)
+}
+
+// CamelCaseObject is a Semver that is encoded in JSON as an object with
+// camelCase keys: major, minor, patch, preRelease and build.
+type CamelCaseObject Semver
+
+// MarshalJSON encodes the version as an object with camelCase keys.
+func (c CamelCaseObject) MarshalJSON() ([]byte, error) {
+	return camelFields.marshal(Semver(c))
+}
+
+// UnmarshalJSON decodes an object with camelCase keys, or a version string.
+func (c *CamelCaseObject) UnmarshalJSON(arr []byte) error {
+	return camelFields.unmarshal((*Semver)(c), arr)
+}
+
+// SnakeCaseObject is a Semver that is encoded in JSON as an object with
+// snake_case keys: major, minor, patch, pre_release and build.
+type SnakeCaseObject Semver
+
+// MarshalJSON encodes the version as an object with snake_case keys.
+func (s SnakeCaseObject) MarshalJSON() ([]byte, error) {
+	return snakeFields.marshal(Semver(s))
+}
+
+// UnmarshalJSON decodes an object with snake_case keys, or a version string.
+func (s *SnakeCaseObject) UnmarshalJSON(arr []byte) error {
+	return snakeFields.unmarshal((*Semver)(s), arr)
+}
@@ -0,0 +1,51 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCaseObjects(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.5")
+
+	b, err := json.Marshal(CamelCaseObject(v))
+	if exp := `{"major":1,"minor":2,"patch":3,"preRelease":"rc.1","build":"build.5"}`; err != nil || string(b) != exp {
+		t.Errorf("camelCase: %s, %v", b, err)
+	}
+	var c CamelCaseObject
+	if err := json.Unmarshal(b, &c); err != nil || Semver(c) != v {
+		t.Errorf("camelCase round trip: %+v, %v", c, err)
+	}
+
+	b, err = json.Marshal(SnakeCaseObject(v))
+	if exp := `{"major":1,"minor":2,"patch":3,"pre_release":"rc.1","build":"build.5"}`; err != nil || string(b) != exp {
+		t.Errorf("snake_case: %s, %v", b, err)
+	}
+	var s SnakeCaseObject
+	if err := json.Unmarshal(b, &s); err != nil || Semver(s) != v {
+		t.Errorf("snake_case round trip: %+v, %v", s, err)
+	}
+
+	if b, _ := json.Marshal(SnakeCaseObject(MustParse("1.0.0"))); string(b) != `{"major":1,"minor":0,"patch":0}` {
+		t.Errorf("empty prerelease and build should be left out: %s", b)
+	}
+	if err := json.Unmarshal([]byte(`"2.0.0"`), &s); err != nil || Semver(s) != MustParse("2.0.0") {
+		t.Errorf("string form: %+v, %v", s, err)
+	}
+
+	bad := []badJsonTest{
+		{`{"major": 1, "minor": 0, "patch": 0, "prerelease": "rc.1"}`, "key from another convention"},
+		{`{"major": 1, "minor": 0}`, "missing patch"},
+		{`{"major": 1, "minor": 0, "patch": 0, "pre_release": "a b"}`, "invalid prerelease"},
+		{`{"major": 1, "minor": 0, "patch": 0, "PRE_RELEASE": "rc.1", "pre_release": "rc.2"}`, "repeated key"},
+	}
+	for _, test := range bad {
+		var s SnakeCaseObject
+		if err := json.Unmarshal([]byte(test.given), &s); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, s)
+		}
+	}
+	if _, err := json.Marshal(CamelCaseObject{Patch: -1}); err == nil {
+		t.Errorf("expected error marshaling an invalid version")
+	}
+}
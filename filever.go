@@ -0,0 +1,51 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ToFileVersion maps v onto the four 16-bit fields of a Windows FileVersion
+// (as stamped into PE version resources).
+//
+// Major, minor and patch become the first three fields. The fourth is the
+// build number: the last dot-separated identifier of the build metadata if it
+// is numeric, and 0 otherwise. The prerelease and any other build metadata are
+// dropped, so 1.2.3-rc.1+ci.42 and 1.2.3+42 both map to 1.2.3.42.
+//
+// An error is returned if a field doesn't fit in 16 bits.
+func (v Semver) ToFileVersion() (a, b, c, d uint16, err error) {
+	var build int
+	if last := v.Build[strings.LastIndex(v.Build, ".")+1:]; isDigits(last) {
+		build, _ = strconv.Atoi(last)
+	}
+	for _, n := range []int{v.Major, v.Minor, v.Patch, build} {
+		if n < 0 || n > math.MaxUint16 {
+			err = fmt.Errorf("%s doesn't fit in a FileVersion: %d is out of range", v, n)
+			return
+		}
+	}
+	return uint16(v.Major), uint16(v.Minor), uint16(v.Patch), uint16(build), nil
+}
+
+// FromFileVersion converts a Windows FileVersion to a Semver, the inverse of
+// ToFileVersion: a non-zero fourth field becomes the build metadata.
+func FromFileVersion(a, b, c, d uint16) Semver {
+	v := Semver{Major: int(a), Minor: int(b), Patch: int(c)}
+	if d != 0 {
+		v.Build = strconv.Itoa(int(d))
+	}
+	return v
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,48 @@
+package semver
+
+import "testing"
+
+type fileVersionTest struct {
+	given      string
+	a, b, c, d uint16
+	reason     string
+}
+
+func TestToFileVersion(t *testing.T) {
+	tests := []fileVersionTest{
+		{"1.2.3", 1, 2, 3, 0, "no build"},
+		{"1.2.3+42", 1, 2, 3, 42, "numeric build"},
+		{"1.2.3+ci.42", 1, 2, 3, 42, "last build identifier"},
+		{"1.2.3+42.sha", 1, 2, 3, 0, "non-numeric last build identifier"},
+		{"1.2.3-rc.1+7", 1, 2, 3, 7, "prerelease dropped"},
+		{"65535.0.0", 65535, 0, 0, 0, "largest major"},
+	}
+
+	for _, test := range tests {
+		a, b, c, d, err := MustParse(test.given).ToFileVersion()
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+		} else if a != test.a || b != test.b || c != test.c || d != test.d {
+			t.Errorf("%s: %d.%d.%d.%d != %d.%d.%d.%d", test.reason, a, b, c, d, test.a, test.b, test.c, test.d)
+		}
+	}
+
+	for _, s := range []string{"65536.0.0", "1.65536.0", "1.2.3+65536"} {
+		if _, _, _, _, err := MustParse(s).ToFileVersion(); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
+
+func TestFromFileVersion(t *testing.T) {
+	if v := FromFileVersion(1, 2, 3, 0); v != (Semver{Major: 1, Minor: 2, Patch: 3}) {
+		t.Errorf("zero build: %+v", v)
+	}
+	v := FromFileVersion(1, 2, 3, 4)
+	if v != (Semver{Major: 1, Minor: 2, Patch: 3, Build: "4"}) {
+		t.Errorf("non-zero build: %+v", v)
+	}
+	if a, b, c, d, _ := v.ToFileVersion(); a != 1 || b != 2 || c != 3 || d != 4 {
+		t.Errorf("round trip: %d.%d.%d.%d", a, b, c, d)
+	}
+}
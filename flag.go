@@ -0,0 +1,22 @@
+package semver
+
+import "flag"
+
+var _ flag.Value = (*Semver)(nil)
+
+// Set parses s into ver, for flag.Value. ver is left unchanged on error, so
+// the flag package reports the parse error against the flag.
+func (ver *Semver) Set(s string) error {
+	return ver.UnmarshalText([]byte(s))
+}
+
+// Flag defines a version flag with the given name, default value and usage
+// string on flag.CommandLine, and returns a pointer to the variable the flag
+// sets. Use flag.Var(&v, ...) to bind an existing variable or another
+// FlagSet.
+func Flag(name string, def Semver, usage string) *Semver {
+	p := new(Semver)
+	*p = def
+	flag.Var(p, name, usage)
+	return p
+}
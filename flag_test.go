@@ -0,0 +1,40 @@
+package semver
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	min := MustParse("1.0.0")
+	fs.Var(&min, "min-version", "minimum version")
+
+	if err := fs.Parse([]string{"-min-version", "1.4.0"}); err != nil {
+		t.Fatal(err)
+	}
+	if min != MustParse("1.4.0") {
+		t.Errorf("flag not set: %s", min)
+	}
+	if err := fs.Parse([]string{"-min-version", "1.4"}); err == nil {
+		t.Errorf("expected parse error")
+	}
+	if min != MustParse("1.4.0") {
+		t.Errorf("invalid value should leave the flag unchanged: %s", min)
+	}
+	if def := fs.Lookup("min-version").DefValue; def != "1.0.0" {
+		t.Errorf("unexpected default: %s", def)
+	}
+}
+
+func TestFlagCommandLine(t *testing.T) {
+	p := Flag("semver-test-version", MustParse("2.0.0"), "usage")
+	if *p != MustParse("2.0.0") {
+		t.Errorf("default not applied: %s", p)
+	}
+	if err := flag.Set("semver-test-version", "2.1.0"); err != nil || *p != MustParse("2.1.0") {
+		t.Errorf("Set: %s, %v", p, err)
+	}
+}
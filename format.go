@@ -0,0 +1,116 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ fmt.Formatter  = Semver{}
+	_ fmt.GoStringer = Semver{}
+)
+
+// Format implements fmt.Formatter. Besides the usual %v, %s and %q, which
+// format the String form, it supports verbs for the individual components:
+//
+//	%M	major version
+//	%m	minor version
+//	%n	patch version (fmt reserves %p for pointers)
+//	%P	prerelease, without the leading -
+//	%B	build metadata, without the leading +
+//
+// %#v is GoString. %+v is the full form, including build metadata, and is the same as %v.
+// A precision on %v or %s keeps only that many core components and drops
+// the prerelease and build metadata, so %.2v formats 1.2.3-rc.1 as 1.2.
+// Width and the - and 0 flags apply as they do for the underlying string and
+// integer verbs.
+func (v Semver) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if f.Flag('#') && verb == 'v' {
+			fmt.Fprint(f, v.GoString())
+			return
+		}
+		s := v.String()
+		if prec, ok := f.Precision(); ok {
+			s = v.core(prec)
+		}
+		fmt.Fprintf(f, directive(f, 's', false), s)
+	case 'q':
+		fmt.Fprintf(f, directive(f, 'q', false), v.String())
+	case 'M':
+		fmt.Fprintf(f, directive(f, 'd', true), v.Major)
+	case 'm':
+		fmt.Fprintf(f, directive(f, 'd', true), v.Minor)
+	case 'n':
+		fmt.Fprintf(f, directive(f, 'd', true), v.Patch)
+	case 'P':
+		fmt.Fprintf(f, directive(f, 's', true), v.Prerelease)
+	case 'B':
+		fmt.Fprintf(f, directive(f, 's', true), v.Build)
+	default:
+		fmt.Fprintf(f, "%%!%c(semver.Semver=%s)", verb, v.String())
+	}
+}
+
+// GoString formats the version as Go source that recreates it, e.g.
+// semver.MustParse("1.2.3-rc.1"), which is what %#v prints. Versions that
+// don't Validate are printed as a struct literal instead.
+func (v Semver) GoString() string {
+	if v.Validate() != nil {
+		return fmt.Sprintf("semver.Semver{Major:%d, Minor:%d, Patch:%d, Prerelease:%q, Build:%q}",
+			v.Major, v.Minor, v.Patch, v.Prerelease, v.Build)
+	}
+	return "semver.MustParse(" + strconv.Quote(v.String()) + ")"
+}
+
+// core formats the first n of the major, minor and patch versions.
+func (v Semver) core(n int) string {
+	if n < 1 {
+		n = 1
+	} else if n > 3 {
+		n = 3
+	}
+	parts := []int{v.Major, v.Minor, v.Patch}[:n]
+	strs := make([]string, n)
+	for i, p := range parts {
+		strs[i] = strconv.Itoa(p)
+	}
+	return strings.Join(strs, ".")
+}
+
+// directive rebuilds the flags and width of f as a format directive for verb,
+// including the precision only if withPrec is set.
+func directive(f fmt.State, verb rune, withPrec bool) string {
+	d := "%"
+	for _, c := range "+-# 0" {
+		if f.Flag(int(c)) {
+			d += string(c)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		d += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok && withPrec {
+		d += "." + strconv.Itoa(p)
+	}
+	return d + string(verb)
+}
+
+// formatEmbedded formats a type that embeds v but has its own String form s,
+// such as Tag: %v, %s and %q format s, and %#v prints goString. A precision
+// and the component verbs apply to v, as they do in Semver's Format.
+func formatEmbedded(f fmt.State, verb rune, s, goString string, v Semver) {
+	_, prec := f.Precision()
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, goString)
+	case (verb == 'v' || verb == 's') && !prec:
+		fmt.Fprintf(f, directive(f, 's', false), s)
+	case verb == 'q':
+		fmt.Fprintf(f, directive(f, 'q', false), s)
+	default:
+		v.Format(f, verb)
+	}
+}
@@ -0,0 +1,108 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+type formatTest struct {
+	format   string
+	expected string
+	reason   string
+}
+
+func TestFormat(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	tests := []formatTest{
+		{"%v", "1.2.3-rc.1+build.5", "default"},
+		{"%s", "1.2.3-rc.1+build.5", "string"},
+		{"%+v", "1.2.3-rc.1+build.5", "full"},
+		{"%q", `"1.2.3-rc.1+build.5"`, "quoted"},
+		{"%M", "1", "major"},
+		{"%m", "2", "minor"},
+		{"%n", "3", "patch"},
+		{"%P", "rc.1", "prerelease"},
+		{"%B", "build.5", "build"},
+		{"%.1v", "1", "one component"},
+		{"%.2v", "1.2", "two components"},
+		{"%.3s", "1.2.3", "three components"},
+		{"%.5v", "1.2.3", "precision larger than the core"},
+		{"%8.2v|", "     1.2|", "width"},
+		{"%-8.2v|", "1.2     |", "left justified"},
+		{"%03M", "001", "zero padded component"},
+		{"%x", "%!x(semver.Semver=1.2.3-rc.1+build.5)", "unknown verb"},
+		{"%#v", `semver.MustParse("1.2.3-rc.1+build.5")`, "go syntax"},
+	}
+
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, v); s != test.expected {
+			t.Errorf("%s: %q != %q", test.reason, s, test.expected)
+		}
+	}
+
+	if s := fmt.Sprint(v); s != v.String() {
+		t.Errorf("Sprint should match String: %q", s)
+	}
+	if s := fmt.Sprintf("%P|", MustParse("1.2.3")); s != "|" {
+		t.Errorf("empty prerelease: %q", s)
+	}
+}
+
+func TestGoString(t *testing.T) {
+	tests := []stringTest{
+		{Semver{1, 2, 3, "", ""}, `semver.MustParse("1.2.3")`, "release"},
+		{Semver{}, `semver.MustParse("0.0.0")`, "zero"},
+		{Semver{1, -2, 3, "", ""}, `semver.Semver{Major:1, Minor:-2, Patch:3, Prerelease:"", Build:""}`, "invalid"},
+		{Semver{1, 2, 3, "rc 1", ""}, `semver.Semver{Major:1, Minor:2, Patch:3, Prerelease:"rc 1", Build:""}`, "invalid prerelease"},
+	}
+
+	for _, test := range tests {
+		if s := fmt.Sprintf("%#v", test.given); s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+		}
+	}
+
+	// nested values use it too
+	if s := fmt.Sprintf("%#v", []Semver{MustParse("1.0.0")}); s != `[]semver.Semver{semver.MustParse("1.0.0")}` {
+		t.Errorf("unexpected nested output: %s", s)
+	}
+}
+
+func TestFormatEmbedded(t *testing.T) {
+	tag, _ := ParseTag("release-1.2.3", WithPrefixes("release-"))
+	vendored, _ := ParseVendored("1.28.3-gke.100")
+	nuget, _ := ParseNuGet("1.2.3.4-beta")
+	tests := []struct {
+		format   string
+		given    interface{}
+		expected string
+		reason   string
+	}{
+		{"%v", tag, "release-1.2.3", "tag"},
+		{"%s", tag, "release-1.2.3", "tag string"},
+		{"%q", tag, `"release-1.2.3"`, "tag quoted"},
+		{"%16v|", tag, "   release-1.2.3|", "tag width"},
+		{"%.2v", tag, "1.2", "tag precision"},
+		{"%M", tag, "1", "tag major"},
+		{"%#v", tag, `semver.Tag{Prefix:"release-", Semver:semver.MustParse("1.2.3")}`, "tag go syntax"},
+		{"%v", vendored, "1.28.3-gke.100", "vendored"},
+		{"%s", vendored, "1.28.3-gke.100", "vendored string"},
+		{"%P|", vendored, "|", "vendored prerelease"},
+		{"%#v", vendored, `semver.Vendored{Semver:semver.MustParse("1.28.3"), Vendor:"gke.100"}`, "vendored go syntax"},
+		{"%v", nuget, "1.2.3.4-beta", "nuget"},
+		{"%s", nuget, "1.2.3.4-beta", "nuget string"},
+		{"%n", nuget, "3", "nuget patch"},
+		{"%#v", nuget, `semver.NuGet{Semver:semver.MustParse("1.2.3-beta"), Revision:4}`, "nuget go syntax"},
+		{"%v", []interface{}{tag, vendored, nuget}, "[release-1.2.3 1.28.3-gke.100 1.2.3.4-beta]", "nested"},
+	}
+
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, test.given); s != test.expected {
+			t.Errorf("%s: %q != %q", test.reason, s, test.expected)
+		}
+	}
+
+	if s := fmt.Sprintln(tag, vendored, nuget); s != "release-1.2.3 1.28.3-gke.100 1.2.3.4-beta\n" {
+		t.Errorf("Println should use String: %q", s)
+	}
+}
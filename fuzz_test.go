@@ -0,0 +1,12 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/beatgammit/semver"
+	"github.com/beatgammit/semver/semvertest"
+)
+
+func FuzzParse(f *testing.F) {
+	semvertest.FuzzParse(f, semver.Parse)
+}
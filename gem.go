@@ -0,0 +1,156 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	gemReg         = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9a-zA-Z]+)*(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?)\s*$`)
+	gemSegmentReg  = regexp.MustCompile(`[0-9]+|[a-zA-Z]+`)
+	gemReleaseReg  = regexp.MustCompile(`^[0-9]+(?:\.[0-9]+)*`)
+	gemAlphaNumReg = regexp.MustCompile(`^[0-9A-Za-z]+$`)
+)
+
+// gemSegment is a segment of a RubyGems version: a number, or a string if
+// alpha is set.
+type gemSegment struct {
+	alpha bool
+	s     string // without leading zeros if numeric
+}
+
+// cmp compares two segments the way Gem::Version does: numbers numerically
+// and after strings, strings lexically.
+func (a gemSegment) cmp(b gemSegment) int {
+	switch {
+	case a.alpha && b.alpha:
+		return strings.Compare(a.s, b.s)
+	case a.alpha:
+		return -1
+	case b.alpha:
+		return 1
+	}
+	if len(a.s) != len(b.s) {
+		return sign(len(a.s) - len(b.s))
+	}
+	return strings.Compare(a.s, b.s)
+}
+
+var gemZero = gemSegment{s: ""}
+
+// gemCanonical splits a version into its segments, dropping the trailing
+// zeros of the release and of the prerelease, so 1.0 and 1 are equal and so
+// are 1.0.a.0 and 1.a.
+func gemCanonical(s string) []gemSegment {
+	// as in Gem::Version, a hyphen introduces a prerelease
+	s = strings.Replace(s, "-", ".pre.", -1)
+	var release, pre []gemSegment
+	for _, seg := range gemSegmentReg.FindAllString(s, -1) {
+		g := gemSegment{alpha: !isDigit(seg[0]), s: seg}
+		if !g.alpha {
+			g.s = strings.TrimLeft(seg, "0")
+		}
+		if g.alpha || pre != nil {
+			pre = append(pre, g)
+		} else {
+			release = append(release, g)
+		}
+	}
+	return append(trimGemZeros(release), trimGemZeros(pre)...)
+}
+
+func trimGemZeros(segs []gemSegment) []gemSegment {
+	for len(segs) > 0 && segs[len(segs)-1] == gemZero {
+		segs = segs[:len(segs)-1]
+	}
+	return segs
+}
+
+// CompareGem compares two RubyGems versions the way Gem::Version#<=> does,
+// returning -1, 0 or 1. Versions are split into numeric and alphabetic
+// segments; a version with any letters is a prerelease, sorting before its
+// release because strings sort before numbers, so 1.0.a < 1.0.b1 < 1.0.
+// Trailing zeros don't matter: 1, 1.0 and 1.0.0 are equal.
+func CompareGem(a, b string) int {
+	sa, sb := gemCanonical(a), gemCanonical(b)
+	for i := 0; i < len(sa) || i < len(sb); i++ {
+		l, r := gemZero, gemZero
+		if i < len(sa) {
+			l = sa[i]
+		}
+		if i < len(sb) {
+			r = sb[i]
+		}
+		if c := l.cmp(r); c != 0 {
+			return sign(c)
+		}
+	}
+	return 0
+}
+
+// ToGem returns v as a RubyGems version, with the prerelease as further
+// segments in the 1.0.a style, so 1.2.3-rc.1 becomes 1.2.3.rc.1. Build
+// metadata is dropped.
+//
+// RubyGems only treats a version as a prerelease if it has letters, and its
+// segments can only be letters and digits, so a prerelease that doesn't
+// start with a letter, such as 1.2.3-1, or that has hyphens is an error.
+// Gem sorts numbers after strings, so 1.0.0-rc.1 and 1.0.0-rc.beta swap
+// order.
+func (v Semver) ToGem() (string, error) {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease == "" {
+		return s, nil
+	}
+	ids := strings.Split(v.Prerelease, ".")
+	if isDigit(ids[0][0]) {
+		return "", fmt.Errorf("Cannot convert %s to RubyGems: prerelease %s doesn't start with a letter", v, v.Prerelease)
+	}
+	for _, id := range ids {
+		if !gemAlphaNumReg.MatchString(id) {
+			return "", fmt.Errorf("Cannot convert %s to RubyGems: prerelease identifier %q isn't alphanumeric", v, id)
+		}
+	}
+	return s + "." + v.Prerelease, nil
+}
+
+// FromGem parses a RubyGems version into a Semver. The leading numeric
+// segments are the major, minor and patch versions, with missing ones 0 and
+// extra zeros dropped, and the rest is the prerelease: 1.0.a becomes
+// 1.0.0-a, 1.2.3.rc.1 becomes 1.2.3-rc.1 and 1.0-rc1 becomes 1.0.0-pre.rc1,
+// as Gem::Version reads it. A release with a non-zero fourth segment has no
+// semver equivalent and is an error.
+func FromGem(s string) (Semver, error) {
+	m := gemReg.FindStringSubmatch(s)
+	if m == nil {
+		return Semver{}, fmt.Errorf("Invalid RubyGems version: %s", s)
+	}
+	version := strings.Replace(m[1], "-", ".pre.", -1)
+	release := gemReleaseReg.FindString(version)
+	rest := version[len(release):]
+	// the prerelease starts after a dot, as in 1.0.a, or directly, as in 1.0a
+	rest = strings.TrimPrefix(rest, ".")
+
+	var v Semver
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range strings.Split(release, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Semver{}, fmt.Errorf("Invalid RubyGems version %s: %s", s, err)
+		}
+		if i >= len(nums) {
+			if n != 0 {
+				return Semver{}, fmt.Errorf("Cannot convert RubyGems version %s: more than three release segments", s)
+			}
+			continue
+		}
+		*nums[i] = n
+	}
+	v.Prerelease = rest
+	if err := v.Validate(); err != nil {
+		return Semver{}, fmt.Errorf("Invalid RubyGems version %s: %s", s, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,105 @@
+package semver
+
+import "testing"
+
+func TestCompareGem(t *testing.T) {
+	// in ascending order, as Gem::Version sorts them
+	ordered := []string{
+		"0.9",
+		"1.0.a",
+		"1.0.a.2",
+		"1.0.a10",
+		"1.0.b1",
+		"1.0-rc1", // 1.0.pre.rc1
+		"1.0.rc.1",
+		"1.0",
+		"1.0.1",
+		"1.2",
+		"1.10",
+		"2",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a, b := ordered[i-1], ordered[i]
+		if c := CompareGem(a, b); c != -1 {
+			t.Errorf("CompareGem(%q, %q) = %d, expected -1", a, b, c)
+		}
+		if c := CompareGem(b, a); c != 1 {
+			t.Errorf("CompareGem(%q, %q) = %d, expected 1", b, a, c)
+		}
+	}
+
+	equal := [][2]string{{"1", "1.0.0"}, {"1.0.a", "1.0.0.a.0"}, {"1.01", "1.1"}, {"1.0a", "1.0.a"}, {"1.0.rc.1", "1.0.rc1"}}
+	for _, pair := range equal {
+		if c := CompareGem(pair[0], pair[1]); c != 0 {
+			t.Errorf("CompareGem(%q, %q) = %d, expected 0", pair[0], pair[1], c)
+		}
+	}
+}
+
+func TestToGem(t *testing.T) {
+	tests := []struct {
+		given, exp string
+		reason     string
+	}{
+		{"1.2.3", "1.2.3", "release"},
+		{"1.2.3-rc.1", "1.2.3.rc.1", "prerelease"},
+		{"1.2.3-beta2+build.5", "1.2.3.beta2", "build dropped"},
+	}
+
+	for _, test := range tests {
+		s, err := MustParse(test.given).ToGem()
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+			continue
+		} else if s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+		}
+		v := MustParse(test.given)
+		v.Build = ""
+		if back, err := FromGem(s); err != nil || back != v {
+			t.Errorf("%s: round trip gave %+v, %v", test.reason, back, err)
+		}
+	}
+
+	for _, s := range []string{"1.2.3-1", "1.2.3-0.rc", "1.2.3-rc-1"} {
+		if g, err := MustParse(s).ToGem(); err == nil {
+			t.Errorf("%s: expected error, returned: %s", s, g)
+		}
+	}
+}
+
+func TestFromGem(t *testing.T) {
+	good := []goodParseTest{
+		{"1", Semver{Major: 1}, "one segment"},
+		{"1.0.a", Semver{Major: 1, Prerelease: "a"}, "1.0.a style"},
+		{"1.0a", Semver{Major: 1, Prerelease: "a"}, "attached prerelease"},
+		{"1.2.3rc1", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc1"}, "attached with a number"},
+		{"1.0-rc1", Semver{Major: 1, Prerelease: "pre.rc1"}, "hyphen"},
+		{"1.2.3.0", Semver{Major: 1, Minor: 2, Patch: 3}, "zero fourth segment"},
+		{" 1.2 ", Semver{Major: 1, Minor: 2}, "whitespace"},
+	}
+
+	for _, test := range good {
+		v, err := FromGem(test.given)
+		if err != nil {
+			t.Errorf("%s: %s; given: %s", test.reason, err, test.given)
+		} else if v != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, v, test.exp)
+		}
+	}
+
+	bad := []badParseTest{
+		{"1.2.3.4", "fourth segment"},
+		{"a.1", "no release"},
+		{"1..2", "empty segment"},
+		{"1.0+b", "build metadata"},
+		{"", "empty"},
+	}
+
+	for _, test := range bad {
+		if v, err := FromGem(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, v)
+		}
+	}
+}
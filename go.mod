@@ -0,0 +1,3 @@
+module github.com/beatgammit/semver
+
+go 1.25.0
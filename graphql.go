@@ -0,0 +1,36 @@
+package semver
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// MarshalGQL writes the version as a GraphQL string, so Semver can be bound
+// to a custom scalar in github.com/99designs/gqlgen.
+func (ver Semver) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(ver.String()))
+}
+
+// UnmarshalGQL parses a custom scalar input value, which must be a string.
+func (ver *Semver) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Semver must be a string, got %T", v)
+	}
+	return ver.UnmarshalText([]byte(s))
+}
+
+// MarshalSemver and UnmarshalSemver are the function form of the gqlgen
+// scalar interface, for binding the scalar with a models entry instead of
+// methods. The returned value satisfies graphql.Marshaler.
+func MarshalSemver(ver Semver) interface{ MarshalGQL(w io.Writer) } {
+	return ver
+}
+
+// UnmarshalSemver parses a custom scalar input value; see MarshalSemver.
+func UnmarshalSemver(v interface{}) (Semver, error) {
+	var ver Semver
+	err := ver.UnmarshalGQL(v)
+	return ver, err
+}
@@ -0,0 +1,35 @@
+package semver
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGQL(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.5")
+
+	var buf bytes.Buffer
+	MarshalSemver(v).MarshalGQL(&buf)
+	if s := buf.String(); s != `"1.2.3-rc.1+build.5"` {
+		t.Errorf("unexpected output: %s", s)
+	}
+
+	if got, err := UnmarshalSemver("1.2.3-rc.1+build.5"); err != nil || got != v {
+		t.Errorf("unmarshal %+v, %v", got, err)
+	}
+
+	bad := []struct {
+		given  interface{}
+		reason string
+	}{
+		{"1.2", "invalid version"},
+		{nil, "null"},
+		{10, "number"},
+		{map[string]interface{}{"major": 1}, "object"},
+	}
+	for _, test := range bad {
+		if got, err := UnmarshalSemver(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, got)
+		}
+	}
+}
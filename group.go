@@ -0,0 +1,68 @@
+package semver
+
+// Group is the versions on one release line.
+type Group struct {
+	Major int
+	// Minor is only meaningful for groups returned by GroupByMinor.
+	Minor int
+	// Versions are the versions on the line, in ascending order.
+	Versions Versions
+}
+
+// GroupByMajor groups versions by major version. Groups are in ascending
+// order of major version.
+func GroupByMajor(versions []Semver) []Group {
+	return groupBy(versions, func(a, b Semver) bool {
+		return a.Major == b.Major
+	})
+}
+
+// GroupByMinor groups versions by major and minor version, e.g. 1.2.0 and
+// 1.2.5-rc.1 are both in the 1.2 group. Groups are in ascending order.
+func GroupByMinor(versions []Semver) []Group {
+	return groupBy(versions, func(a, b Semver) bool {
+		return a.Major == b.Major && a.Minor == b.Minor
+	})
+}
+
+// groupBy sorts a copy of versions and splits it into runs of versions on the
+// same line.
+func groupBy(versions []Semver, sameLine func(a, b Semver) bool) []Group {
+	sorted := append(Versions(nil), versions...)
+	sorted.Sort()
+
+	var groups []Group
+	start := 0
+	for i := 1; i <= len(sorted); i++ {
+		if i < len(sorted) && sameLine(sorted[start], sorted[i]) {
+			continue
+		}
+		groups = append(groups, Group{
+			Major:    sorted[start].Major,
+			Minor:    sorted[start].Minor,
+			Versions: sorted[start:i:i],
+		})
+		start = i
+	}
+	return groups
+}
+
+// LatestPerMajor returns the latest version on each major line, in ascending
+// order, considering only the versions the options allow.
+func LatestPerMajor(versions []Semver, opts ...FilterOption) []Semver {
+	return latestPer(GroupByMajor(filter(versions, newFilterOptions(opts))))
+}
+
+// LatestPerMinor returns the latest version on each minor line, in ascending
+// order, considering only the versions the options allow.
+func LatestPerMinor(versions []Semver, opts ...FilterOption) []Semver {
+	return latestPer(GroupByMinor(filter(versions, newFilterOptions(opts))))
+}
+
+func latestPer(groups []Group) []Semver {
+	latest := make([]Semver, len(groups))
+	for i, g := range groups {
+		latest[i], _ = Latest(g.Versions)
+	}
+	return latest
+}
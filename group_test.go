@@ -0,0 +1,64 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	vs, _ := ParseVersions([]string{"2.1.0", "1.2.0", "1.10.0", "1.2.5-rc.1", "2.0.0", "1.2.1", "0.1.0"})
+
+	byMajor := GroupByMajor(vs)
+	if len(byMajor) != 3 {
+		t.Fatalf("expected 3 groups, got %v", byMajor)
+	}
+	exp := [][]string{{"0.1.0"}, {"1.2.0", "1.2.1", "1.2.5-rc.1", "1.10.0"}, {"2.0.0", "2.1.0"}}
+	for i, g := range byMajor {
+		if g.Major != i || !reflect.DeepEqual(g.Versions.Strings(), exp[i]) {
+			t.Errorf("group %d: %d %q != %q", i, g.Major, g.Versions.Strings(), exp[i])
+		}
+	}
+
+	byMinor := GroupByMinor(vs)
+	lines := make([][2]int, len(byMinor))
+	for i, g := range byMinor {
+		lines[i] = [2]int{g.Major, g.Minor}
+	}
+	if exp := [][2]int{{0, 1}, {1, 2}, {1, 10}, {2, 0}, {2, 1}}; !reflect.DeepEqual(lines, exp) {
+		t.Errorf("%v != %v", lines, exp)
+	}
+	if got := byMinor[1].Versions.Strings(); !reflect.DeepEqual(got, []string{"1.2.0", "1.2.1", "1.2.5-rc.1"}) {
+		t.Errorf("unexpected 1.2 group: %q", got)
+	}
+
+	// groups don't share backing arrays
+	byMinor[1].Versions = append(byMinor[1].Versions, MustParse("9.9.9"))
+	if byMinor[2].Versions[0] != MustParse("1.10.0") {
+		t.Errorf("appending to a group modified the next one")
+	}
+
+	if g := GroupByMajor(nil); len(g) != 0 {
+		t.Errorf("expected no groups, got %v", g)
+	}
+}
+
+func TestLatestPer(t *testing.T) {
+	vs, _ := ParseVersions([]string{"2.1.0", "1.2.0", "1.10.0+b", "1.2.5-rc.1", "2.2.0-beta", "1.10.0+a", "1.2.1", "3.0.0-rc.1"})
+
+	tests := []struct {
+		got    []Semver
+		exp    []string
+		reason string
+	}{
+		{LatestPerMajor(vs), []string{"1.10.0+b", "2.2.0-beta", "3.0.0-rc.1"}, "per major"},
+		{LatestPerMajor(vs, WithoutPrerelease()), []string{"1.10.0+b", "2.1.0"}, "per major, stable"},
+		{LatestPerMinor(vs), []string{"1.2.5-rc.1", "1.10.0+b", "2.1.0", "2.2.0-beta", "3.0.0-rc.1"}, "per minor"},
+		{LatestPerMinor(vs, WithConstraint(MustParseConstraint("^1"))), []string{"1.2.1", "1.10.0+b"}, "per minor, constrained"},
+	}
+
+	for _, test := range tests {
+		if got := Versions(test.got).Strings(); !reflect.DeepEqual(got, test.exp) {
+			t.Errorf("%s: %q != %q", test.reason, got, test.exp)
+		}
+	}
+}
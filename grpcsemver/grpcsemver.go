@@ -0,0 +1,98 @@
+// Package grpcsemver provides google.golang.org/grpc interceptors that send
+// the caller's version with every call, and let servers reject callers older
+// than a minimum version.
+package grpcsemver
+
+import (
+	"context"
+
+	"github.com/beatgammit/semver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey is the metadata key the caller's version is sent under.
+const MetadataKey = "x-client-version"
+
+type contextKey struct{}
+
+// FromContext returns the caller's version, as checked by the server
+// interceptors.
+func FromContext(ctx context.Context) (semver.Semver, bool) {
+	v, ok := ctx.Value(contextKey{}).(semver.Semver)
+	return v, ok
+}
+
+// UnaryClientInterceptor sends v with every unary call.
+func UnaryClientInterceptor(v semver.Semver) grpc.UnaryClientInterceptor {
+	s := v.String()
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, MetadataKey, s), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor sends v with every streaming call.
+func StreamClientInterceptor(v semver.Semver) grpc.StreamClientInterceptor {
+	s := v.String()
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(metadata.AppendToOutgoingContext(ctx, MetadataKey, s), desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor rejects unary calls from callers whose version
+// doesn't satisfy min, such as >=1.4.0, and puts the version of those it
+// accepts on the context for FromContext. Calls without a valid version fail
+// with codes.InvalidArgument, and calls from versions outside min with
+// codes.FailedPrecondition. min has the usual Constraint prerelease rule, so
+// parse it with semver.IncludePrerelease to accept prerelease callers.
+func UnaryServerInterceptor(min semver.Constraint) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := check(ctx, min)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor checks the callers of streaming calls as
+// UnaryServerInterceptor does unary ones.
+func StreamServerInterceptor(min semver.Constraint) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := check(ss.Context(), min)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ss, ctx})
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// check reads the caller's version from the incoming metadata of ctx and
+// checks it against min, returning a context carrying it.
+func check(ctx context.Context, min semver.Constraint) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing %s metadata", MetadataKey)
+	}
+	v, err := semver.Parse(values[0])
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid %s metadata: %s", MetadataKey, err)
+	}
+	if !min.Check(v) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Client version %s does not satisfy %s", v, min)
+	}
+	return context.WithValue(ctx, contextKey{}, v), nil
+}
@@ -0,0 +1,127 @@
+package grpcsemver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/beatgammit/semver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// send runs a unary call from a client at version v through both
+// interceptors, returning the version the handler saw.
+func send(t *testing.T, v semver.Semver, min semver.Constraint) (semver.Semver, error) {
+	var seen semver.Semver
+	server := UnaryServerInterceptor(min)
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		ctx = metadata.NewIncomingContext(context.Background(), md)
+		_, err := server(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			var ok bool
+			if seen, ok = FromContext(ctx); !ok {
+				t.Errorf("no version on the handler's context")
+			}
+			return nil, nil
+		})
+		return err
+	}
+	err := UnaryClientInterceptor(v)(context.Background(), "/svc/Method", nil, nil, nil, invoker)
+	return seen, err
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	min := semver.MustParseConstraint(">=1.4.0")
+	tests := []struct {
+		version string
+		code    codes.Code
+		reason  string
+	}{
+		{"1.4.0", codes.OK, "minimum version"},
+		{"2.0.1", codes.OK, "newer version"},
+		{"1.3.9", codes.FailedPrecondition, "too old"},
+		{"1.5.0-rc.1", codes.FailedPrecondition, "prerelease"},
+	}
+
+	for _, test := range tests {
+		v := semver.MustParse(test.version)
+		seen, err := send(t, v, min)
+		if code := status.Code(err); code != test.code {
+			t.Errorf("%s: code %v != %v: %v", test.reason, code, test.code, err)
+		} else if err == nil && seen != v {
+			t.Errorf("%s: handler saw %s", test.reason, seen)
+		}
+	}
+
+	pre := semver.MustParseConstraint(">=1.4.0", semver.IncludePrerelease())
+	if _, err := send(t, semver.MustParse("1.5.0-rc.1"), pre); err != nil {
+		t.Errorf("prerelease with IncludePrerelease: %s", err)
+	}
+}
+
+func TestServerInterceptorMetadata(t *testing.T) {
+	server := UnaryServerInterceptor(semver.MustParseConstraint(">=1.0.0"))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	tests := []struct {
+		md     metadata.MD
+		reason string
+	}{
+		{nil, "no metadata"},
+		{metadata.Pairs("other", "1.0.0"), "missing version"},
+		{metadata.Pairs(MetadataKey, "1.0"), "invalid version"},
+	}
+
+	for _, test := range tests {
+		ctx := context.Background()
+		if test.md != nil {
+			ctx = metadata.NewIncomingContext(ctx, test.md)
+		}
+		if _, err := server(ctx, nil, &grpc.UnaryServerInfo{}, handler); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", test.reason, err)
+		}
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamInterceptors(t *testing.T) {
+	var out context.Context
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		out = ctx
+		return nil, nil
+	}
+	v := semver.MustParse("1.2.3")
+	if _, err := StreamClientInterceptor(v)(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Stream", streamer); err != nil {
+		t.Fatal(err)
+	}
+	md, _ := metadata.FromOutgoingContext(out)
+	if got := md.Get(MetadataKey); len(got) != 1 || got[0] != "1.2.3" {
+		t.Fatalf("outgoing metadata %v", md)
+	}
+
+	ss := fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+	err := StreamServerInterceptor(semver.MustParseConstraint("^1.2"))(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		if seen, ok := FromContext(stream.Context()); !ok || seen != v {
+			t.Errorf("stream context version %s, %t", seen, ok)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err = StreamServerInterceptor(semver.MustParseConstraint("^2"))(nil, ss, &grpc.StreamServerInfo{}, func(interface{}, grpc.ServerStream) error {
+		t.Errorf("handler called for a rejected stream")
+		return nil
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+}
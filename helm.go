@@ -0,0 +1,160 @@
+package semver
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Chart holds the version fields of a Helm chart's Chart.yaml.
+type Chart struct {
+	// Version is the chart's own version, which Helm requires to be a
+	// semantic version.
+	Version Semver
+	// AppVersion is the version of the packaged application. Helm treats it
+	// as free-form text; AppSemver reads it as a version.
+	AppVersion string
+	// KubeVersion is the range of Kubernetes versions the chart supports. The
+	// zero Constraint, used when the field is missing, allows any version.
+	KubeVersion Constraint
+}
+
+// ParseChart reads the version, appVersion and kubeVersion fields of a
+// Chart.yaml document. Only top-level scalar fields are looked at, so this
+// doesn't need a YAML parser. version is parsed strictly, kubeVersion as a
+// constraint that prerelease cluster versions can satisfy (see
+// CheckKubeVersion), and appVersion is returned as written.
+func ParseChart(data []byte) (Chart, error) {
+	fields, err := chartFields(data)
+	if err != nil {
+		return Chart{}, err
+	}
+
+	var c Chart
+	s, ok := fields["version"]
+	if !ok {
+		return Chart{}, fmt.Errorf("Invalid Chart.yaml: no version")
+	}
+	if c.Version, err = Parse(s); err != nil {
+		return Chart{}, fmt.Errorf("Invalid Chart.yaml: version: %s", err)
+	}
+	c.AppVersion = fields["appVersion"]
+	if s, ok := fields["kubeVersion"]; ok {
+		if c.KubeVersion, err = ParseConstraint(s, IncludePrerelease()); err != nil {
+			return Chart{}, fmt.Errorf("Invalid Chart.yaml: kubeVersion: %s", err)
+		}
+	}
+	return c, nil
+}
+
+// AppSemver parses the chart's appVersion leniently, with Coerce, since
+// application versions such as "v1.16" are rarely strict semver.
+func (c Chart) AppSemver() (Semver, error) {
+	if c.AppVersion == "" {
+		return Semver{}, fmt.Errorf("Invalid Chart.yaml: no appVersion")
+	}
+	return Coerce(c.AppVersion)
+}
+
+// CheckKubeVersion checks that a cluster running Kubernetes version
+// kubeVersion, such as "v1.28.3-gke.1286000" as reported by the API server,
+// satisfies the chart's kubeVersion range. Missing minor and patch versions
+// are taken as 0.
+//
+// Clusters from most vendors report a prerelease, so unlike Constraint's
+// usual rule a prerelease satisfies any range it falls in. That matches what
+// charts get in Helm by writing ranges such as ">=1.20.0-0".
+func (c Chart) CheckKubeVersion(kubeVersion string) error {
+	v, err := Coerce(kubeVersion)
+	if err != nil {
+		return err
+	}
+	if !c.KubeVersion.Check(v) {
+		return fmt.Errorf("Unsupported Kubernetes version: %s does not satisfy %s", kubeVersion, c.KubeVersion)
+	}
+	return nil
+}
+
+// NextChartVersion returns the version to give a chart when its appVersion
+// changes from oldApp to newApp, bumping the chart's version by as much as
+// the application's changed: a new major version of the application is a
+// new major version of the chart, and so on. Prerelease and build changes
+// bump the patch version, as does an unchanged appVersion, since the chart
+// itself must have changed to need a new version. Both app versions are
+// parsed with Coerce, and newApp must not be older than oldApp.
+func NextChartVersion(chart Semver, oldApp, newApp string) (Semver, error) {
+	from, err := Coerce(oldApp)
+	if err != nil {
+		return Semver{}, err
+	}
+	to, err := Coerce(newApp)
+	if err != nil {
+		return Semver{}, err
+	}
+	if to.Cmp(from) < 0 {
+		return Semver{}, fmt.Errorf("App version went backwards: %s to %s", oldApp, newApp)
+	}
+	switch Diff(from, to) {
+	case MajorChange:
+		return chart.NextMajor(), nil
+	case MinorChange:
+		return chart.NextMinor(), nil
+	}
+	return chart.NextPatch(), nil
+}
+
+// chartFields returns the top-level scalar fields of a YAML document, with
+// quotes and trailing comments removed.
+func chartFields(data []byte) (map[string]string, error) {
+	fields := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+			continue
+		}
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:i])
+		value, err := yamlScalar(strings.TrimSpace(line[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid Chart.yaml: %s: %s", key, err)
+		}
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("Invalid Chart.yaml: %s", err)
+	}
+	return fields, nil
+}
+
+// yamlScalar unquotes a flow scalar and strips any trailing comment.
+func yamlScalar(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == '"' {
+				return strconv.Unquote(s[:i+1])
+			}
+		}
+		return "", fmt.Errorf("unterminated string")
+	case strings.HasPrefix(s, "'"):
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : end+1], nil
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s), nil
+}
@@ -0,0 +1,123 @@
+package semver
+
+import "testing"
+
+const testChart = `# A chart
+apiVersion: v2
+name: web
+description: "A chart: for things"
+version: 1.4.2
+appVersion: "v2.8" # the image tag
+kubeVersion: '>=1.24.0-0 <1.31.0-0'
+dependencies:
+  - name: redis
+    version: 17.x.x
+maintainers:
+- name: someone
+`
+
+func TestParseChart(t *testing.T) {
+	c, err := ParseChart([]byte(testChart))
+	if err != nil {
+		t.Fatalf("error parsing chart: %s", err)
+	}
+	if exp := MustParse("1.4.2"); c.Version != exp {
+		t.Errorf("version %s != %s", c.Version, exp)
+	}
+	if c.AppVersion != "v2.8" {
+		t.Errorf("appVersion %q != %q", c.AppVersion, "v2.8")
+	}
+	if v, err := c.AppSemver(); err != nil || v != MustParse("2.8.0") {
+		t.Errorf("app semver %s, %v", v, err)
+	}
+	if s := c.KubeVersion.String(); s != ">=1.24.0-0 <1.31.0-0" {
+		t.Errorf("kubeVersion %q", s)
+	}
+
+	c, err = ParseChart([]byte("name: web\nversion: v0.1.0\n"))
+	if err != nil {
+		t.Fatalf("minimal chart: %s", err)
+	}
+	if _, err := c.AppSemver(); err == nil {
+		t.Errorf("minimal chart: expected an error for a missing appVersion")
+	}
+	if err := c.CheckKubeVersion("1.10.0"); err != nil {
+		t.Errorf("minimal chart: any Kubernetes version should do: %s", err)
+	}
+
+	bad := []badParseTest{
+		{"name: web\n", "no version"},
+		{"version: 1.4\n", "partial version"},
+		{"version: \"1.4.2\n", "unterminated string"},
+		{"version: 1.4.2\nkubeVersion: \">=1.2.3.4\"\n", "bad kubeVersion"},
+		{"chart:\n  version: 1.4.2\n", "nested version"},
+	}
+
+	for _, test := range bad {
+		if c, err := ParseChart([]byte(test.given)); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, c)
+		}
+	}
+}
+
+func TestCheckKubeVersion(t *testing.T) {
+	c, err := ParseChart([]byte(testChart))
+	if err != nil {
+		t.Fatalf("error parsing chart: %s", err)
+	}
+	tests := []struct {
+		given  string
+		ok     bool
+		reason string
+	}{
+		{"v1.24.0", true, "lower bound"},
+		{"v1.28.3-gke.1286000", true, "vendor prerelease"},
+		{"1.30", true, "partial version"},
+		{"v1.31.0", false, "upper bound"},
+		{"v1.23.17-eks-8ccc7ba", false, "too old"},
+	}
+
+	for _, test := range tests {
+		err := c.CheckKubeVersion(test.given)
+		if test.ok && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+		} else if !test.ok && err == nil {
+			t.Errorf("%s: expected %s to be rejected", test.reason, test.given)
+		}
+	}
+	if err := c.CheckKubeVersion("latest"); err == nil {
+		t.Errorf("expected an error for an invalid version")
+	}
+}
+
+func TestNextChartVersion(t *testing.T) {
+	chart := MustParse("1.4.2")
+	tests := []struct {
+		oldApp string
+		newApp string
+		exp    string
+		reason string
+	}{
+		{"v2.8", "v3.0", "2.0.0", "major app bump"},
+		{"v2.8", "v2.9", "1.5.0", "minor app bump"},
+		{"v2.8", "v2.8.1", "1.4.3", "patch app bump"},
+		{"2.9.0-rc.1", "2.9.0", "1.4.3", "app release"},
+		{"v2.8", "v2.8", "1.4.3", "app unchanged"},
+	}
+
+	for _, test := range tests {
+		v, err := NextChartVersion(chart, test.oldApp, test.newApp)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+		} else if v.String() != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, v, test.exp)
+		}
+	}
+
+	if v, err := NextChartVersion(chart, "v2.8", "v2.7"); err == nil {
+		t.Errorf("downgrade: expected error, returned: %s", v)
+	}
+	if v, err := NextChartVersion(chart, "v2.8", "latest"); err == nil {
+		t.Errorf("invalid app version: expected error, returned: %s", v)
+	}
+}
@@ -0,0 +1,136 @@
+// Package httpsemver serves versioned HTTP APIs: a Router picks the handler
+// for each request from the versions registered with it, by the version
+// requirement the client sends in the Accept-Version or X-API-Version
+// header.
+package httpsemver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/beatgammit/semver"
+)
+
+// Request headers that give the client's version requirement, in the order
+// they're checked, and the response header that gives the version served.
+const (
+	AcceptVersionHeader = "Accept-Version"
+	APIVersionHeader    = "X-API-Version"
+)
+
+type contextKey struct{}
+
+// FromContext returns the API version the Router chose for a request, for
+// handlers shared between versions.
+func FromContext(ctx context.Context) (semver.Semver, bool) {
+	v, ok := ctx.Value(contextKey{}).(semver.Semver)
+	return v, ok
+}
+
+// Router is an http.Handler that serves each request with the handler
+// registered for the highest version satisfying the request's requirement.
+// A requirement is a version such as 1.2.3, a range such as ^1.2 in the
+// syntax of semver.Constraint, or "latest"; requests without one get the
+// latest. The latest is the highest release, or the highest prerelease if
+// no releases are registered, and otherwise prereleases are only served as
+// the Constraint prerelease rule allows.
+//
+// The served version is sent in the X-API-Version response header.
+// Requirements that can't be parsed get a 400 Bad Request response, and
+// ones no registered version satisfies a 406 Not Acceptable. If no versions
+// are registered every request gets a 404 Not Found.
+//
+// The zero Router is ready to use, and handlers may be registered while
+// it's serving.
+type Router struct {
+	mu       sync.RWMutex
+	versions []semver.Semver
+	handlers map[semver.Semver]http.Handler
+}
+
+// Handle registers the handler for version v, replacing any handler already
+// registered for it.
+func (rt *Router) Handle(v semver.Semver, h http.Handler) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.handlers == nil {
+		rt.handlers = make(map[semver.Semver]http.Handler)
+	}
+	if _, ok := rt.handlers[v]; !ok {
+		rt.versions = append(rt.versions, v)
+	}
+	rt.handlers[v] = h
+}
+
+// HandleFunc registers the handler function for version v.
+func (rt *Router) HandleFunc(v semver.Semver, f func(http.ResponseWriter, *http.Request)) {
+	rt.Handle(v, http.HandlerFunc(f))
+}
+
+// Versions returns the registered versions, in ascending order.
+func (rt *Router) Versions() []semver.Semver {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	vs := append(semver.Versions(nil), rt.versions...)
+	vs.Sort()
+	return vs
+}
+
+// Select returns the registered version that a request with the given
+// requirement is served by.
+func (rt *Router) Select(requirement string) (semver.Semver, error) {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	requirement = strings.TrimSpace(requirement)
+	if requirement == "" || strings.EqualFold(requirement, "latest") {
+		if v, ok := semver.Latest(rt.versions, semver.WithoutPrerelease()); ok {
+			return v, nil
+		} else if v, ok := semver.Latest(rt.versions); ok {
+			return v, nil
+		}
+		return semver.Semver{}, errNoVersions
+	}
+	c, err := semver.ParseConstraint(requirement)
+	if err != nil {
+		return semver.Semver{}, err
+	}
+	if v, ok := semver.Latest(rt.versions, semver.WithConstraint(c)); ok {
+		return v, nil
+	}
+	return semver.Semver{}, errNotAcceptable
+}
+
+var (
+	errNoVersions    = fmt.Errorf("No API versions are registered")
+	errNotAcceptable = fmt.Errorf("No API version satisfies the requirement")
+)
+
+// ServeHTTP serves the request with the handler for the version it asks for.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", AcceptVersionHeader+", "+APIVersionHeader)
+	requirement := r.Header.Get(AcceptVersionHeader)
+	if requirement == "" {
+		requirement = r.Header.Get(APIVersionHeader)
+	}
+	v, err := rt.Select(requirement)
+	switch {
+	case err == errNotAcceptable:
+		http.Error(w, fmt.Sprintf("%s: %q; available versions are %s", err, requirement, strings.Join(semver.Versions(rt.Versions()).Strings(), ", ")), http.StatusNotAcceptable)
+		return
+	case err == errNoVersions:
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Invalid API version requirement: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	rt.mu.RLock()
+	h := rt.handlers[v]
+	rt.mu.RUnlock()
+	w.Header().Set(APIVersionHeader, v.String())
+	h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, v)))
+}
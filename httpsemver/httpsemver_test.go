@@ -0,0 +1,81 @@
+package httpsemver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+func versionHandler(w http.ResponseWriter, r *http.Request) {
+	v, ok := FromContext(r.Context())
+	if !ok {
+		http.Error(w, "no version", http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "served %s", v)
+}
+
+func TestRouter(t *testing.T) {
+	var rt Router
+	for _, s := range []string{"1.0.0", "1.2.0", "2.0.0", "2.1.0-beta.1"} {
+		rt.HandleFunc(semver.MustParse(s), versionHandler)
+	}
+	tests := []struct {
+		header string
+		value  string
+		code   int
+		served string
+		reason string
+	}{
+		{"", "", http.StatusOK, "2.0.0", "no requirement"},
+		{AcceptVersionHeader, "latest", http.StatusOK, "2.0.0", "latest"},
+		{AcceptVersionHeader, "1.0.0", http.StatusOK, "1.0.0", "exact"},
+		{AcceptVersionHeader, "^1", http.StatusOK, "1.2.0", "range"},
+		{APIVersionHeader, "v1", http.StatusOK, "1.2.0", "X-API-Version header"},
+		{AcceptVersionHeader, "2.1.0-beta.1", http.StatusOK, "2.1.0-beta.1", "exact prerelease"},
+		{AcceptVersionHeader, "^3", http.StatusNotAcceptable, "", "unsatisfiable"},
+		{AcceptVersionHeader, "one", http.StatusBadRequest, "", "invalid"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		if test.header != "" {
+			req.Header.Set(test.header, test.value)
+		}
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, req)
+		if rec.Code != test.code {
+			t.Errorf("%s: status %d != %d: %s", test.reason, rec.Code, test.code, rec.Body)
+			continue
+		}
+		if test.code != http.StatusOK {
+			continue
+		}
+		if got := rec.Header().Get(APIVersionHeader); got != test.served {
+			t.Errorf("%s: served version header %q != %q", test.reason, got, test.served)
+		}
+		if body := rec.Body.String(); body != "served "+test.served {
+			t.Errorf("%s: body %q", test.reason, body)
+		}
+		if rec.Header().Get("Vary") == "" {
+			t.Errorf("%s: missing Vary header", test.reason)
+		}
+	}
+}
+
+func TestRouterEmpty(t *testing.T) {
+	var rt Router
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status %d != %d", rec.Code, http.StatusNotFound)
+	}
+
+	rt.HandleFunc(semver.MustParse("0.1.0-alpha.1"), versionHandler)
+	if v, err := rt.Select("latest"); err != nil || v.String() != "0.1.0-alpha.1" {
+		t.Errorf("latest with only prereleases: %s, %v", v, err)
+	}
+}
@@ -0,0 +1,62 @@
+package semver
+
+import "strings"
+
+// Identifier is one of the dot-separated identifiers that make up a prerelease
+// or build metadata, e.g. "rc" and "1" in 1.2.3-rc.1.
+type Identifier string
+
+// IsNumeric reports whether id is made up only of digits.
+func (id Identifier) IsNumeric() bool {
+	return isDigits(string(id))
+}
+
+// Cmp compares two identifiers by prerelease precedence:
+// - < 0 if a < b
+// - > 0 if a > b
+// - == 0 if a == b
+//
+// Numeric identifiers are compared numerically, however long they are, and
+// have lower precedence than alphanumeric identifiers, which are compared
+// lexically in ASCII sort order.
+func (a Identifier) Cmp(b Identifier) int {
+	numA, numB := a.IsNumeric(), b.IsNumeric()
+	switch {
+	case numA && numB:
+		sa := strings.TrimLeft(string(a), "0")
+		sb := strings.TrimLeft(string(b), "0")
+		if len(sa) != len(sb) {
+			return len(sa) - len(sb)
+		}
+		return strings.Compare(sa, sb)
+	case numA:
+		return -1
+	case numB:
+		return 1
+	}
+	return strings.Compare(string(a), string(b))
+}
+
+// PrereleaseIdentifiers splits the prerelease into its identifiers. It returns
+// nil if there is no prerelease.
+func (v Semver) PrereleaseIdentifiers() []Identifier {
+	return splitIdentifiers(v.Prerelease)
+}
+
+// BuildIdentifiers splits the build metadata into its identifiers. It returns
+// nil if there is no build metadata.
+func (v Semver) BuildIdentifiers() []Identifier {
+	return splitIdentifiers(v.Build)
+}
+
+func splitIdentifiers(s string) []Identifier {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ".")
+	ids := make([]Identifier, len(parts))
+	for i, p := range parts {
+		ids[i] = Identifier(p)
+	}
+	return ids
+}
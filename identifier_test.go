@@ -0,0 +1,47 @@
+package semver
+
+import "testing"
+
+type identifierTest struct {
+	a, b   Identifier
+	exp    int
+	reason string
+}
+
+func TestIdentifierCmp(t *testing.T) {
+	tests := []identifierTest{
+		{"1", "1", 0, "equal numbers"},
+		{"2", "10", -1, "numeric, not lexical"},
+		{"007", "7", 0, "leading zeros"},
+		{"99999999999999999999", "99999999999999999998", 1, "larger than int64"},
+		{"alpha", "beta", -1, "lexical"},
+		{"Beta", "alpha", -1, "ASCII order"},
+		{"1", "a", -1, "numeric before alphanumeric"},
+		{"-1", "1", 1, "hyphen makes it alphanumeric"},
+	}
+
+	for _, test := range tests {
+		c := test.a.Cmp(test.b)
+		if sign(c) != test.exp {
+			t.Errorf("%s: %s.Cmp(%s) = %d, expected %d", test.reason, test.a, test.b, c, test.exp)
+		}
+		if sign(test.b.Cmp(test.a)) != -test.exp {
+			t.Errorf("%s: not antisymmetric", test.reason)
+		}
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.005")
+	pre := v.PrereleaseIdentifiers()
+	if len(pre) != 2 || pre[0] != "rc" || pre[0].IsNumeric() || pre[1] != "1" || !pre[1].IsNumeric() {
+		t.Errorf("unexpected prerelease identifiers: %q", pre)
+	}
+	build := v.BuildIdentifiers()
+	if len(build) != 2 || build[0] != "build" || build[1] != "005" || !build[1].IsNumeric() {
+		t.Errorf("unexpected build identifiers: %q", build)
+	}
+	if ids := MustParse("1.2.3").PrereleaseIdentifiers(); ids != nil {
+		t.Errorf("expected nil, got %q", ids)
+	}
+}
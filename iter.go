@@ -0,0 +1,129 @@
+//go:build go1.23
+
+package semver
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// All yields the versions in order.
+func All(vs Versions) iter.Seq[Semver] {
+	return func(yield func(Semver) bool) {
+		for _, v := range vs {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Ascending yields the versions in ascending order, without modifying vs.
+func Ascending(vs Versions) iter.Seq[Semver] {
+	sorted := append(Versions(nil), vs...)
+	sorted.Sort()
+	return All(sorted)
+}
+
+// Descending yields the versions in descending order, without modifying vs.
+// Versions with equal precedence keep their relative order.
+func Descending(vs Versions) iter.Seq[Semver] {
+	sorted := append(Versions(nil), vs...)
+	sorted.Sort()
+	return func(yield func(Semver) bool) {
+		// walk runs of equal precedence forwards so they stay in order
+		for end := len(sorted); end > 0; {
+			start := end - 1
+			for start > 0 && sorted[start-1].Cmp(sorted[end-1]) == 0 {
+				start--
+			}
+			for _, v := range sorted[start:end] {
+				if !yield(v) {
+					return
+				}
+			}
+			end = start
+		}
+	}
+}
+
+// Satisfying yields the versions from seq that satisfy c.
+func Satisfying(seq iter.Seq[Semver], c Constraint) iter.Seq[Semver] {
+	return func(yield func(Semver) bool) {
+		for v := range seq {
+			if c.Check(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect gathers the versions from seq into a slice.
+func Collect(seq iter.Seq[Semver]) Versions {
+	var vs Versions
+	for v := range seq {
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+// MergeSorted merges sequences that are each in ascending order into a single
+// ascending sequence, collapsing versions of equal precedence into the first
+// one seen; of versions from different sequences, the one from the earliest
+// sequence wins. It holds only one pending version per sequence.
+func MergeSorted(seqs ...iter.Seq[Semver]) iter.Seq[Semver] {
+	return func(yield func(Semver) bool) {
+		h := make(mergeHeap, 0, len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			if v, ok := next(); ok {
+				h = append(h, mergeItem{v, i, next})
+			}
+		}
+		heap.Init(&h)
+
+		var last Semver
+		started := false
+		for len(h) > 0 {
+			item := h[0]
+			if !started || item.v.Cmp(last) != 0 {
+				if !yield(item.v) {
+					return
+				}
+				last, started = item.v, true
+			}
+			if v, ok := item.next(); ok {
+				h[0].v = v
+				heap.Fix(&h, 0)
+			} else {
+				heap.Pop(&h)
+			}
+		}
+	}
+}
+
+type mergeItem struct {
+	v    Semver
+	seq  int
+	next func() (Semver, bool)
+}
+
+// mergeHeap orders pending versions by precedence, then by sequence.
+type mergeHeap []mergeItem
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool {
+	if c := h[i].v.Cmp(h[j].v); c != 0 {
+		return c < 0
+	}
+	return h[i].seq < h[j].seq
+}
+func (h mergeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x interface{}) { *h = append(*h, x.(mergeItem)) }
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,77 @@
+//go:build go1.23
+
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.10.0", "1.2.0+b", "2.0.0-rc.1", "1.2.0+a", "0.9.0"})
+
+	tests := []struct {
+		got    Versions
+		exp    []string
+		reason string
+	}{
+		{Collect(All(vs)), []string{"1.10.0", "1.2.0+b", "2.0.0-rc.1", "1.2.0+a", "0.9.0"}, "all"},
+		{Collect(Ascending(vs)), []string{"0.9.0", "1.2.0+b", "1.2.0+a", "1.10.0", "2.0.0-rc.1"}, "ascending"},
+		{Collect(Descending(vs)), []string{"2.0.0-rc.1", "1.10.0", "1.2.0+b", "1.2.0+a", "0.9.0"}, "descending"},
+		{Collect(Satisfying(Descending(vs), MustParseConstraint("^1"))), []string{"1.10.0", "1.2.0+b", "1.2.0+a"}, "satisfying"},
+	}
+
+	for _, test := range tests {
+		if got := test.got.Strings(); !reflect.DeepEqual(got, test.exp) {
+			t.Errorf("%s: %q != %q", test.reason, got, test.exp)
+		}
+	}
+
+	// stopping early
+	var first []Semver
+	for v := range Descending(vs) {
+		first = append(first, v)
+		if len(first) == 2 {
+			break
+		}
+	}
+	if len(first) != 2 || first[1] != MustParse("1.10.0") {
+		t.Errorf("unexpected early stop: %v", first)
+	}
+
+	if vs[0] != MustParse("1.10.0") {
+		t.Errorf("input should not be modified")
+	}
+	if got := Collect(All(nil)); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	a, _ := ParseVersions([]string{"1.0.0", "1.2.0+mirror-a", "2.0.0"})
+	b, _ := ParseVersions([]string{"0.9.0", "1.2.0+mirror-b", "1.3.0", "2.0.0"})
+	c, _ := ParseVersions([]string{"1.3.0", "3.0.0-rc.1"})
+
+	got := Collect(MergeSorted(All(a), All(b), All(c), All(nil))).Strings()
+	exp := []string{"0.9.0", "1.0.0", "1.2.0+mirror-a", "1.3.0", "2.0.0", "3.0.0-rc.1"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("%q != %q", got, exp)
+	}
+
+	// duplicates within a sequence collapse too
+	dup, _ := ParseVersions([]string{"1.0.0", "1.0.0", "1.1.0"})
+	if got := Collect(MergeSorted(All(dup))).Strings(); !reflect.DeepEqual(got, []string{"1.0.0", "1.1.0"}) {
+		t.Errorf("unexpected merge: %q", got)
+	}
+
+	// stopping early stops the inputs
+	var n int
+	for range MergeSorted(All(a), All(b)) {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if got := Collect(MergeSorted()); got != nil {
+		t.Errorf("expected nothing, got %v", got)
+	}
+}
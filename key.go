@@ -0,0 +1,56 @@
+package semver
+
+import "strings"
+
+// Key is the part of a version that determines its precedence. Two versions
+// have equal Keys exactly when Cmp reports them equal, so unlike Semver, Key
+// works with == and as a map key: 1.2.3 and 1.2.3+build have the same Key.
+type Key struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// Key returns the precedence key of v. Build metadata is dropped and leading
+// zeros are trimmed from numeric prerelease identifiers, which Cmp compares
+// by value.
+func (v Semver) Key() Key {
+	return Key{
+		Major:      v.Major,
+		Minor:      v.Minor,
+		Patch:      v.Patch,
+		Prerelease: canonicalPrerelease(v.Prerelease),
+	}
+}
+
+// Semver returns the version with no build metadata that k is the key of.
+func (k Key) Semver() Semver {
+	return Semver{Major: k.Major, Minor: k.Minor, Patch: k.Patch, Prerelease: k.Prerelease}
+}
+
+func (k Key) String() string {
+	return k.Semver().String()
+}
+
+// Equal reports whether a and b have the same precedence, i.e. a.Cmp(b) == 0.
+func (a Semver) Equal(b Semver) bool {
+	return a.Key() == b.Key()
+}
+
+func canonicalPrerelease(pre string) string {
+	if !strings.Contains(pre, "0") {
+		return pre
+	}
+	ids := splitIdentifiers(pre)
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = string(id)
+		if id.IsNumeric() && len(id) > 1 && id[0] == '0' {
+			if parts[i] = strings.TrimLeft(parts[i], "0"); parts[i] == "" {
+				parts[i] = "0"
+			}
+		}
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,45 @@
+package semver
+
+import "testing"
+
+type keyTest struct {
+	a, b   string
+	equal  bool
+	reason string
+}
+
+func TestKey(t *testing.T) {
+	tests := []keyTest{
+		{"1.2.3", "1.2.3", true, "identical"},
+		{"1.2.3", "1.2.3+build.5", true, "build metadata"},
+		{"v1.2.3+a", "1.2.3+b", true, "different build metadata"},
+		{"1.2.3-rc.01", "1.2.3-rc.1", true, "leading zero in numeric identifier"},
+		{"1.2.3-rc.00", "1.2.3-rc.0", true, "all zeros"},
+		{"1.2.3-rc.10", "1.2.3-rc.1", false, "trailing zero"},
+		{"1.2.3-0a", "1.2.3-a", false, "alphanumeric identifiers are not trimmed"},
+		{"1.2.3-rc.1", "1.2.3", false, "prerelease"},
+		{"1.2.3", "1.2.4", false, "patch"},
+	}
+
+	for _, test := range tests {
+		a, b := MustParse(test.a), MustParse(test.b)
+		if eq := a.Key() == b.Key(); eq != test.equal {
+			t.Errorf("%s: %s == %s is %v", test.reason, test.a, test.b, eq)
+		}
+		if eq := a.Equal(b); eq != (a.Cmp(b) == 0) {
+			t.Errorf("%s: Equal disagrees with Cmp for %s and %s", test.reason, test.a, test.b)
+		}
+	}
+
+	m := map[Key]int{}
+	for _, s := range []string{"1.2.3", "1.2.3+a", "1.2.3+b", "1.2.4"} {
+		m[MustParse(s).Key()]++
+	}
+	if len(m) != 2 || m[MustParse("1.2.3").Key()] != 3 {
+		t.Errorf("unexpected map: %v", m)
+	}
+
+	if k := MustParse("1.2.3-rc.1+build").Key(); k.String() != "1.2.3-rc.1" || k.Semver() != MustParse("1.2.3-rc.1") {
+		t.Errorf("unexpected key: %s", k)
+	}
+}
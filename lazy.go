@@ -0,0 +1,123 @@
+package semver
+
+import (
+	"sort"
+	"strings"
+)
+
+// Lazy is a version kept as the string it was written as. It compares by
+// semver precedence straight from the string, without building a Semver or
+// allocating, which matters when sorting millions of them.
+type Lazy string
+
+// Semver parses l.
+func (l Lazy) Semver() (Semver, error) {
+	return Parse(string(l))
+}
+
+// String returns l unchanged.
+func (l Lazy) String() string {
+	return string(l)
+}
+
+// lazyParts are the pieces of a Lazy, sliced out of the original string.
+type lazyParts struct {
+	major, minor, patch Identifier
+	prerelease          string
+}
+
+// parts slices l into its components, reporting whether it is well formed.
+func (l Lazy) parts() (p lazyParts, ok bool) {
+	s := strings.TrimPrefix(string(l), "v")
+	if p.major, s, ok = cutNumber(s, true); !ok {
+		return
+	}
+	if p.minor, s, ok = cutNumber(s, true); !ok {
+		return
+	}
+	if p.patch, s, ok = cutNumber(s, false); !ok {
+		return
+	}
+	if strings.HasPrefix(s, "-") {
+		end := strings.IndexByte(s, '+')
+		if end < 0 {
+			end = len(s)
+		}
+		if p.prerelease = s[1:end]; !identifierChars(p.prerelease) {
+			return p, false
+		}
+		s = s[end:]
+	}
+	if strings.HasPrefix(s, "+") {
+		if !identifierChars(s[1:]) {
+			return p, false
+		}
+		s = ""
+	}
+	return p, s == ""
+}
+
+// cutNumber slices a run of digits off s, and the dot after it if dot is set.
+func cutNumber(s string, dot bool) (n Identifier, rest string, ok bool) {
+	i := 0
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	n, rest = Identifier(s[:i]), s[i:]
+	if i == 0 {
+		return n, rest, false
+	}
+	if dot {
+		if rest == "" || rest[0] != '.' {
+			return n, rest, false
+		}
+		rest = rest[1:]
+	}
+	return n, rest, true
+}
+
+// identifierChars reports whether s is a non-empty run of the characters
+// allowed in a prerelease or build metadata.
+func identifierChars(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '-' || c == '.') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Cmp compares two lazy versions with the same rules as Semver.Cmp. Strings
+// that aren't well formed sort before all versions, in lexical order.
+func (a Lazy) Cmp(b Lazy) int {
+	pa, okA := a.parts()
+	pb, okB := b.parts()
+	if !okA || !okB {
+		switch {
+		case okA:
+			return 1
+		case okB:
+			return -1
+		}
+		return strings.Compare(string(a), string(b))
+	}
+
+	if c := pa.major.Cmp(pb.major); c != 0 {
+		return c
+	}
+	if c := pa.minor.Cmp(pb.minor); c != 0 {
+		return c
+	}
+	if c := pa.patch.Cmp(pb.patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(pa.prerelease, pb.prerelease)
+}
+
+// SortLazy sorts ls in ascending order of precedence.
+func SortLazy(ls []Lazy) {
+	sort.Slice(ls, func(i, j int) bool {
+		return ls[i].Cmp(ls[j]) < 0
+	})
+}
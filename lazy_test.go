@@ -0,0 +1,63 @@
+package semver
+
+import "testing"
+
+var lazyCorpus = []string{
+	"1.0.0", "v1.0.0", "0.0.1", "1.2.3-rc.1", "1.2.3-rc.1.2", "1.2.3-rc.1.10", "1.2.3-alpha.beta.1",
+	"1.2.3-0.3.7", "1.2.3-x-y-z.--", "1.2.3+build.5", "1.2.3-rc.1+build.5", "01.02.03", "10.20.30",
+	"1.2.3-99999999999999999999", "1.2.3-99999999999999999998",
+}
+
+var lazyMalformed = []string{
+	"", "v", "1", "1.2", "1.2.3.4", "1x2x3", "1.2.3-", "1.2.3+", "1.2.3-+", "1.2.3-rc.1+",
+	"-1.0.0", "1.-2.3", " 1.2.3", "1.2.3 ", "V1.2.3", "vv1.2.3", "1.2.3-\u00e9", "1.2.3\x00",
+}
+
+func TestLazyCmp(t *testing.T) {
+	// every pair of parseable versions must compare the same way as Semver
+	for _, a := range lazyCorpus {
+		for _, b := range lazyCorpus {
+			va, err := parse(a)
+			if err != nil {
+				t.Fatalf("%q: %s", a, err)
+			}
+			vb, _ := parse(b)
+			if exp, c := sign(va.Cmp(vb)), sign(Lazy(a).Cmp(Lazy(b))); c != exp {
+				t.Errorf("Lazy(%q).Cmp(%q) = %d, Semver gives %d", a, b, c, exp)
+			}
+		}
+	}
+}
+
+func TestLazyMalformed(t *testing.T) {
+	for _, s := range lazyMalformed {
+		if _, err := parse(s); err == nil {
+			t.Fatalf("%q: expected Parse to reject", s)
+		}
+		if _, ok := Lazy(s).parts(); ok {
+			t.Errorf("%q: Lazy accepts what Parse rejects", s)
+		}
+		if Lazy(s).Cmp("0.0.1") >= 0 {
+			t.Errorf("%q: malformed strings should sort first", s)
+		}
+	}
+}
+
+func TestSortLazy(t *testing.T) {
+	ls := []Lazy{"1.10.0", "v1.2.0", "bogus", "1.2.0-rc.1", "1.9.9"}
+	SortLazy(ls)
+	exp := []Lazy{"bogus", "1.2.0-rc.1", "v1.2.0", "1.9.9", "1.10.0"}
+	for i := range exp {
+		if ls[i] != exp[i] {
+			t.Fatalf("%q != %q", ls, exp)
+		}
+	}
+}
+
+func BenchmarkLazyCmp(b *testing.B) {
+	x, y := Lazy("1.2.3-rc.1.2"), Lazy("v1.2.3-rc.1.10")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x.Cmp(y)
+	}
+}
@@ -0,0 +1,278 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Maven versions are compared as lists of items, following the rules of
+// Maven's ComparableVersion: the version is split on dots, hyphens and
+// transitions between digits and letters, a hyphen or transition starts a
+// nested list, and trailing zeros and release qualifiers are dropped, so 1,
+// 1.0, 1-0 and 1.0-ga are all equal.
+
+type mavenKind int
+
+const (
+	mavenInt mavenKind = iota
+	mavenString
+	mavenList
+)
+
+type mavenItem struct {
+	kind  mavenKind
+	value string // the number, without leading zeros, or the qualifier
+	list  []*mavenItem
+}
+
+// mavenQualifiers are the well-known qualifiers, in order; "" is a release.
+// Unknown qualifiers sort after these, and so after the release, lexically.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+var mavenAliases = map[string]string{"ga": "", "final": "", "release": "", "cr": "rc"}
+
+func newMavenString(s string, followedByDigit bool) *mavenItem {
+	if followedByDigit && len(s) == 1 {
+		switch s {
+		case "a":
+			s = "alpha"
+		case "b":
+			s = "beta"
+		case "m":
+			s = "milestone"
+		}
+	}
+	if alias, ok := mavenAliases[s]; ok {
+		s = alias
+	}
+	return &mavenItem{kind: mavenString, value: s}
+}
+
+func newMavenInt(s string) *mavenItem {
+	if s = strings.TrimLeft(s, "0"); s == "" {
+		s = "0"
+	}
+	return &mavenItem{kind: mavenInt, value: s}
+}
+
+func mavenParseItem(isDigit bool, s string) *mavenItem {
+	if isDigit {
+		return newMavenInt(s)
+	}
+	return newMavenString(s, false)
+}
+
+// parseMaven splits a version into items as ComparableVersion does.
+func parseMaven(version string) *mavenItem {
+	version = strings.ToLower(version)
+	root := &mavenItem{kind: mavenList}
+	list := root
+	stack := []*mavenItem{root}
+	push := func() {
+		l := &mavenItem{kind: mavenList}
+		list.list = append(list.list, l)
+		list = l
+		stack = append(stack, l)
+	}
+
+	isDigitRun, start := false, 0
+	for i := 0; i < len(version); i++ {
+		switch c := version[i]; {
+		case c == '.' || c == '-':
+			if i == start {
+				list.list = append(list.list, newMavenInt("0"))
+			} else {
+				list.list = append(list.list, mavenParseItem(isDigitRun, version[start:i]))
+			}
+			start = i + 1
+			if c == '-' {
+				push()
+			}
+		case isDigit(c):
+			if !isDigitRun && i > start {
+				list.list = append(list.list, newMavenString(version[start:i], true))
+				start = i
+				push()
+			}
+			isDigitRun = true
+		default:
+			if isDigitRun && i > start {
+				list.list = append(list.list, mavenParseItem(true, version[start:i]))
+				start = i
+				push()
+			}
+			isDigitRun = false
+		}
+	}
+	if len(version) > start {
+		list.list = append(list.list, mavenParseItem(isDigitRun, version[start:]))
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+	return root
+}
+
+// isNull reports whether the item is equivalent to nothing: 0, a release
+// qualifier or an empty list.
+func (it *mavenItem) isNull() bool {
+	switch it.kind {
+	case mavenInt:
+		return it.value == "0"
+	case mavenString:
+		return it.value == ""
+	}
+	return len(it.list) == 0
+}
+
+// normalize drops trailing null items, stopping at the last item that isn't
+// a list.
+func (it *mavenItem) normalize() {
+	for i := len(it.list) - 1; i >= 0; i-- {
+		last := it.list[i]
+		if last.isNull() {
+			it.list = append(it.list[:i], it.list[i+1:]...)
+		} else if last.kind != mavenList {
+			break
+		}
+	}
+}
+
+func comparableQualifier(q string) string {
+	for i, known := range mavenQualifiers {
+		if q == known {
+			return strconv.Itoa(i)
+		}
+	}
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + q
+}
+
+// cmp compares two items; b may be nil, meaning the end of a list.
+func (a *mavenItem) cmp(b *mavenItem) int {
+	switch a.kind {
+	case mavenInt:
+		if b == nil {
+			if a.value == "0" {
+				return 0
+			}
+			return 1
+		}
+		switch b.kind {
+		case mavenInt:
+			if len(a.value) != len(b.value) {
+				return sign(len(a.value) - len(b.value))
+			}
+			return strings.Compare(a.value, b.value)
+		}
+		return 1
+	case mavenString:
+		if b == nil {
+			return strings.Compare(comparableQualifier(a.value), comparableQualifier(""))
+		}
+		switch b.kind {
+		case mavenString:
+			return strings.Compare(comparableQualifier(a.value), comparableQualifier(b.value))
+		case mavenInt, mavenList:
+			return -1
+		}
+	}
+
+	// a is a list
+	if b == nil {
+		if len(a.list) == 0 {
+			return 0
+		}
+		return a.list[0].cmp(nil)
+	}
+	switch b.kind {
+	case mavenInt:
+		return -1
+	case mavenString:
+		return 1
+	}
+	for i := 0; i < len(a.list) || i < len(b.list); i++ {
+		var c int
+		switch {
+		case i >= len(a.list):
+			c = -b.list[i].cmp(nil)
+		case i >= len(b.list):
+			c = a.list[i].cmp(nil)
+		default:
+			c = a.list[i].cmp(b.list[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// CompareMaven compares two Maven versions as Maven's ComparableVersion
+// does, returning -1, 0 or 1. Qualifiers are case-insensitive and ordered
+// alpha < beta < milestone < rc (or cr) < snapshot < release (ga, final or
+// none) < sp, with unknown qualifiers after sp in lexical order; a, b and m
+// directly followed by a number abbreviate alpha, beta and milestone, so
+// 1.0-alpha-1 equals 1.0a1.
+func CompareMaven(a, b string) int {
+	return sign(parseMaven(a).cmp(parseMaven(b)))
+}
+
+// ToMaven returns v as a Maven version, with the prerelease as a qualifier
+// after a hyphen: 1.2.3-rc.1 and 1.2.3-SNAPSHOT stay as they are. Build
+// metadata is dropped, since Maven would take it into account when ordering.
+//
+// Maven orders qualifiers differently from semver, and puts unknown ones
+// such as nightly after the release, so ToMaven returns an error if the
+// result wouldn't sort before the release, keeping prereleases before their
+// release in both systems.
+func (v Semver) ToMaven() (string, error) {
+	core := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease == "" {
+		return core, nil
+	}
+	s := core + "-" + v.Prerelease
+	if CompareMaven(s, core) >= 0 {
+		return "", fmt.Errorf("Cannot convert %s to Maven: %s would not sort before %s", v, s, core)
+	}
+	return s, nil
+}
+
+var mavenReg = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:[-.]([0-9A-Za-z][0-9A-Za-z-.]*))?$`)
+
+// FromMaven parses a Maven version with up to three numeric components and
+// an optional qualifier, such as 1.2, 1.2.3-SNAPSHOT or 2.0-beta-1. Missing
+// components are 0, release qualifiers such as Final are dropped and other
+// qualifiers become the prerelease. Qualifiers that Maven sorts after the
+// release, such as sp, have no semver equivalent and are errors.
+func FromMaven(s string) (Semver, error) {
+	m := mavenReg.FindStringSubmatch(s)
+	if m == nil {
+		return Semver{}, fmt.Errorf("Cannot convert Maven version %s: not major[.minor[.patch]][-qualifier]", s)
+	}
+	var v Semver
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range m[1:4] {
+		if p == "" {
+			continue
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Semver{}, fmt.Errorf("Invalid Maven version %s: %s", s, err)
+		}
+		*nums[i] = n
+	}
+	core := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	switch c := CompareMaven(s, core); {
+	case c == 0:
+		return v, nil
+	case c > 0:
+		return Semver{}, fmt.Errorf("Cannot convert Maven version %s: it sorts after %s", s, core)
+	}
+	v.Prerelease = m[4]
+	if err := v.Validate(); err != nil {
+		return Semver{}, fmt.Errorf("Invalid Maven version %s: %s", s, err)
+	}
+	return v, nil
+}
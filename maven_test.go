@@ -0,0 +1,103 @@
+package semver
+
+import "testing"
+
+func TestCompareMaven(t *testing.T) {
+	// in ascending order, from Maven's ComparableVersionTest
+	orders := [][]string{
+		{"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11", "1-rc", "1-cr2",
+			"1-rc123", "1-SNAPSHOT", "1", "1-sp", "1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot",
+			"1-1", "1-2", "1-123"},
+		{"2.0", "2-1", "2.0.a", "2.0.0.a", "2.0.2", "2.0.123", "2.1.0", "2.1-a", "2.1b", "2.1-c", "2.1-1", "2.1.0.1",
+			"2.2", "2.123", "11.a2", "11.a11", "11.b2", "11.b11", "11.m2", "11.m11", "11", "11.a", "11b", "11c", "11m"},
+	}
+	for _, ordered := range orders {
+		for i := 1; i < len(ordered); i++ {
+			a, b := ordered[i-1], ordered[i]
+			if c := CompareMaven(a, b); c != -1 {
+				t.Errorf("CompareMaven(%q, %q) = %d, expected -1", a, b, c)
+			}
+			if c := CompareMaven(b, a); c != 1 {
+				t.Errorf("CompareMaven(%q, %q) = %d, expected 1", b, a, c)
+			}
+		}
+	}
+
+	equal := [][]string{
+		{"1", "1.0", "1.0.0", "1-ga", "1.0-final", "1-RELEASE", "1.0.0-0.0"},
+		{"1x", "1-x", "1.0x", "1.0-x", "1.0.0x"},
+		{"1a1", "1-a1", "1-alpha-1", "1.0alpha1"},
+		{"1b2", "1-beta-2", "1.0-BETA2"},
+		{"1m3", "1-milestone-3", "1.0-milestone3"},
+		{"1rc", "1cr", "1-rc"},
+		{"10000000000000000000000", "10000000000000000000000.0"},
+	}
+	for _, group := range equal {
+		for _, b := range group[1:] {
+			if c := CompareMaven(group[0], b); c != 0 {
+				t.Errorf("CompareMaven(%q, %q) = %d, expected 0", group[0], b, c)
+			}
+		}
+	}
+}
+
+func TestToMaven(t *testing.T) {
+	tests := []struct {
+		given, exp string
+		reason     string
+	}{
+		{"1.2.3", "1.2.3", "release"},
+		{"1.2.3-rc.1", "1.2.3-rc.1", "rc"},
+		{"1.2.3-SNAPSHOT", "1.2.3-SNAPSHOT", "snapshot"},
+		{"1.2.3-alpha.1+build.5", "1.2.3-alpha.1", "build dropped"},
+	}
+
+	for _, test := range tests {
+		s, err := MustParse(test.given).ToMaven()
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+		} else if s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+		}
+	}
+
+	for _, s := range []string{"1.2.3-nightly.5", "1.2.3-1", "1.2.3-sp.1"} {
+		if m, err := MustParse(s).ToMaven(); err == nil {
+			t.Errorf("%s: expected error, returned: %s", s, m)
+		}
+	}
+}
+
+func TestFromMaven(t *testing.T) {
+	good := []goodParseTest{
+		{"1.2", Semver{Major: 1, Minor: 2}, "partial"},
+		{"1.2.3-SNAPSHOT", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "SNAPSHOT"}, "snapshot"},
+		{"2.0-beta-1", Semver{Major: 2, Prerelease: "beta-1"}, "qualifier with a hyphen"},
+		{"5.3.0.RC2", Semver{Major: 5, Minor: 3, Prerelease: "RC2"}, "dotted qualifier"},
+		{"5.3.0.Final", Semver{Major: 5, Minor: 3}, "release qualifier"},
+	}
+
+	for _, test := range good {
+		v, err := FromMaven(test.given)
+		if err != nil {
+			t.Errorf("%s: %s; given: %s", test.reason, err, test.given)
+		} else if v != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, v, test.exp)
+		}
+	}
+
+	bad := []badParseTest{
+		{"1.0-sp1", "service pack"},
+		{"1.0-1", "numeric qualifier"},
+		{"1.0-custom", "unknown qualifier"},
+		{"1.2.3.4", "four components"},
+		{"v1.0", "leading v"},
+		{"", "empty"},
+	}
+
+	for _, test := range bad {
+		if v, err := FromMaven(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, v)
+		}
+	}
+}
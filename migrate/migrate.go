@@ -0,0 +1,90 @@
+// Package migrate orders migrations, such as schema or config changes, by
+// the versions that introduce them, so that moving from one version to
+// another runs exactly the steps in between.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/beatgammit/semver"
+)
+
+// Step is a migration introduced by a version.
+type Step struct {
+	Version semver.Semver
+	Name    string
+	Run     func(context.Context) error
+}
+
+// Migrations is a set of steps. The zero Migrations is empty and ready to
+// use.
+type Migrations struct {
+	steps []Step
+}
+
+// Register adds a step introduced by version v. Several steps may be
+// registered for the same version; they run in the order they were
+// registered.
+func (m *Migrations) Register(v semver.Semver, name string, run func(context.Context) error) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	if run == nil {
+		return fmt.Errorf("Migration %s for %s has no function", name, v)
+	}
+	m.steps = append(m.steps, Step{v, name, run})
+	return nil
+}
+
+// Plan returns the steps to go from version from to version to: those
+// introduced after from, up to and including to, ordered by precedence.
+// Prereleases count like any other version, so going from 1.2.0-rc.1 to
+// 1.2.0 runs the steps of 1.2.0-rc.2 and 1.2.0, but going to 1.3.0-beta.1
+// doesn't run the steps of 1.3.0. Build metadata is ignored. Planning to a
+// version older than from is an error, since steps can't be undone.
+func (m *Migrations) Plan(from, to semver.Semver) ([]Step, error) {
+	if to.Cmp(from) < 0 {
+		return nil, fmt.Errorf("Cannot migrate down from %s to %s", from, to)
+	}
+	var plan []Step
+	for _, s := range m.steps {
+		if s.Version.Cmp(from) > 0 && s.Version.Cmp(to) <= 0 {
+			plan = append(plan, s)
+		}
+	}
+	sort.SliceStable(plan, func(i, j int) bool { return plan[i].Version.Cmp(plan[j].Version) < 0 })
+	return plan, nil
+}
+
+// Run runs the steps from Plan, stopping at the first that fails. After the
+// last step of each version succeeds it calls done with that version, and
+// then with to, so that the caller can record how far it got. Running again
+// from the last recorded version picks up where a failed run stopped, and
+// running from to does nothing, not even calling done. Only the steps of the
+// version that failed run twice, so they should be safe to repeat.
+func (m *Migrations) Run(ctx context.Context, from, to semver.Semver, done func(semver.Semver) error) error {
+	plan, err := m.Plan(from, to)
+	if err != nil || from.Cmp(to) == 0 {
+		return err
+	}
+	for i, s := range plan {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.Run(ctx); err != nil {
+			return fmt.Errorf("Migration %s for %s failed: %w", s.Name, s.Version, err)
+		}
+		last := i+1 == len(plan) || plan[i+1].Version.Cmp(s.Version) != 0
+		if last && done != nil {
+			if err := done(s.Version); err != nil {
+				return err
+			}
+		}
+	}
+	if done != nil && (len(plan) == 0 || plan[len(plan)-1].Version.Cmp(to) != 0) {
+		return done(to)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+// record returns Migrations with a step named after each version, which
+// appends its name to *ran.
+func record(ran *[]string, versions ...string) *Migrations {
+	m := new(Migrations)
+	for _, s := range versions {
+		name := s
+		m.Register(semver.MustParse(s), name, func(context.Context) error {
+			*ran = append(*ran, name)
+			return nil
+		})
+	}
+	return m
+}
+
+func names(steps []Step) []string {
+	var out []string
+	for _, s := range steps {
+		out = append(out, s.Name)
+	}
+	return out
+}
+
+func TestPlan(t *testing.T) {
+	var ran []string
+	m := record(&ran, "1.3.0", "1.2.0", "1.2.0-rc.2", "1.2.0-rc.1", "1.1.0", "1.3.0-beta.1")
+	tests := []struct {
+		from, to string
+		exp      []string
+		reason   string
+	}{
+		{"1.0.0", "1.3.0", []string{"1.1.0", "1.2.0-rc.1", "1.2.0-rc.2", "1.2.0", "1.3.0-beta.1", "1.3.0"}, "everything"},
+		{"1.2.0-rc.1", "1.2.0", []string{"1.2.0-rc.2", "1.2.0"}, "from a prerelease"},
+		{"1.2.0", "1.3.0-beta.1", []string{"1.3.0-beta.1"}, "to a prerelease"},
+		{"1.1.0", "1.1.5", nil, "no steps in between"},
+		{"1.3.0", "1.3.0+build.2", nil, "build metadata"},
+	}
+
+	for _, test := range tests {
+		plan, err := m.Plan(semver.MustParse(test.from), semver.MustParse(test.to))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+		} else if got := names(plan); !reflect.DeepEqual(got, test.exp) {
+			t.Errorf("%s: %v != %v", test.reason, got, test.exp)
+		}
+	}
+
+	if _, err := m.Plan(semver.MustParse("1.3.0"), semver.MustParse("1.2.0")); err == nil {
+		t.Errorf("expected an error migrating down")
+	}
+	if err := m.Register(semver.MustParse("2.0.0"), "nil", nil); err == nil {
+		t.Errorf("expected an error registering a nil step")
+	}
+}
+
+func TestRun(t *testing.T) {
+	var ran, recorded []string
+	m := record(&ran, "1.1.0", "1.2.0")
+	fail := true
+	m.Register(semver.MustParse("1.2.0"), "flaky", func(context.Context) error {
+		ran = append(ran, "flaky")
+		if fail {
+			return errors.New("disk full")
+		}
+		return nil
+	})
+	done := func(v semver.Semver) error {
+		recorded = append(recorded, v.String())
+		return nil
+	}
+
+	err := m.Run(context.Background(), semver.MustParse("1.0.0"), semver.MustParse("1.4.0"), done)
+	if err == nil {
+		t.Fatalf("expected the flaky step to fail")
+	}
+	if exp := []string{"1.1.0", "1.2.0", "flaky"}; !reflect.DeepEqual(ran, exp) {
+		t.Errorf("ran %v != %v", ran, exp)
+	}
+	if exp := []string{"1.1.0"}; !reflect.DeepEqual(recorded, exp) {
+		t.Errorf("recorded %v != %v", recorded, exp)
+	}
+
+	// resume from the last recorded version
+	fail, ran, recorded = false, nil, nil
+	if err := m.Run(context.Background(), semver.MustParse("1.1.0"), semver.MustParse("1.4.0"), done); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp := []string{"1.2.0", "flaky"}; !reflect.DeepEqual(ran, exp) {
+		t.Errorf("ran %v != %v", ran, exp)
+	}
+	if exp := []string{"1.2.0", "1.4.0"}; !reflect.DeepEqual(recorded, exp) {
+		t.Errorf("recorded %v != %v", recorded, exp)
+	}
+
+	// running again does nothing
+	ran, recorded = nil, nil
+	if err := m.Run(context.Background(), semver.MustParse("1.4.0"), semver.MustParse("1.4.0"), done); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(ran) != 0 || len(recorded) != 0 {
+		t.Errorf("re-run ran %v, recorded %v", ran, recorded)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.Run(ctx, semver.MustParse("1.0.0"), semver.MustParse("1.4.0"), nil); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// incompatible is the build metadata the go command appends to v2 or later
+// versions of modules that don't have a go.mod file, e.g. v2.0.1+incompatible.
+const incompatible = "incompatible"
+
+// Incompatible reports whether v carries the +incompatible suffix.
+//
+// The go command only accepts the suffix on v2 or later and, like Cmp, ignores
+// it for precedence: v2.0.1+incompatible and v2.0.1 are the same version.
+func (v Semver) Incompatible() bool {
+	return v.Build == incompatible
+}
+
+// ModuleVersion returns v in the canonical form the go command uses for
+// module versions: with a leading v and without build metadata, except for
+// +incompatible, so 1.2.3+build becomes v1.2.3 and 2.0.1+incompatible
+// stays v2.0.1+incompatible.
+func (v Semver) ModuleVersion() string {
+	u := v
+	if !v.Incompatible() {
+		u.Build = ""
+	}
+	return "v" + u.String()
+}
+
+// MajorSuffix returns the major version suffix that semantic import
+// versioning requires at the end of the path of a module with version v:
+// "/vN" for v2 and later, and "" for v0, v1 and +incompatible versions.
+func (v Semver) MajorSuffix() string {
+	if v.Major < 2 || v.Incompatible() {
+		return ""
+	}
+	return "/v" + strconv.Itoa(v.Major)
+}
+
+// CheckModulePath checks that a module path and version are consistent, as
+// the go command does: a path ending in /vN (or .vN for gopkg.in) must have
+// major version N, a path without a suffix must have major version 0 or 1
+// unless the version is +incompatible, and +incompatible is only allowed on
+// v2 or later without a suffix.
+func CheckModulePath(path string, v Semver) error {
+	prefix, suffix, err := splitModulePath(path)
+	if err != nil {
+		return err
+	}
+	if v.Incompatible() {
+		if v.Major < 2 {
+			return fmt.Errorf("Invalid module version %s: +incompatible requires v2 or later", v.ModuleVersion())
+		}
+		if suffix != "" {
+			return fmt.Errorf("Invalid module version %s: +incompatible not allowed with the major version suffix of %s", v.ModuleVersion(), path)
+		}
+		return nil
+	}
+	if strings.HasPrefix(suffix, ".v") {
+		// gopkg.in/yaml.v2; the go command once generated v0.0.0
+		// pseudo-versions for .v1 paths, so it still accepts them
+		n, _ := strconv.Atoi(strings.TrimSuffix(suffix[2:], "-unstable"))
+		if v.Major != n && !(n == 1 && strings.HasPrefix(v.ModuleVersion(), "v0.0.0-")) {
+			return fmt.Errorf("Module %s has version %s; its path requires v%d", path, v.ModuleVersion(), n)
+		}
+		return nil
+	}
+	if want := v.MajorSuffix(); suffix != want {
+		if want == "" {
+			return fmt.Errorf("Module %s has version %s; its path should be %s", path, v.ModuleVersion(), prefix)
+		}
+		return fmt.Errorf("Module %s has version %s; its path should be %s%s", path, v.ModuleVersion(), prefix, want)
+	}
+	return nil
+}
+
+// splitModulePath splits a module path into the path without its major
+// version suffix and the suffix, such as "/v2", or "" if there is none.
+func splitModulePath(path string) (prefix, suffix string, err error) {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		i := strings.LastIndex(path, ".v")
+		if i < 0 || !isDigits(strings.TrimSuffix(path[i+2:], "-unstable")) {
+			return "", "", fmt.Errorf("Invalid module path %s: gopkg.in paths must end in .vN", path)
+		}
+		return path[:i], path[i:], nil
+	}
+	i := strings.LastIndexByte(path, '/')
+	if i <= 0 || len(path) < i+3 || path[i+1] != 'v' || !isDigits(path[i+2:]) {
+		return path, "", nil
+	}
+	if n := path[i+2:]; n == "1" || n[0] == '0' {
+		return "", "", fmt.Errorf("Invalid module path %s: invalid major version suffix %s", path, path[i:])
+	}
+	return path[:i], path[i:], nil
+}
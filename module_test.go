@@ -0,0 +1,90 @@
+package semver
+
+import "testing"
+
+func TestIncompatible(t *testing.T) {
+	v := MustParse("v2.0.1+incompatible")
+	if !v.Incompatible() {
+		t.Errorf("expected %s to be incompatible", v)
+	}
+	if s := v.String(); s != "2.0.1+incompatible" {
+		t.Errorf("suffix not preserved: %s", s)
+	}
+	if v.Cmp(MustParse("v2.0.1")) != 0 {
+		t.Errorf("+incompatible should not affect precedence")
+	}
+	if MustParse("v2.0.1+incompatible.1").Incompatible() {
+		t.Errorf("only the exact +incompatible suffix is recognized")
+	}
+
+	p, err := ParsePseudo("v2.0.1-0.20240101120000-abcdef123456+incompatible")
+	if err != nil {
+		t.Fatalf("error parsing pseudo-version: %s", err)
+	}
+	if exp := (Semver{Major: 2, Build: "incompatible"}); p.Base != exp {
+		t.Errorf("base %+v != %+v", p.Base, exp)
+	}
+}
+
+func TestModuleVersion(t *testing.T) {
+	tests := []struct {
+		given, version, suffix string
+	}{
+		{"1.2.3", "v1.2.3", ""},
+		{"v0.1.0-rc.1+build", "v0.1.0-rc.1", ""},
+		{"2.0.1+incompatible", "v2.0.1+incompatible", ""},
+		{"2.0.0", "v2.0.0", "/v2"},
+		{"v12.3.4-pre", "v12.3.4-pre", "/v12"},
+	}
+
+	for _, test := range tests {
+		v := MustParse(test.given)
+		if s := v.ModuleVersion(); s != test.version {
+			t.Errorf("%s: ModuleVersion() = %s, expected %s", test.given, s, test.version)
+		}
+		if s := v.MajorSuffix(); s != test.suffix {
+			t.Errorf("%s: MajorSuffix() = %q, expected %q", test.given, s, test.suffix)
+		}
+	}
+}
+
+func TestCheckModulePath(t *testing.T) {
+	good := []struct {
+		path, version string
+	}{
+		{"example.com/mod", "v0.1.0"},
+		{"example.com/mod", "v1.2.3"},
+		{"example.com/mod", "v3.0.0+incompatible"},
+		{"example.com/mod/v2", "v2.0.0"},
+		{"example.com/mod/v2", "v2.1.0-0.20240101120000-abcdef123456"},
+		{"example.com/v2", "v2.0.0"},
+		{"gopkg.in/yaml.v2", "v2.4.0"},
+		{"gopkg.in/yaml.v1", "v0.0.0-20140101000000-abcdef123456"},
+		{"gopkg.in/check.v1-unstable", "v1.0.0"},
+	}
+	for _, test := range good {
+		if err := CheckModulePath(test.path, MustParse(test.version)); err != nil {
+			t.Errorf("%s@%s: %s", test.path, test.version, err)
+		}
+	}
+
+	bad := []struct {
+		path, version, reason string
+	}{
+		{"example.com/mod", "v2.0.0", "missing suffix"},
+		{"example.com/mod/v2", "v1.0.0", "suffix on v1"},
+		{"example.com/mod/v2", "v3.0.0", "wrong suffix"},
+		{"example.com/mod/v2", "v2.0.0+incompatible", "suffix with +incompatible"},
+		{"example.com/mod", "v1.0.0+incompatible", "+incompatible on v1"},
+		{"example.com/mod/v1", "v1.0.0", "v1 suffix"},
+		{"example.com/mod/v02", "v2.0.0", "leading zero in suffix"},
+		{"gopkg.in/yaml.v2", "v3.0.0", "wrong gopkg.in suffix"},
+		{"gopkg.in/yaml.v1", "v0.1.0", "v0 release for gopkg.in .v1"},
+		{"gopkg.in/yaml", "v1.0.0", "gopkg.in without suffix"},
+	}
+	for _, test := range bad {
+		if err := CheckModulePath(test.path, MustParse(test.version)); err == nil {
+			t.Errorf("%s: %s@%s should be invalid", test.reason, test.path, test.version)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package semver
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MarshalMsgpack encodes the version as a MessagePack string. It implements
+// msgpack.Marshaler from github.com/vmihailenco/msgpack, which needs no
+// import.
+func (ver Semver) MarshalMsgpack() ([]byte, error) {
+	return appendMsgpackString(nil, ver.String()), nil
+}
+
+// UnmarshalMsgpack decodes a version encoded either as a string or in the
+// array form written by MsgpackArray, and validates it.
+func (ver *Semver) UnmarshalMsgpack(data []byte) error {
+	r := msgpackReader(data)
+	var v Semver
+	var err error
+	if len(r) > 0 && isMsgpackArray(r[0]) {
+		v, err = r.readArray()
+	} else {
+		var s string
+		if s, err = r.readString(); err == nil {
+			v, err = Parse(s)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(r) != 0 {
+		return fmt.Errorf("Invalid semver msgpack: trailing data")
+	}
+	*ver = v
+	return nil
+}
+
+// MsgpackArray is a Semver that is encoded in MessagePack as an array of
+// [major, minor, patch], followed by the prerelease and build metadata strings
+// when they are present. This is smaller than the string form and sorts
+// numerically in stores that understand MessagePack.
+type MsgpackArray Semver
+
+// MarshalMsgpack encodes the version in the array form.
+func (a MsgpackArray) MarshalMsgpack() ([]byte, error) {
+	v := Semver(a)
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	n := 3
+	if v.Build != "" {
+		n = 5
+	} else if v.Prerelease != "" {
+		n = 4
+	}
+	b := []byte{0x90 | byte(n)}
+	b = appendMsgpackUint(b, uint64(v.Major))
+	b = appendMsgpackUint(b, uint64(v.Minor))
+	b = appendMsgpackUint(b, uint64(v.Patch))
+	if n > 3 {
+		b = appendMsgpackString(b, v.Prerelease)
+	}
+	if n > 4 {
+		b = appendMsgpackString(b, v.Build)
+	}
+	return b, nil
+}
+
+// UnmarshalMsgpack decodes a version encoded in either form.
+func (a *MsgpackArray) UnmarshalMsgpack(data []byte) error {
+	return (*Semver)(a).UnmarshalMsgpack(data)
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= 0xff:
+		b = append(b, 0xd9, byte(n))
+	case n <= 0xffff:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+func appendMsgpackUint(b []byte, n uint64) []byte {
+	switch {
+	case n < 0x80:
+		return append(b, byte(n))
+	case n <= 0xff:
+		return append(b, 0xcc, byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcf), n)
+}
+
+func isMsgpackArray(c byte) bool {
+	return c&0xf0 == 0x90 || c == 0xdc || c == 0xdd
+}
+
+// msgpackReader decodes the handful of MessagePack types a version uses,
+// consuming its input as it goes.
+type msgpackReader []byte
+
+// next consumes n bytes.
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || len(*r) < n {
+		return nil, fmt.Errorf("Invalid semver msgpack: unexpected end of data")
+	}
+	b := (*r)[:n]
+	*r = (*r)[n:]
+	return b, nil
+}
+
+// length reads a big-endian length of size bytes.
+func (r *msgpackReader) length(size int) (int, error) {
+	b, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	if n > uint64(maxInt) {
+		return 0, fmt.Errorf("Invalid semver msgpack: length out of range")
+	}
+	return int(n), nil
+}
+
+func (r *msgpackReader) readString() (string, error) {
+	tag, err := r.next(1)
+	if err != nil {
+		return "", err
+	}
+	var n int
+	switch c := tag[0]; {
+	case c&0xe0 == 0xa0:
+		n = int(c & 0x1f)
+	case c == 0xd9, c == 0xc4:
+		n, err = r.length(1)
+	case c == 0xda, c == 0xc5:
+		n, err = r.length(2)
+	case c == 0xdb, c == 0xc6:
+		n, err = r.length(4)
+	default:
+		return "", fmt.Errorf("Invalid semver msgpack: expected a string, got 0x%02x", c)
+	}
+	if err != nil {
+		return "", err
+	}
+	b, err := r.next(n)
+	return string(b), err
+}
+
+func (r *msgpackReader) readUint() (int, error) {
+	tag, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	var size int
+	switch c := tag[0]; {
+	case c < 0x80:
+		return int(c), nil
+	case c == 0xcc, c == 0xd0:
+		size = 1
+	case c == 0xcd, c == 0xd1:
+		size = 2
+	case c == 0xce, c == 0xd2:
+		size = 4
+	case c == 0xcf, c == 0xd3:
+		size = 8
+	default:
+		return 0, fmt.Errorf("Invalid semver msgpack: expected a non-negative integer, got 0x%02x", c)
+	}
+	b, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	// signed and unsigned encodings agree for the non-negative values we accept
+	if tag[0] >= 0xd0 && b[0]&0x80 != 0 {
+		return 0, fmt.Errorf("Invalid semver msgpack: negative version number")
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	if n > uint64(maxInt) {
+		return 0, fmt.Errorf("Invalid semver msgpack: version number out of range")
+	}
+	return int(n), nil
+}
+
+func (r *msgpackReader) readArray() (v Semver, err error) {
+	tag, _ := r.next(1)
+	var n int
+	switch c := tag[0]; {
+	case c&0xf0 == 0x90:
+		n = int(c & 0x0f)
+	case c == 0xdc:
+		n, err = r.length(2)
+	case c == 0xdd:
+		n, err = r.length(4)
+	}
+	if err != nil {
+		return v, err
+	}
+	if n < 3 || n > 5 {
+		return v, fmt.Errorf("Invalid semver msgpack: array of %d elements", n)
+	}
+	for _, p := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if *p, err = r.readUint(); err != nil {
+			return v, err
+		}
+	}
+	for i, p := range []*string{&v.Prerelease, &v.Build} {
+		if n > 3+i {
+			if *p, err = r.readString(); err != nil {
+				return v, err
+			}
+		}
+	}
+	return v, v.Validate()
+}
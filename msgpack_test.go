@@ -0,0 +1,67 @@
+package semver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type msgpackTest struct {
+	given  Semver
+	str    []byte
+	array  []byte
+	reason string
+}
+
+func TestMsgpack(t *testing.T) {
+	tests := []msgpackTest{
+		{MustParse("1.2.3"), []byte("\xa51.2.3"), []byte("\x93\x01\x02\x03"), "basic"},
+		{MustParse("1.2.300-rc.1"), []byte("\xac1.2.300-rc.1"), []byte("\x94\x01\x02\xcd\x01\x2c\xa4rc.1"), "prerelease"},
+		{MustParse("1.2.3+5"), []byte("\xa71.2.3+5"), []byte("\x95\x01\x02\x03\xa0\xa15"), "build, no prerelease"},
+	}
+
+	for _, test := range tests {
+		if b, err := test.given.MarshalMsgpack(); err != nil || !bytes.Equal(b, test.str) {
+			t.Errorf("%s: string form %q, %v", test.reason, b, err)
+		}
+		if b, err := MsgpackArray(test.given).MarshalMsgpack(); err != nil || !bytes.Equal(b, test.array) {
+			t.Errorf("%s: array form %q, %v", test.reason, b, err)
+		}
+		for _, data := range [][]byte{test.str, test.array} {
+			var ver Semver
+			if err := ver.UnmarshalMsgpack(data); err != nil || ver != test.given {
+				t.Errorf("%s: decoding %q: %+v, %v", test.reason, data, ver, err)
+			}
+			var a MsgpackArray
+			if err := a.UnmarshalMsgpack(data); err != nil || Semver(a) != test.given {
+				t.Errorf("%s: decoding %q as MsgpackArray: %+v, %v", test.reason, data, a, err)
+			}
+		}
+	}
+
+	long := Semver{Major: 1, Prerelease: strings.Repeat("a", 40)}
+	b, _ := long.MarshalMsgpack()
+	if b[0] != 0xd9 {
+		t.Errorf("expected str8 for a %d byte string, got 0x%02x", len(long.String()), b[0])
+	}
+	var ver Semver
+	if err := ver.UnmarshalMsgpack(b); err != nil || ver != long {
+		t.Errorf("str8 round trip: %+v, %v", ver, err)
+	}
+
+	bad := [][]byte{
+		nil,
+		[]byte("\xa31.2"),
+		[]byte("\xa61.2.3"),
+		[]byte("\x92\x01\x02"),
+		[]byte("\x93\x01\x02\xff"),
+		[]byte("\x93\x01\x02\xd0\xff"),
+		[]byte("\x93\x01\x02\x03\x00"),
+		[]byte("\x01"),
+	}
+	for _, data := range bad {
+		if err := ver.UnmarshalMsgpack(data); err == nil {
+			t.Errorf("%q: expected error", data)
+		}
+	}
+}
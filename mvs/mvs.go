@@ -0,0 +1,115 @@
+// Package mvs implements minimal version selection, the algorithm the go
+// command uses to choose the versions of a module's dependencies. Each
+// module version lists the minimum versions of the modules it requires, and
+// the build list uses, for every module reachable from the target, the
+// highest of the minimums asked for. The result depends only on the
+// requirement lists, never on which versions happen to be newest.
+package mvs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beatgammit/semver"
+)
+
+// Module is a module path at a version.
+type Module struct {
+	Path    string
+	Version semver.Semver
+}
+
+// String formats the module as the go command does, e.g. "example.com/a@v1.2.3".
+func (m Module) String() string {
+	return m.Path + "@v" + m.Version.String()
+}
+
+// Reqs gives the requirement lists of module versions.
+type Reqs interface {
+	// Required returns the modules that m requires, at their minimum
+	// versions.
+	Required(m Module) ([]Module, error)
+}
+
+// Graph is a Reqs held in memory, mapping each module version to its
+// requirements. Module versions that aren't in the map are errors.
+type Graph map[Module][]Module
+
+// Required returns the requirements of m.
+func (g Graph) Required(m Module) ([]Module, error) {
+	reqs, ok := g[m]
+	if !ok {
+		return nil, fmt.Errorf("Unknown module %s", m)
+	}
+	return reqs, nil
+}
+
+// Error reports a failure to read the requirements of a module, along with
+// the chain of requirements that reached it from the target.
+type Error struct {
+	Stack []Module // from the target to the module that failed
+	Err   error
+}
+
+func (e *Error) Error() string {
+	var names []string
+	for _, m := range e.Stack {
+		names = append(names, m.String())
+	}
+	return strings.Join(names, " requires\n\t") + ": " + e.Err.Error()
+}
+
+// BuildList returns the build list for target: target itself, then the
+// selected version of every other module it needs, sorted by path. Every
+// module version reached is visited, including ones that end up not being
+// selected, since their requirements still count. Requirements on the
+// target's own path never change the target's version.
+func BuildList(target Module, reqs Reqs) ([]Module, error) {
+	selected := map[string]semver.Semver{target.Path: target.Version}
+	parent := map[Module]Module{}
+	seen := map[Module]bool{target: true}
+	queue := []Module{target}
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+		required, err := reqs.Required(m)
+		if err != nil {
+			return nil, &Error{stack(m, target, parent), err}
+		}
+		for _, r := range required {
+			if seen[r] {
+				continue
+			}
+			seen[r] = true
+			parent[r] = m
+			queue = append(queue, r)
+			if r.Path == target.Path {
+				// the target's own version always wins
+				continue
+			}
+			if v, ok := selected[r.Path]; !ok || r.Version.Cmp(v) > 0 {
+				selected[r.Path] = r.Version
+			}
+		}
+	}
+
+	list := []Module{target}
+	for path, v := range selected {
+		if path != target.Path {
+			list = append(list, Module{path, v})
+		}
+	}
+	sort.Slice(list[1:], func(i, j int) bool { return list[i+1].Path < list[j+1].Path })
+	return list, nil
+}
+
+// stack returns the chain of requirements from target to m.
+func stack(m, target Module, parent map[Module]Module) []Module {
+	s := []Module{m}
+	for m != target {
+		m = parent[m]
+		s = append([]Module{m}, s...)
+	}
+	return s
+}
@@ -0,0 +1,111 @@
+package mvs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+func mod(s string) Module {
+	i := strings.IndexByte(s, '@')
+	return Module{s[:i], semver.MustParse(s[i+1:])}
+}
+
+// graph builds a Graph from lines of "module: requirement requirement ..."
+func graph(lines ...string) Graph {
+	g := Graph{}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		m := mod(strings.TrimSuffix(fields[0], ":"))
+		g[m] = []Module{}
+		for _, r := range fields[1:] {
+			g[m] = append(g[m], mod(r))
+		}
+	}
+	return g
+}
+
+func names(list []Module) string {
+	var s []string
+	for _, m := range list {
+		s = append(s, m.String())
+	}
+	return strings.Join(s, " ")
+}
+
+func TestBuildList(t *testing.T) {
+	// the example from the minimal version selection design
+	g := graph(
+		"a@1.0.0: b@1.2.0 c@1.2.0",
+		"b@1.1.0: d@1.1.0",
+		"b@1.2.0: d@1.3.0",
+		"c@1.1.0:",
+		"c@1.2.0: d@1.4.0",
+		"c@1.3.0: f@1.1.0",
+		"d@1.1.0: e@1.1.0",
+		"d@1.2.0: e@1.1.0",
+		"d@1.3.0: e@1.2.0",
+		"d@1.4.0: e@1.2.0",
+		"e@1.1.0:",
+		"e@1.2.0:",
+		"e@1.3.0:",
+		"f@1.1.0: g@1.1.0",
+		"g@1.1.0: f@1.1.0",
+	)
+	tests := []struct {
+		target string
+		exp    string
+		reason string
+	}{
+		{"a@1.0.0", "a@v1.0.0 b@v1.2.0 c@v1.2.0 d@v1.4.0 e@v1.2.0", "highest minimum wins"},
+		{"c@1.3.0", "c@v1.3.0 f@v1.1.0 g@v1.1.0", "cycle"},
+		{"e@1.3.0", "e@v1.3.0", "no requirements"},
+	}
+
+	for _, test := range tests {
+		list, err := BuildList(mod(test.target), g)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+		} else if s := names(list); s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+		}
+	}
+}
+
+func TestBuildListTarget(t *testing.T) {
+	// b requires a newer a, which mustn't replace the target, but the newer
+	// a's requirements still count
+	g := graph(
+		"a@1.0.0: b@1.0.0",
+		"a@1.1.0: c@1.1.0",
+		"b@1.0.0: a@1.1.0",
+		"c@1.1.0:",
+	)
+	list, err := BuildList(mod("a@1.0.0"), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s, exp := names(list), "a@v1.0.0 b@v1.0.0 c@v1.1.0"; s != exp {
+		t.Errorf("%s != %s", s, exp)
+	}
+}
+
+func TestBuildListError(t *testing.T) {
+	g := graph(
+		"a@1.0.0: b@1.0.0",
+		"b@1.0.0: c@1.0.0",
+	)
+	_, err := BuildList(mod("a@1.0.0"), g)
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("expected an *Error, got %v", err)
+	}
+	if s, exp := names(e.Stack), "a@v1.0.0 b@v1.0.0 c@v1.0.0"; s != exp {
+		t.Errorf("stack %s != %s", s, exp)
+	}
+	if exp := "a@v1.0.0 requires\n\tb@v1.0.0 requires\n\tc@v1.0.0: Unknown module c@v1.0.0"; err.Error() != exp {
+		t.Errorf("%q != %q", err.Error(), exp)
+	}
+}
@@ -0,0 +1,88 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A NegotiateOption changes how Negotiate and NegotiateRanges choose a
+// version.
+type NegotiateOption func(*negotiation)
+
+type negotiation struct {
+	prerelease func(Semver) bool
+}
+
+// AcceptPrerelease decides which prereleases may be chosen. Without it,
+// prereleases are acceptable only as the constraints' prerelease rule
+// allows, so a client supporting ^2.0.0-rc.1 can settle on 2.0.0-rc.2 but
+// not 2.1.0-beta.1. With it, a prerelease is acceptable if accept returns
+// true and it falls within the constraints' ranges, and never otherwise;
+// pass a func that always returns false to negotiate releases only.
+func AcceptPrerelease(accept func(Semver) bool) NegotiateOption {
+	return func(n *negotiation) { n.prerelease = accept }
+}
+
+// acceptable reports whether v satisfies every one of cs under the
+// negotiation's prerelease policy.
+func (n *negotiation) acceptable(v Semver, cs ...Constraint) bool {
+	hooked := v.Prerelease != "" && n.prerelease != nil
+	if hooked && !n.prerelease(v) {
+		return false
+	}
+	for _, c := range cs {
+		if hooked {
+			c.includePrerelease = true
+		}
+		if !c.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// best returns the first version in versions with the highest precedence
+// that satisfies every one of cs.
+func (n *negotiation) best(versions []Semver, cs ...Constraint) (best Semver, found bool) {
+	for _, v := range versions {
+		if n.acceptable(v, cs...) && (!found || v.Cmp(best) > 0) {
+			best, found = v, true
+		}
+	}
+	return
+}
+
+// Negotiate chooses the version of a protocol to speak, the highest that the
+// server offers and the client supports. If the server offers versions that
+// differ only in build metadata the first is chosen.
+func Negotiate(clientSupported Constraint, serverOffered []Semver, opts ...NegotiateOption) (Semver, error) {
+	n := new(negotiation)
+	for _, opt := range opts {
+		opt(n)
+	}
+	if v, ok := n.best(serverOffered, clientSupported); ok {
+		return v, nil
+	}
+	return Semver{}, fmt.Errorf("No mutually acceptable version: client supports %q, server offers %s", clientSupported, joinVersions(serverOffered))
+}
+
+// NegotiateRanges chooses the version of a protocol to speak when both sides
+// give the ranges they support: the highest of the known versions of the
+// protocol that satisfies both.
+func NegotiateRanges(client, server Constraint, known []Semver, opts ...NegotiateOption) (Semver, error) {
+	n := new(negotiation)
+	for _, opt := range opts {
+		opt(n)
+	}
+	if v, ok := n.best(known, client, server); ok {
+		return v, nil
+	}
+	return Semver{}, fmt.Errorf("No mutually acceptable version: client supports %q, server supports %q", client, server)
+}
+
+func joinVersions(vs []Semver) string {
+	if len(vs) == 0 {
+		return "nothing"
+	}
+	return strings.Join(Versions(vs).Strings(), ", ")
+}
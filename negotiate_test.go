@@ -0,0 +1,77 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	offered := []Semver{
+		MustParse("1.0.0"),
+		MustParse("1.4.0"),
+		MustParse("2.0.0-rc.2"),
+		MustParse("2.1.0-beta.1"),
+		MustParse("3.0.0"),
+	}
+	onlyRC := AcceptPrerelease(func(v Semver) bool { return strings.HasPrefix(v.Prerelease, "rc.") })
+	tests := []struct {
+		client string
+		opts   []NegotiateOption
+		exp    string
+		reason string
+	}{
+		{"^1.0.0", nil, "1.4.0", "highest of the major"},
+		{">=1.0.0 <3.0.0", nil, "1.4.0", "prereleases skipped"},
+		{"^2.0.0-rc.1", nil, "2.0.0-rc.2", "prerelease named by the range"},
+		{">=1.0.0 <3.0.0", []NegotiateOption{AcceptPrerelease(func(Semver) bool { return true })}, "2.1.0-beta.1", "any prerelease accepted"},
+		{">=1.0.0 <3.0.0", []NegotiateOption{onlyRC}, "2.0.0-rc.2", "rc prereleases accepted"},
+		{"^2.0.0-rc.1", []NegotiateOption{AcceptPrerelease(func(Semver) bool { return false })}, "", "prereleases rejected"},
+		{"^1.0.0 || ^3.0.0", nil, "3.0.0", "alternatives"},
+		{"^4.0.0", nil, "", "no overlap"},
+	}
+
+	for _, test := range tests {
+		v, err := Negotiate(MustParseConstraint(test.client), offered, test.opts...)
+		if test.exp == "" {
+			if err == nil {
+				t.Errorf("%s: expected error, returned: %s", test.reason, v)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+		} else if v.String() != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, v, test.exp)
+		}
+	}
+
+	_, err := Negotiate(MustParseConstraint("^4"), offered[:2])
+	if exp := `No mutually acceptable version: client supports "^4", server offers 1.0.0, 1.4.0`; err == nil || err.Error() != exp {
+		t.Errorf("%v != %q", err, exp)
+	}
+}
+
+func TestNegotiateRanges(t *testing.T) {
+	known := []Semver{MustParse("1.0.0"), MustParse("1.1.0"), MustParse("1.2.0"), MustParse("2.0.0")}
+	tests := []struct {
+		client string
+		server string
+		exp    string
+		reason string
+	}{
+		{"^1.0.0", ">=1.1.0", "1.2.0", "overlap"},
+		{">=1.0.0", "<=1.1.0 || 2.x", "2.0.0", "alternatives"},
+		{"^1.0.0", "^2.0.0", "", "disjoint"},
+	}
+
+	for _, test := range tests {
+		v, err := NegotiateRanges(MustParseConstraint(test.client), MustParseConstraint(test.server), known)
+		if test.exp == "" {
+			if err == nil {
+				t.Errorf("%s: expected error, returned: %s", test.reason, v)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+		} else if v.String() != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, v, test.exp)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicate is wrapped by the Err of a Rejected tag that is Equal to an
+// earlier one.
+var ErrDuplicate = errors.New("Duplicate version")
+
+// Rejected is a tag NormalizeTags dropped, and why.
+type Rejected struct {
+	Index int    // position in the input
+	Tag   string // the tag as given
+	Err   error
+}
+
+func (r Rejected) String() string {
+	return fmt.Sprintf("%d: %q: %s", r.Index, r.Tag, r.Err)
+}
+
+// NormalizeTags turns a raw list of tags into a clean, sorted set of
+// versions. Each tag is parsed as ParseWith does with opts, so prefixes and
+// stray characters are handled by the same options; pass Lenient to coerce
+// forms like "v1.2". Tags that don't parse, and versions Equal to one seen
+// earlier, are reported as Rejected, in input order.
+func NormalizeTags(tags []string, opts ...ParseOption) (Versions, []Rejected) {
+	o := newParseOptions(opts)
+	var out Versions
+	var rejected []Rejected
+	first := make(map[Key]string, len(tags))
+	for i, tag := range tags {
+		_, _, v, err := o.parse(tag)
+		if err != nil {
+			rejected = append(rejected, Rejected{i, tag, err})
+			continue
+		}
+		k := v.Key()
+		if prev, ok := first[k]; ok {
+			rejected = append(rejected, Rejected{i, tag, fmt.Errorf("%w of %q", ErrDuplicate, prev)})
+			continue
+		}
+		first[k] = tag
+		out = append(out, v)
+	}
+	out.Sort()
+	return out, rejected
+}
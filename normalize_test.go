@@ -0,0 +1,36 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags := []string{"release-1.2", "v1.10.0", "latest", "release-1.2.0", "1.0.0-rc.1", " v2 ", "v1.2.3.4", "1.10.0+build"}
+
+	vs, rejected := NormalizeTags(tags, WithPrefixes("release-"), Lenient(), WithWhitespace(Trim))
+	if exp := []string{"1.0.0-rc.1", "1.2.0", "1.10.0", "2.0.0"}; !reflect.DeepEqual(vs.Strings(), exp) {
+		t.Errorf("%q != %q", vs.Strings(), exp)
+	}
+
+	indexes := make([]int, len(rejected))
+	for i, r := range rejected {
+		indexes[i] = r.Index
+		if r.Tag != tags[r.Index] {
+			t.Errorf("rejected tag %q should be as given, %q", r.Tag, tags[r.Index])
+		}
+	}
+	if exp := []int{2, 3, 6, 7}; !reflect.DeepEqual(indexes, exp) {
+		t.Errorf("rejected %v != %v: %v", indexes, exp, rejected)
+	}
+	if !errors.Is(rejected[1].Err, ErrDuplicate) || errors.Is(rejected[0].Err, ErrDuplicate) {
+		t.Errorf("unexpected reasons: %v", rejected)
+	}
+
+	// strict by default
+	vs, rejected = NormalizeTags([]string{"v1.2", "v1.2.0"})
+	if len(vs) != 1 || len(rejected) != 1 || rejected[0].Index != 0 {
+		t.Errorf("unexpected strict result: %v, %v", vs, rejected)
+	}
+}
@@ -0,0 +1,255 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ fmt.Formatter            = NuGet{}
+	_ fmt.GoStringer           = NuGet{}
+	_ encoding.TextMarshaler   = NuGet{}
+	_ encoding.TextUnmarshaler = (*NuGet)(nil)
+	_ json.Marshaler           = NuGet{}
+	_ json.Unmarshaler         = (*NuGet)(nil)
+)
+
+var nugetReg = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z-.]+))?(?:\+([0-9A-Za-z-.]+))?$`)
+
+// NuGet is a NuGet package version: a semver with an optional fourth,
+// revision, component, as in 1.2.3.4-beta.
+type NuGet struct {
+	Semver
+	Revision int
+}
+
+// ParseNuGet parses a NuGet version. As in NuGet, missing minor and patch
+// versions are 0 and leading zeros are allowed, so 1, 1.0 and 01.0.0.0 all
+// parse to 1.0.0.
+func ParseNuGet(s string) (NuGet, error) {
+	m := nugetReg.FindStringSubmatch(s)
+	if m == nil {
+		return NuGet{}, fmt.Errorf("Invalid NuGet version: %s", s)
+	}
+	var n NuGet
+	nums := []*int{&n.Major, &n.Minor, &n.Patch, &n.Revision}
+	for i, p := range m[1:5] {
+		if p == "" {
+			continue
+		}
+		var err error
+		if *nums[i], err = strconv.Atoi(p); err != nil {
+			return NuGet{}, fmt.Errorf("Invalid NuGet version %s: %s", s, err)
+		}
+	}
+	n.Prerelease, n.Build = m[5], m[6]
+	if err := n.Validate(); err != nil {
+		return NuGet{}, fmt.Errorf("Invalid NuGet version %s: %s", s, err)
+	}
+	return n, nil
+}
+
+// Normalized returns the version in NuGet's normalized form, which it uses
+// to identify packages: three components, plus the revision if it isn't 0,
+// without leading zeros or build metadata. So 1.0, 1.0.0.0 and 1.0.0+abc all
+// normalize to 1.0.0, and 1.2.3.4-beta stays as it is.
+func (n NuGet) Normalized() string {
+	u := n.Semver
+	u.Build = ""
+	if n.Revision == 0 {
+		return u.String()
+	}
+	u.Prerelease = ""
+	s := u.String() + "." + strconv.Itoa(n.Revision)
+	if n.Prerelease != "" {
+		s += "-" + n.Prerelease
+	}
+	return s
+}
+
+// String returns the Normalized version with its build metadata.
+func (n NuGet) String() string {
+	if n.Build == "" {
+		return n.Normalized()
+	}
+	return n.Normalized() + "+" + n.Build
+}
+
+// AppendText appends the String form of the version to b.
+func (n NuGet) AppendText(b []byte) ([]byte, error) {
+	return append(b, n.String()...), nil
+}
+
+// MarshalText encodes the version as its String form, revision included.
+//
+// NuGet embeds Semver, so without its own encoders it would inherit
+// Semver's, which drop the revision. It implements every encoding that
+// Semver does, each encoding the version as a string.
+func (n NuGet) MarshalText() ([]byte, error) {
+	return n.AppendText(nil)
+}
+
+// UnmarshalText parses a version with ParseNuGet, leaving n unchanged on
+// error.
+func (n *NuGet) UnmarshalText(arr []byte) error {
+	v, err := ParseNuGet(string(arr))
+	if err == nil {
+		*n = v
+	}
+	return err
+}
+
+// MarshalJSON encodes the version as a JSON string.
+func (n NuGet) MarshalJSON() ([]byte, error) {
+	return marshalTextJSON(n)
+}
+
+// UnmarshalJSON decodes a JSON string with UnmarshalText. null leaves n
+// unchanged.
+func (n *NuGet) UnmarshalJSON(arr []byte) error {
+	return unmarshalTextJSON(n, "NuGet version", arr)
+}
+
+// MarshalYAML encodes the version as a YAML scalar.
+func (n NuGet) MarshalYAML() (interface{}, error) {
+	return marshalTextYAML(n)
+}
+
+// UnmarshalYAML decodes a YAML scalar with UnmarshalText.
+func (n *NuGet) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalTextYAML(n, unmarshal)
+}
+
+// UnmarshalTOML decodes a TOML string with UnmarshalText.
+func (n *NuGet) UnmarshalTOML(data interface{}) error {
+	return unmarshalTextTOML(n, "NuGet version", data)
+}
+
+// MarshalBSONValue encodes the version as a BSON string.
+func (n NuGet) MarshalBSONValue() (byte, []byte, error) {
+	return marshalTextBSON(n)
+}
+
+// UnmarshalBSONValue decodes a BSON string with UnmarshalText.
+func (n *NuGet) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalTextBSON(n, "NuGet version", typ, data)
+}
+
+// MarshalMsgpack encodes the version as a MessagePack string.
+func (n NuGet) MarshalMsgpack() ([]byte, error) {
+	return marshalTextMsgpack(n)
+}
+
+// UnmarshalMsgpack decodes a MessagePack string with UnmarshalText.
+func (n *NuGet) UnmarshalMsgpack(data []byte) error {
+	return unmarshalTextMsgpack(n, "NuGet version", data)
+}
+
+// MarshalCBOR encodes the version as a CBOR text string.
+func (n NuGet) MarshalCBOR() ([]byte, error) {
+	return marshalTextCBOR(n)
+}
+
+// UnmarshalCBOR decodes a CBOR text string with UnmarshalText.
+func (n *NuGet) UnmarshalCBOR(data []byte) error {
+	return unmarshalTextCBOR(n, data)
+}
+
+// MarshalBinary encodes the version as its String form, so that
+// encoding/gob keeps the revision.
+func (n NuGet) MarshalBinary() ([]byte, error) {
+	return n.MarshalText()
+}
+
+// UnmarshalBinary decodes the output of MarshalBinary.
+func (n *NuGet) UnmarshalBinary(data []byte) error {
+	return n.UnmarshalText(data)
+}
+
+// Value stores the version as TEXT.
+func (n NuGet) Value() (driver.Value, error) {
+	return n.String(), nil
+}
+
+// Scan reads a version from a TEXT column.
+func (n *NuGet) Scan(src interface{}) error {
+	return scanText(n, "NuGet", src)
+}
+
+// MarshalGQL writes the version as a GraphQL string.
+func (n NuGet) MarshalGQL(w io.Writer) {
+	marshalTextGQL(n, w)
+}
+
+// UnmarshalGQL parses a custom scalar input value, which must be a string.
+func (n *NuGet) UnmarshalGQL(v interface{}) error {
+	return unmarshalTextGQL(n, "NuGet", v)
+}
+
+// Set parses s with UnmarshalText, for flag.Value.
+func (n *NuGet) Set(s string) error {
+	return n.UnmarshalText([]byte(s))
+}
+
+// Format implements fmt.Formatter, so that %v and %s print the String form,
+// including the revision, rather than the embedded Semver. The other verbs
+// are as for Semver.
+func (n NuGet) Format(f fmt.State, verb rune) {
+	formatEmbedded(f, verb, n.String(), n.GoString(), n.Semver)
+}
+
+// GoString formats the version as a Go struct literal, which is what %#v
+// prints.
+func (n NuGet) GoString() string {
+	return fmt.Sprintf("semver.NuGet{Semver:%#v, Revision:%d}", n.Semver, n.Revision)
+}
+
+// Cmp compares two NuGet versions as NuGet does: numerically by component,
+// including the revision, then by prerelease with the semver rules, except
+// that alphanumeric identifiers are compared case-insensitively, so
+// 1.0.0-BETA equals 1.0.0-beta. Build metadata is ignored.
+func (a NuGet) Cmp(b NuGet) int {
+	ca, cb := a.Semver, b.Semver
+	ca.Prerelease, cb.Prerelease = "", ""
+	if c := ca.Cmp(cb); c != 0 {
+		return c
+	}
+	if a.Revision != b.Revision {
+		return sign(a.Revision - b.Revision)
+	}
+	return comparePrerelease(strings.ToLower(a.Prerelease), strings.ToLower(b.Prerelease))
+}
+
+// NormalizeNuGet parses s with ParseNuGet and returns it Normalized.
+func NormalizeNuGet(s string) (string, error) {
+	n, err := ParseNuGet(s)
+	if err != nil {
+		return "", err
+	}
+	return n.Normalized(), nil
+}
+
+// ToNuGet returns v as a NuGet version with no revision.
+func (v Semver) ToNuGet() NuGet {
+	return NuGet{Semver: v}
+}
+
+// FromNuGet parses a NuGet version into a Semver. A non-zero revision has no
+// semver equivalent and is an error; a zero revision, as in 1.2.3.0, is
+// dropped, as NuGet's normalization does.
+func FromNuGet(s string) (Semver, error) {
+	n, err := ParseNuGet(s)
+	if err != nil {
+		return Semver{}, err
+	}
+	if n.Revision != 0 {
+		return Semver{}, fmt.Errorf("Cannot convert NuGet version %s: revision %d has no semver equivalent", s, n.Revision)
+	}
+	return n.Semver, nil
+}
@@ -0,0 +1,112 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizeNuGet(t *testing.T) {
+	tests := []struct {
+		given, exp string
+		reason     string
+	}{
+		{"1", "1.0.0", "major only"},
+		{"1.0", "1.0.0", "two components"},
+		{"1.0.0.0", "1.0.0", "zero revision"},
+		{"1.2.3.4", "1.2.3.4", "revision"},
+		{"01.002.0003.0", "1.2.3", "leading zeros"},
+		{"1.2.3.4-Beta.1", "1.2.3.4-Beta.1", "revision and prerelease"},
+		{"1.0.0-rc.1+sha.abc", "1.0.0-rc.1", "metadata dropped"},
+	}
+
+	for _, test := range tests {
+		s, err := NormalizeNuGet(test.given)
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+		} else if s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+		}
+	}
+
+	for _, s := range []string{"", "v1.0.0", "1.2.3.4.5", "1.0.0-", "1.0.0-a_b", "1..0"} {
+		if n, err := NormalizeNuGet(s); err == nil {
+			t.Errorf("%q: expected error, returned: %s", s, n)
+		}
+	}
+
+	n, _ := ParseNuGet("1.2.3.4-rc+abc")
+	if s := n.String(); s != "1.2.3.4-rc+abc" {
+		t.Errorf("String() = %s", s)
+	}
+}
+
+func TestNuGetCmp(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-Alpha.2", "1.0.0-BETA", "1.0.0", "1.0.0.1-rc", "1.0.0.1", "1.0.0.10", "1.0.1"}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := ParseNuGet(ordered[i-1])
+		b, _ := ParseNuGet(ordered[i])
+		if a.Cmp(b) >= 0 || b.Cmp(a) <= 0 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+
+	equal := [][2]string{{"1.0.0-beta", "1.0.0-BETA"}, {"1.0", "1.0.0.0"}, {"1.0.0+a", "1.0.0+b"}}
+	for _, pair := range equal {
+		a, _ := ParseNuGet(pair[0])
+		b, _ := ParseNuGet(pair[1])
+		if c := a.Cmp(b); c != 0 {
+			t.Errorf("%s vs %s: %d, expected 0", pair[0], pair[1], c)
+		}
+	}
+}
+
+func TestFromNuGet(t *testing.T) {
+	good := []goodParseTest{
+		{"1.2", Semver{Major: 1, Minor: 2}, "two components"},
+		{"1.2.3.0-rc.1+abc", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Build: "abc"}, "zero revision"},
+	}
+
+	for _, test := range good {
+		v, err := FromNuGet(test.given)
+		if err != nil {
+			t.Errorf("%s: %s; given: %s", test.reason, err, test.given)
+		} else if v != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, v, test.exp)
+		}
+		if n := v.ToNuGet(); n.Semver != v || n.Revision != 0 {
+			t.Errorf("%s: ToNuGet gave %+v", test.reason, n)
+		}
+	}
+
+	if v, err := FromNuGet("1.2.3.4"); err == nil {
+		t.Errorf("revision: expected error, returned: %+v", v)
+	}
+}
+
+func TestNuGetEncoding(t *testing.T) {
+	for _, s := range []string{"1.2.3", "1.2.3.4", "1.2.3.4-beta.1+abc", "1.0.0-rc.1"} {
+		n, err := ParseNuGet(s)
+		if err != nil {
+			t.Errorf("%s: error parsing: %s", s, err)
+			continue
+		}
+		testEmbeddedEncodings(t, n, s, func() embeddedDecoder { return new(NuGet) })
+	}
+
+	// the revision survives a round trip through a struct field
+	type pkg struct {
+		Version NuGet `json:"version"`
+	}
+	n, _ := ParseNuGet("1.2.3.4")
+	b, err := json.Marshal(pkg{n})
+	if err != nil || string(b) != `{"version":"1.2.3.4"}` {
+		t.Errorf("json.Marshal: %s, %v", b, err)
+	}
+	var p pkg
+	if err := json.Unmarshal(b, &p); err != nil || p.Version.Revision != 4 || p.Version.Semver != MustParse("1.2.3") {
+		t.Errorf("json.Unmarshal: %+v, %v", p, err)
+	}
+	if err := p.Version.UnmarshalText([]byte("1.2.3.4.5")); err == nil {
+		t.Errorf("expected error, got %+v", p.Version)
+	}
+}
@@ -0,0 +1,64 @@
+package semver
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+)
+
+var (
+	_ json.Marshaler           = NullSemver{}
+	_ json.Unmarshaler         = (*NullSemver)(nil)
+	_ encoding.TextMarshaler   = NullSemver{}
+	_ encoding.TextUnmarshaler = (*NullSemver)(nil)
+)
+
+// MarshalJSON encodes the version as a JSON string, or null if it isn't
+// Valid.
+func (n NullSemver) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Semver.MarshalJSON()
+}
+
+// UnmarshalJSON decodes null as an absent version, and anything else as
+// Semver.UnmarshalJSON does. Unlike a *Semver field, which encoding/json
+// sets to nil for null, the Semver of an absent NullSemver is always reset
+// to 0.0.0 so it can't carry over a stale value.
+func (n *NullSemver) UnmarshalJSON(arr []byte) error {
+	if string(bytes.TrimSpace(arr)) == "null" {
+		n.Semver, n.Valid = Semver{}, false
+		return nil
+	}
+	var v Semver
+	if err := v.UnmarshalJSON(arr); err != nil {
+		return err
+	}
+	n.Semver, n.Valid = v, true
+	return nil
+}
+
+// MarshalText encodes the version as its String form, or an empty string if
+// it isn't Valid.
+func (n NullSemver) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.Semver.MarshalText()
+}
+
+// UnmarshalText decodes an empty string as an absent version, and anything
+// else with Parse.
+func (n *NullSemver) UnmarshalText(arr []byte) error {
+	if len(arr) == 0 {
+		n.Semver, n.Valid = Semver{}, false
+		return nil
+	}
+	var v Semver
+	if err := v.UnmarshalText(arr); err != nil {
+		return err
+	}
+	n.Semver, n.Valid = v, true
+	return nil
+}
@@ -0,0 +1,71 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type nullJsonTest struct {
+	given  string
+	exp    NullSemver
+	reason string
+}
+
+func TestNullSemverJson(t *testing.T) {
+	tests := []nullJsonTest{
+		{`null`, NullSemver{}, "null"},
+		{` null `, NullSemver{}, "null with whitespace"},
+		{`"0.0.0"`, NullSemver{Semver{}, true}, "0.0.0 is not absent"},
+		{`"1.2.3-rc.1"`, NullSemver{MustParse("1.2.3-rc.1"), true}, "string"},
+		{`{"major": 1, "minor": 2, "patch": 3}`, NullSemver{MustParse("1.2.3"), true}, "object"},
+	}
+
+	for _, test := range tests {
+		n := NullSemver{MustParse("9.9.9"), true}
+		if err := json.Unmarshal([]byte(test.given), &n); err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+		} else if n != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, n, test.exp)
+		}
+	}
+
+	n := NullSemver{MustParse("9.9.9"), true}
+	if err := json.Unmarshal([]byte(`"1.2"`), &n); err == nil {
+		t.Errorf("expected error for an invalid version")
+	} else if n != (NullSemver{MustParse("9.9.9"), true}) {
+		t.Errorf("should be unchanged on error: %+v", n)
+	}
+
+	// as optional fields
+	type release struct {
+		Min NullSemver `json:"min"`
+		Max NullSemver `json:"max"`
+	}
+	b, err := json.Marshal(release{Min: NullSemver{Semver{}, true}})
+	if err != nil || string(b) != `{"min":"0.0.0","max":null}` {
+		t.Errorf("unexpected encoding: %s, %v", b, err)
+	}
+	var r release
+	if err := json.Unmarshal([]byte(`{"min":"0.0.0"}`), &r); err != nil || !r.Min.Valid || r.Max.Valid {
+		t.Errorf("unexpected decoding: %+v, %v", r, err)
+	}
+}
+
+func TestNullSemverText(t *testing.T) {
+	var n NullSemver
+	if b, err := n.MarshalText(); err != nil || len(b) != 0 {
+		t.Errorf("absent: %q, %v", b, err)
+	}
+	if err := n.UnmarshalText([]byte("1.2.3")); err != nil || n != (NullSemver{MustParse("1.2.3"), true}) {
+		t.Errorf("present: %+v, %v", n, err)
+	}
+	if b, err := n.MarshalText(); err != nil || string(b) != "1.2.3" {
+		t.Errorf("present: %q, %v", b, err)
+	}
+	if err := n.UnmarshalText(nil); err != nil || n.Valid {
+		t.Errorf("empty: %+v, %v", n, err)
+	}
+	if err := n.UnmarshalText([]byte("bogus")); err == nil {
+		t.Errorf("expected error for an invalid version")
+	}
+}
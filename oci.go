@@ -0,0 +1,45 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ociTagReg is the tag grammar of the OCI distribution spec.
+var ociTagReg = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+
+// ValidateOCITag checks tag against the OCI distribution spec's grammar:
+// up to 128 letters, digits, underscores, dots and hyphens, not starting
+// with a dot or hyphen.
+func ValidateOCITag(tag string) error {
+	if !ociTagReg.MatchString(tag) {
+		if len(tag) > 128 {
+			return fmt.Errorf("Invalid OCI tag: longer than 128 characters: %s", tag)
+		}
+		return fmt.Errorf("Invalid OCI tag: %q", tag)
+	}
+	return nil
+}
+
+// ToOCITag returns v as a container image tag. Tags can't contain +, so the
+// + before build metadata becomes _, which semver never uses, making the
+// mapping reversible with FromOCITag: 1.2.3-rc.1+build.5 is tagged
+// 1.2.3-rc.1_build.5. An error is returned if the tag would be longer than
+// the 128 characters OCI allows.
+func (v Semver) ToOCITag() (string, error) {
+	tag := strings.Replace(v.String(), "+", "_", 1)
+	if err := ValidateOCITag(tag); err != nil {
+		return "", fmt.Errorf("Cannot convert %s to an OCI tag: %s", v, err)
+	}
+	return tag, nil
+}
+
+// FromOCITag parses a tag made by ToOCITag, or a plain version tag such as
+// v1.2.3, turning the _ back into +.
+func FromOCITag(tag string) (Semver, error) {
+	if err := ValidateOCITag(tag); err != nil {
+		return Semver{}, err
+	}
+	return Parse(strings.Replace(tag, "_", "+", 1))
+}
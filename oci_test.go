@@ -0,0 +1,69 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToOCITag(t *testing.T) {
+	tests := []struct {
+		given, exp string
+		reason     string
+	}{
+		{"1.2.3", "1.2.3", "release"},
+		{"v1.2.3-rc.1", "1.2.3-rc.1", "prerelease"},
+		{"1.2.3+build.5", "1.2.3_build.5", "build"},
+		{"1.2.3-rc.1+sha-abc.5", "1.2.3-rc.1_sha-abc.5", "prerelease and build"},
+	}
+
+	for _, test := range tests {
+		v := MustParse(test.given)
+		tag, err := v.ToOCITag()
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+			continue
+		} else if tag != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, tag, test.exp)
+		}
+		if back, err := FromOCITag(tag); err != nil || back != v {
+			t.Errorf("%s: round trip gave %+v, %v", test.reason, back, err)
+		}
+	}
+
+	long := Semver{Major: 1, Build: strings.Repeat("a", 128)}
+	if tag, err := long.ToOCITag(); err == nil {
+		t.Errorf("long build: expected error, returned: %s", tag)
+	}
+}
+
+func TestFromOCITag(t *testing.T) {
+	if v, err := FromOCITag("v2.0.0"); err != nil || v != (Semver{Major: 2}) {
+		t.Errorf("v prefix: %+v, %v", v, err)
+	}
+	bad := []badParseTest{
+		{"latest", "not a version"},
+		{"1.2.3+build", "plus sign"},
+		{"1.2.3_a_b", "two underscores"},
+		{"-1.2.3", "leading hyphen"},
+		{"", "empty"},
+	}
+
+	for _, test := range bad {
+		if v, err := FromOCITag(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, v)
+		}
+	}
+}
+
+func TestValidateOCITag(t *testing.T) {
+	for _, tag := range []string{"latest", "_", "v1.2.3_build", "A-b.C_d", strings.Repeat("a", 128)} {
+		if err := ValidateOCITag(tag); err != nil {
+			t.Errorf("%q: %s", tag, err)
+		}
+	}
+	for _, tag := range []string{"", ".hidden", "-x", "a+b", "a/b", "a:b", strings.Repeat("a", 129)} {
+		if err := ValidateOCITag(tag); err == nil {
+			t.Errorf("%q: expected error", tag)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// A ParseOption configures how ParseWith and ParseTag interpret their input.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	prefixes   []string
+	whitespace CharPolicy
+	bom        CharPolicy
+	lookalikes CharPolicy
+	lenient    bool
+}
+
+func newParseOptions(opts []ParseOption) *parseOptions {
+	o := new(parseOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithPrefixes strips the first matching prefix, such as "release-", "ver" or
+// a monorepo path like "tools/gopls/", before parsing. Longer prefixes are
+// tried first, so "version-" wins over "ver". A leading v after the prefix is
+// still accepted.
+func WithPrefixes(prefixes ...string) ParseOption {
+	return func(o *parseOptions) {
+		o.prefixes = append(o.prefixes, prefixes...)
+		sort.SliceStable(o.prefixes, func(i, j int) bool {
+			return len(o.prefixes[i]) > len(o.prefixes[j])
+		})
+	}
+}
+
+// A CharPolicy says what to do with characters that commonly sneak into
+// versions copied out of spreadsheets and web pages.
+type CharPolicy int
+
+const (
+	// Reject fails with an error naming the offending character. This is
+	// the default for every class of character.
+	Reject CharPolicy = iota
+	// Trim removes the characters from both ends of the input.
+	Trim
+	// Normalize replaces the characters with their ASCII equivalents, or
+	// removes them where there is none.
+	Normalize
+)
+
+// WithWhitespace sets the policy for leading and trailing whitespace,
+// including non-breaking spaces. Normalize behaves like Trim.
+func WithWhitespace(p CharPolicy) ParseOption {
+	return func(o *parseOptions) { o.whitespace = p }
+}
+
+// WithBOM sets the policy for byte order marks and zero-width characters.
+// Normalize removes them from anywhere in the input.
+func WithBOM(p CharPolicy) ParseOption {
+	return func(o *parseOptions) { o.bom = p }
+}
+
+// WithLookalikes sets the policy for non-ASCII characters that look like the
+// ones used in versions, such as U+2010 HYPHEN or FULLWIDTH DIGIT ONE.
+// Lookalikes never appear at the ends of a version, so Trim behaves like
+// Reject.
+func WithLookalikes(p CharPolicy) ParseOption {
+	return func(o *parseOptions) { o.lookalikes = p }
+}
+
+// zeroWidth reports whether r is a byte order mark or an invisible
+// zero-width character.
+func zeroWidth(r rune) bool {
+	switch r {
+	case '\uFEFF', '\u200B', '\u200C', '\u200D', '\u2060':
+		return true
+	}
+	return false
+}
+
+// lookalike returns the ASCII character r is commonly mistaken for, or 0.
+func lookalike(r rune) rune {
+	switch {
+	case r >= '\uFF01' && r <= '\uFF5E':
+		// fullwidth forms of printable ASCII
+		return r - '\uFF01' + '!'
+	case r >= '\u2010' && r <= '\u2015', r == '\u2212', r == '\uFE63':
+		return '-'
+	case r == '\u2024', r == '\u3002':
+		return '.'
+	case r == '\uFE62':
+		return '+'
+	}
+	return 0
+}
+
+// clean applies the character policies to s.
+func (o *parseOptions) clean(s string) (string, error) {
+	s = strings.TrimFunc(s, func(r rune) bool {
+		return o.whitespace != Reject && unicode.IsSpace(r) || o.bom != Reject && zeroWidth(r)
+	})
+	if o.bom == Normalize {
+		s = strings.Map(func(r rune) rune {
+			if zeroWidth(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	if o.lookalikes == Normalize {
+		s = strings.Map(func(r rune) rune {
+			if a := lookalike(r); a != 0 {
+				return a
+			}
+			return r
+		}, s)
+	}
+
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			return s, fmt.Errorf("Invalid semver string: %q contains whitespace", s)
+		case zeroWidth(r):
+			return s, fmt.Errorf("Invalid semver string: %q contains invisible character %U", s, r)
+		case lookalike(r) != 0:
+			return s, fmt.Errorf("Invalid semver string: %q contains %U, a lookalike of %q", s, r, lookalike(r))
+		}
+	}
+	return s, nil
+}
+
+// parse applies the character policies, strips the longest configured prefix
+// and parses what remains.
+func (o *parseOptions) parse(s string) (prefix, rest string, v Semver, err error) {
+	if s, err = o.clean(s); err != nil {
+		return
+	}
+	rest = s
+	for _, p := range o.prefixes {
+		if strings.HasPrefix(s, p) {
+			prefix, rest = p, s[len(p):]
+			break
+		}
+	}
+	if o.lenient {
+		v, err = Coerce(rest)
+	} else {
+		v, err = Parse(rest)
+	}
+	return
+}
+
+// ParseWith parses semver like Parse, after applying opts.
+func ParseWith(semver string, opts ...ParseOption) (Semver, error) {
+	_, _, v, err := newParseOptions(opts).parse(semver)
+	return v, err
+}
@@ -0,0 +1,54 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+type policyTest struct {
+	given  string
+	opts   []ParseOption
+	exp    Semver
+	reason string
+}
+
+func TestParseWithPolicies(t *testing.T) {
+	v123 := Semver{Major: 1, Minor: 2, Patch: 3}
+	good := []policyTest{
+		{" 1.2.3\t", []ParseOption{WithWhitespace(Trim)}, v123, "trimmed whitespace"},
+		{"\u00A01.2.3\n", []ParseOption{WithWhitespace(Normalize)}, v123, "non-breaking space"},
+		{"\uFEFF1.2.3", []ParseOption{WithBOM(Trim)}, v123, "trimmed BOM"},
+		{"\uFEFF 1.2.3 ", []ParseOption{WithBOM(Trim), WithWhitespace(Trim)}, v123, "trimmed BOM and whitespace"},
+		{"1.2\u200B.3", []ParseOption{WithBOM(Normalize)}, v123, "removed zero-width space"},
+		{"1.2.3\u2010rc.1", []ParseOption{WithLookalikes(Normalize)}, Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, "unicode hyphen"},
+		{"\uFF11.\uFF12.\uFF13", []ParseOption{WithLookalikes(Normalize)}, v123, "fullwidth digits"},
+		{" release\u20131.2.3", []ParseOption{WithWhitespace(Trim), WithLookalikes(Normalize), WithPrefixes("release-")}, v123, "normalized before prefix stripping"},
+	}
+
+	for _, test := range good {
+		v, err := ParseWith(test.given, test.opts...)
+		if err != nil {
+			t.Errorf("%s: error parsing: %s; given: %q", test.reason, err, test.given)
+		} else if v != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, v, test.exp)
+		}
+	}
+
+	bad := []policyTest{
+		{" 1.2.3", nil, Semver{}, "whitespace"},
+		{"\uFEFF1.2.3", nil, Semver{}, "BOM"},
+		{"1.2.3\u2010rc.1", nil, Semver{}, "lookalike"},
+		{"1.2.3\u2010rc.1", []ParseOption{WithLookalikes(Trim)}, Semver{}, "lookalikes can't be trimmed"},
+		{"1.2\u200B.3", []ParseOption{WithBOM(Trim)}, Semver{}, "zero-width character inside"},
+		{"1.2. 3", []ParseOption{WithWhitespace(Trim)}, Semver{}, "whitespace inside"},
+	}
+
+	for _, test := range bad {
+		v, err := ParseWith(test.given, test.opts...)
+		if err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, v)
+		} else if !strings.Contains(err.Error(), "contains") {
+			t.Errorf("%s: error doesn't describe the character: %s", test.reason, err)
+		}
+	}
+}
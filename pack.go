@@ -0,0 +1,38 @@
+package semver
+
+import "fmt"
+
+// packBits is the width of each of major, minor and patch in a packed version.
+const packBits = 21
+
+const packMax = 1<<packBits - 1
+
+// Pack encodes the core version as a uint64, with major, minor and patch in
+// successive 21-bit fields, so that packed versions sort numerically in
+// precedence order. Build metadata is dropped, as it doesn't affect precedence.
+//
+// Versions with a prerelease can't be packed, nor can versions with a
+// component larger than 2097151.
+func (v Semver) Pack() (uint64, error) {
+	if v.Prerelease != "" {
+		return 0, fmt.Errorf("Cannot pack %s: prereleases aren't supported", v)
+	}
+	for _, n := range []int{v.Major, v.Minor, v.Patch} {
+		if n < 0 || n > packMax {
+			return 0, fmt.Errorf("Cannot pack %s: %d doesn't fit in %d bits", v, n, packBits)
+		}
+	}
+	return uint64(v.Major)<<(2*packBits) | uint64(v.Minor)<<packBits | uint64(v.Patch), nil
+}
+
+// Unpack decodes a version packed by Pack.
+func Unpack(u uint64) (Semver, error) {
+	if u>>(3*packBits) != 0 {
+		return Semver{}, fmt.Errorf("Invalid packed version: %#x", u)
+	}
+	return Semver{
+		Major: int(u >> (2 * packBits) & packMax),
+		Minor: int(u >> packBits & packMax),
+		Patch: int(u & packMax),
+	}, nil
+}
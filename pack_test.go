@@ -0,0 +1,35 @@
+package semver
+
+import "testing"
+
+func TestPack(t *testing.T) {
+	ordered := []string{"0.0.0", "0.0.1", "0.1.0", "0.2097151.2097151", "1.0.0", "1.2.3+build", "1.2.4", "2097151.2097151.2097151"}
+	var last uint64
+	for i, s := range ordered {
+		v := MustParse(s)
+		u, err := v.Pack()
+		if err != nil {
+			t.Errorf("%s: %s", s, err)
+			continue
+		}
+		if i > 0 && u <= last {
+			t.Errorf("%s: packed %#x doesn't sort after %#x", s, u, last)
+		}
+		last = u
+
+		w, err := Unpack(u)
+		v.Build = ""
+		if err != nil || w != v {
+			t.Errorf("%s: unpacked %+v, %v", s, w, err)
+		}
+	}
+
+	for _, s := range []string{"1.2.3-rc.1", "2097152.0.0", "0.0.2097152"} {
+		if u, err := MustParse(s).Pack(); err == nil {
+			t.Errorf("%s: expected error, got %#x", s, u)
+		}
+	}
+	if v, err := Unpack(1 << 63); err == nil {
+		t.Errorf("expected error, got %s", v)
+	}
+}
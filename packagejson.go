@@ -0,0 +1,138 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PackageVersion returns the version field of a package.json document.
+func PackageVersion(data []byte) (Semver, error) {
+	var pkg struct {
+		Version *string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return Semver{}, fmt.Errorf("Invalid package.json: %s", err)
+	}
+	if pkg.Version == nil {
+		return Semver{}, fmt.Errorf("Invalid package.json: no version")
+	}
+	return Parse(*pkg.Version)
+}
+
+// SetPackageVersion returns a copy of the package.json document data with
+// its version field set to v. Only the version is rewritten, so formatting,
+// key order and everything else is left as it was. A missing version is
+// added as the first field.
+func SetPackageVersion(data []byte, v Semver) ([]byte, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	value := strconv.Quote(v.String())
+	start, end, first, err := topLevelField(data, "version")
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	switch {
+	case start >= 0:
+		out.Write(data[:start])
+		out.WriteString(value)
+		out.Write(data[end:])
+	case data[first] == '}':
+		out.Write(data[:first])
+		out.WriteString(`"version": ` + value)
+		out.Write(data[first:])
+	default:
+		// insert it before the first field, copying the whitespace in front
+		// of that field so the indentation matches
+		indent := data[bytes.IndexByte(data, '{')+1 : first]
+		out.Write(data[:first])
+		out.WriteString(`"version": ` + value + ",")
+		out.Write(indent)
+		out.Write(data[first:])
+	}
+	return out.Bytes(), nil
+}
+
+// topLevelField finds the value of the named field of the JSON object data,
+// returning its byte offsets, or -1 if it has none, along with the offset of
+// the first field, or of the closing brace if there are no fields. If the
+// field is repeated the last one wins, as it does for json.Unmarshal.
+func topLevelField(data []byte, name string) (start, end, first int, err error) {
+	invalid := func(err error) (int, int, int, error) {
+		return -1, -1, -1, fmt.Errorf("Invalid package.json: %s", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return invalid(err)
+	} else if tok != json.Delim('{') {
+		return invalid(fmt.Errorf("not an object"))
+	}
+	// InputOffset is the end of the opening brace; the first field or the
+	// closing brace follows any whitespace
+	offset := int(dec.InputOffset())
+	first = len(data) - len(bytes.TrimLeft(data[offset:], " \t\r\n"))
+
+	start, end = -1, -1
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return invalid(err)
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return invalid(err)
+		}
+		if tok == name {
+			end = int(dec.InputOffset())
+			start = end - len(raw)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return invalid(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return invalid(fmt.Errorf("unexpected data after the object"))
+	}
+	return start, end, first, nil
+}
+
+// PackageEngines returns the engines field of a package.json document, such
+// as {"node": ">=18"}, with each range parsed by ParseConstraint. As in npm,
+// prerelease versions of an engine satisfy any range they fall in.
+func PackageEngines(data []byte) (map[string]Constraint, error) {
+	var pkg struct {
+		Engines map[string]string `json:"engines"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("Invalid package.json: %s", err)
+	}
+	engines := make(map[string]Constraint, len(pkg.Engines))
+	for name, r := range pkg.Engines {
+		c, err := ParseConstraint(r, IncludePrerelease())
+		if err != nil {
+			return nil, fmt.Errorf("Invalid package.json: engines.%s: %s", name, err)
+		}
+		engines[name] = c
+	}
+	return engines, nil
+}
+
+// CheckEngine checks that version v of the engine, such as node or npm,
+// satisfies the range the package.json document data gives for it, as npm
+// does when installing. A package that doesn't name the engine accepts any
+// version.
+func CheckEngine(data []byte, engine string, v Semver) error {
+	engines, err := PackageEngines(data)
+	if err != nil {
+		return err
+	}
+	if c, ok := engines[engine]; ok && !c.Check(v) {
+		return fmt.Errorf("Unsupported engine: %s %s does not satisfy %s", engine, v, c)
+	}
+	return nil
+}
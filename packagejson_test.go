@@ -0,0 +1,107 @@
+package semver
+
+import "testing"
+
+func TestPackageVersion(t *testing.T) {
+	v, err := PackageVersion([]byte(`{"name": "pkg", "version": "1.2.3-rc.1"}`))
+	if err != nil {
+		t.Fatalf("error reading version: %s", err)
+	}
+	if exp := MustParse("1.2.3-rc.1"); v != exp {
+		t.Errorf("%s != %s", v, exp)
+	}
+
+	bad := []badParseTest{
+		{`{"name": "pkg"}`, "no version"},
+		{`{"version": "1.2"}`, "invalid version"},
+		{`{"version": 1}`, "version isn't a string"},
+		{`["1.2.3"]`, "not an object"},
+		{`{"version": "1.2.3"`, "truncated"},
+	}
+
+	for _, test := range bad {
+		if v, err := PackageVersion([]byte(test.given)); err == nil {
+			t.Errorf("%s: expected error, returned: %s", test.reason, v)
+		}
+	}
+}
+
+func TestSetPackageVersion(t *testing.T) {
+	tests := []struct {
+		given  string
+		exp    string
+		reason string
+	}{
+		{"{\n  \"name\": \"pkg\",\n  \"version\": \"1.0.0\",\n  \"main\": \"index.js\"\n}\n", "{\n  \"name\": \"pkg\",\n  \"version\": \"2.0.0-rc.1\",\n  \"main\": \"index.js\"\n}\n", "formatting kept"},
+		{`{"version":"1.0.0"}`, `{"version":"2.0.0-rc.1"}`, "compact"},
+		{`{"version": "1.0.0", "dependencies": {"version": "1.0.0"}}`, `{"version": "2.0.0-rc.1", "dependencies": {"version": "1.0.0"}}`, "nested version untouched"},
+		{"{\n  \"name\": \"pkg\"\n}", "{\n  \"version\": \"2.0.0-rc.1\",\n  \"name\": \"pkg\"\n}", "version added"},
+		{`{}`, `{"version": "2.0.0-rc.1"}`, "empty object"},
+		{`{"version": null}`, `{"version": "2.0.0-rc.1"}`, "null version"},
+	}
+
+	v := MustParse("2.0.0-rc.1")
+	for _, test := range tests {
+		out, err := SetPackageVersion([]byte(test.given), v)
+		if err != nil {
+			t.Errorf("%s: error setting version: %s", test.reason, err)
+			continue
+		}
+		if string(out) != test.exp {
+			t.Errorf("%s: %q != %q", test.reason, out, test.exp)
+			continue
+		}
+		if got, err := PackageVersion(out); err != nil || got != v {
+			t.Errorf("%s: read back %s, %v", test.reason, got, err)
+		}
+	}
+
+	bad := []badParseTest{
+		{`"1.0.0"`, "not an object"},
+		{`{"version": "1.0.0"`, "truncated"},
+		{`{"version": "1.0.0"} {}`, "trailing data"},
+	}
+
+	for _, test := range bad {
+		if out, err := SetPackageVersion([]byte(test.given), v); err == nil {
+			t.Errorf("%s: expected error, returned: %s", test.reason, out)
+		}
+	}
+}
+
+func TestCheckEngine(t *testing.T) {
+	pkg := []byte(`{"name": "pkg", "engines": {"node": ">=18.17 <21 || ^22", "npm": "^9 || ^10"}}`)
+	tests := []struct {
+		engine  string
+		version string
+		ok      bool
+		reason  string
+	}{
+		{"node", "18.17.0", true, "lower bound"},
+		{"node", "20.11.1", true, "inside the first range"},
+		{"node", "22.1.0", true, "second range"},
+		{"node", "22.0.0-nightly.1", false, "below the second range"},
+		{"node", "20.0.0-rc.1", true, "prereleases count"},
+		{"node", "18.16.1", false, "too old"},
+		{"node", "21.0.0", false, "in the gap"},
+		{"npm", "10.2.4", true, "npm"},
+		{"npm", "8.19.4", false, "old npm"},
+		{"yarn", "1.22.0", true, "engine not named"},
+	}
+
+	for _, test := range tests {
+		err := CheckEngine(pkg, test.engine, MustParse(test.version))
+		if test.ok && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+		} else if !test.ok && err == nil {
+			t.Errorf("%s: expected %s %s to be rejected", test.reason, test.engine, test.version)
+		}
+	}
+
+	if err := CheckEngine([]byte(`{"name": "pkg"}`), "node", MustParse("4.0.0")); err != nil {
+		t.Errorf("no engines: unexpected error: %s", err)
+	}
+	if _, err := PackageEngines([]byte(`{"engines": {"node": ">=1.2.3.4"}}`)); err == nil {
+		t.Errorf("expected an error for an invalid range")
+	}
+}
@@ -0,0 +1,130 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440Reg is the version pattern from PEP 440, matched case-insensitively.
+var pep440Reg = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(\d+)!)?` + // epoch
+	`(\d+(?:\.\d+)*)` + // release
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` + // pre
+	`((?:-\d+)|(?:[-_.]?(?:post|rev|r)[-_.]?\d*))?` + // post
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` + // dev
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?` + // local
+	`\s*$`)
+
+// pep440Labels maps the prerelease labels FromPEP440 produces to their
+// normalized PEP 440 spellings.
+var pep440Labels = map[string]string{"alpha": "a", "beta": "b", "rc": "rc"}
+
+// ToPEP440 returns v as a normalized PEP 440 version, so that a Python
+// package can carry the same version as a Go one. A prerelease of the form
+// FromPEP440 produces, alpha.N, beta.N or rc.N, optionally followed by
+// dev.N, or dev.N alone, maps to aN, bN, rcN and .devN. Build metadata
+// becomes the local version, after a +.
+//
+// Anything that wouldn't convert back to the same version with FromPEP440
+// is an error: other prerelease forms, such as rc without a number, and
+// build metadata with hyphens or capitals, which PEP 440 normalizes.
+//
+// The conversion keeps versions, not their order: PEP 440 puts a dev release
+// before its prerelease and ignores local versions, so 1.0.0-rc.1.dev.2
+// sorts before 1.0.0-rc.1 in Python and after it in semver.
+func (v Semver) ToPEP440() (string, error) {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		ids := strings.Split(v.Prerelease, ".")
+		if label, ok := pep440Labels[ids[0]]; ok && len(ids) >= 2 && isCanonicalNumber(ids[1]) {
+			s += label + ids[1]
+			ids = ids[2:]
+		}
+		if len(ids) == 2 && ids[0] == "dev" && isCanonicalNumber(ids[1]) {
+			s += ".dev" + ids[1]
+			ids = nil
+		}
+		if len(ids) != 0 {
+			return "", fmt.Errorf("Cannot convert %s to PEP 440: prerelease %s has no equivalent", v, v.Prerelease)
+		}
+	}
+	if v.Build != "" {
+		if strings.Contains(v.Build, "-") || strings.ToLower(v.Build) != v.Build {
+			return "", fmt.Errorf("Cannot convert %s to PEP 440: build %s would be normalized to %s", v, v.Build, strings.ToLower(strings.Replace(v.Build, "-", ".", -1)))
+		}
+		s += "+" + v.Build
+	}
+	return s, nil
+}
+
+// FromPEP440 parses a PEP 440 version, in any of the spellings PEP 440
+// allows, into a Semver. Missing minor and patch versions are 0, aN, bN and
+// rcN (or cN) become the prereleases alpha.N, beta.N and rc.N, .devN adds
+// dev.N and the local version becomes build metadata.
+//
+// Versions that semver can't represent are errors: a non-zero epoch, a
+// post-release and more than three release components.
+func FromPEP440(s string) (Semver, error) {
+	m := pep440Reg.FindStringSubmatch(s)
+	if m == nil {
+		return Semver{}, fmt.Errorf("Invalid PEP 440 version: %s", s)
+	}
+	epoch, release, preL, preN, post, dev, devN, local := m[1], m[2], strings.ToLower(m[3]), m[4], m[5], m[6], m[7], m[8]
+	if strings.TrimLeft(epoch, "0") != "" {
+		return Semver{}, fmt.Errorf("Cannot convert PEP 440 version %s: epoch %s has no semver equivalent", s, epoch)
+	}
+	if post != "" {
+		return Semver{}, fmt.Errorf("Cannot convert PEP 440 version %s: post-releases have no semver equivalent", s)
+	}
+
+	var v Semver
+	parts := strings.Split(release, ".")
+	if len(parts) > 3 {
+		return Semver{}, fmt.Errorf("Cannot convert PEP 440 version %s: more than three release components", s)
+	}
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Semver{}, fmt.Errorf("Invalid PEP 440 version %s: %s", s, err)
+		}
+		*nums[i] = n
+	}
+
+	var pre []string
+	if preL != "" {
+		switch preL {
+		case "a", "alpha":
+			preL = "alpha"
+		case "b", "beta":
+			preL = "beta"
+		default:
+			preL = "rc"
+		}
+		pre = append(pre, preL, pep440Number(preN))
+	}
+	if dev != "" {
+		pre = append(pre, "dev", pep440Number(devN))
+	}
+	v.Prerelease = strings.Join(pre, ".")
+	v.Build = strings.NewReplacer("-", ".", "_", ".").Replace(strings.ToLower(local))
+	if err := v.Validate(); err != nil {
+		return Semver{}, fmt.Errorf("Invalid PEP 440 version %s: %s", s, err)
+	}
+	return v, nil
+}
+
+// pep440Number normalizes an implicit or zero-padded number as PEP 440 does.
+func pep440Number(s string) string {
+	if s = strings.TrimLeft(s, "0"); s == "" {
+		return "0"
+	}
+	return s
+}
+
+// isCanonicalNumber reports whether s is a number without leading zeros.
+func isCanonicalNumber(s string) bool {
+	return isDigits(s) && (s == "0" || s[0] != '0')
+}
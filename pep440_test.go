@@ -0,0 +1,93 @@
+package semver
+
+import "testing"
+
+func TestToPEP440(t *testing.T) {
+	tests := []struct {
+		given, exp string
+		reason     string
+	}{
+		{"1.2.3", "1.2.3", "release"},
+		{"1.2.3-alpha.1", "1.2.3a1", "alpha"},
+		{"1.2.3-beta.0", "1.2.3b0", "beta"},
+		{"1.2.3-rc.10", "1.2.3rc10", "rc"},
+		{"1.2.3-dev.4", "1.2.3.dev4", "dev"},
+		{"1.2.3-rc.1.dev.2", "1.2.3rc1.dev2", "rc dev"},
+		{"1.2.3+ubuntu.1", "1.2.3+ubuntu.1", "local"},
+		{"1.2.3-beta.2+cpu", "1.2.3b2+cpu", "prerelease and local"},
+	}
+
+	for _, test := range tests {
+		v := MustParse(test.given)
+		s, err := v.ToPEP440()
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+			continue
+		} else if s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+		}
+		if back, err := FromPEP440(s); err != nil || back != v {
+			t.Errorf("%s: round trip gave %+v, %v", test.reason, back, err)
+		}
+	}
+
+	lossy := []badParseTest{
+		{"1.2.3-rc", "label without a number"},
+		{"1.2.3-a.1", "non-canonical label"},
+		{"1.2.3-RC.1", "capitalized label"},
+		{"1.2.3-rc.01", "zero-padded number"},
+		{"1.2.3-rc.1.2", "extra identifiers"},
+		{"1.2.3-nightly.5", "unknown label"},
+		{"1.2.3-dev.1.rc.2", "dev before rc"},
+		{"1.2.3+build-5", "hyphen in build"},
+		{"1.2.3+Build", "capitals in build"},
+	}
+	for _, test := range lossy {
+		if s, err := MustParse(test.given).ToPEP440(); err == nil {
+			t.Errorf("%s: expected error, returned: %s", test.reason, s)
+		}
+	}
+}
+
+func TestFromPEP440(t *testing.T) {
+	good := []goodParseTest{
+		{"1.2", Semver{Major: 1, Minor: 2}, "two components"},
+		{"v1", Semver{Major: 1}, "leading v, one component"},
+		{"0!1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}, "zero epoch"},
+		{"1.0a1", Semver{Major: 1, Prerelease: "alpha.1"}, "a"},
+		{"1.0-Alpha-1", Semver{Major: 1, Prerelease: "alpha.1"}, "alternative spelling"},
+		{"1.0.beta", Semver{Major: 1, Prerelease: "beta.0"}, "implicit number"},
+		{"1.0c3", Semver{Major: 1, Prerelease: "rc.3"}, "c"},
+		{"1.0preview02", Semver{Major: 1, Prerelease: "rc.2"}, "preview, zero-padded"},
+		{"1.0.dev", Semver{Major: 1, Prerelease: "dev.0"}, "dev"},
+		{"1.0rc1.dev2", Semver{Major: 1, Prerelease: "rc.1.dev.2"}, "rc dev"},
+		{"1.0+Ubuntu-1_2", Semver{Major: 1, Build: "ubuntu.1.2"}, "local"},
+		{" 1.0 ", Semver{Major: 1}, "surrounding whitespace"},
+	}
+
+	for _, test := range good {
+		v, err := FromPEP440(test.given)
+		if err != nil {
+			t.Errorf("%s: %s; given: %s", test.reason, err, test.given)
+		} else if v != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, v, test.exp)
+		}
+	}
+
+	bad := []badParseTest{
+		{"1!1.0", "epoch"},
+		{"1.0.post1", "post"},
+		{"1.0-1", "implicit post"},
+		{"1.0rc1.post2.dev3", "post dev"},
+		{"1.2.3.4", "four components"},
+		{"1.0-gamma", "unknown label"},
+		{"", "empty"},
+		{"1.0+", "empty local"},
+	}
+
+	for _, test := range bad {
+		if v, err := FromPEP440(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, v)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Pin records the exact version chosen for a dependency along with the
+// constraint it was chosen from, as in a lock file.
+type Pin struct {
+	Name       string
+	Version    Semver
+	Constraint Constraint
+}
+
+// Pins is the contents of a pin file, which has one pin per line: a name, a
+// version and the constraint, separated by spaces.
+//
+//	# pins for the plugin manager
+//	left-pad 1.3.0 ^1.2.0
+//	requests 2.31.0 >=2.28 <3
+//
+// Blank lines and lines starting with # are ignored.
+type Pins []Pin
+
+// ReadPins reads a pin file. Names must be unique.
+func ReadPins(r io.Reader) (Pins, error) {
+	var pins Pins
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("Invalid pin file: line %d: expected a name, a version and a constraint", n)
+		}
+		var p Pin
+		var err error
+		p.Name = fields[0]
+		if seen[p.Name] {
+			return nil, fmt.Errorf("Invalid pin file: line %d: %s is pinned twice", n, p.Name)
+		}
+		seen[p.Name] = true
+		if p.Version, err = Parse(fields[1]); err != nil {
+			return nil, fmt.Errorf("Invalid pin file: line %d: %s", n, err)
+		}
+		if p.Constraint, err = ParseConstraint(strings.Join(fields[2:], " ")); err != nil {
+			return nil, fmt.Errorf("Invalid pin file: line %d: %s", n, err)
+		}
+		pins = append(pins, p)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
+
+// WriteTo writes the pins in the format ReadPins reads, in order.
+func (ps Pins) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	for _, p := range ps {
+		c := p.Constraint.String()
+		if c == "" {
+			c = "*"
+		}
+		n, err := fmt.Fprintf(w, "%s %s %s\n", p.Name, p.Version, c)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// Get returns the pin with the given name, or false if there isn't one.
+func (ps Pins) Get(name string) (Pin, bool) {
+	for _, p := range ps {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Pin{}, false
+}
+
+// Verify checks that every pinned version satisfies its constraint, as it
+// may not after a constraint is edited by hand, reporting every pin that
+// doesn't.
+func (ps Pins) Verify() error {
+	var bad []string
+	for _, p := range ps {
+		if !p.Constraint.Check(p.Version) {
+			bad = append(bad, fmt.Sprintf("%s %s does not satisfy %s", p.Name, p.Version, p.Constraint))
+		}
+	}
+	if bad != nil {
+		return fmt.Errorf("%d invalid pins: %s", len(bad), strings.Join(bad, "; "))
+	}
+	return nil
+}
+
+// OutdatedPin is a pin with a newer version its constraint allows.
+type OutdatedPin struct {
+	Pin
+	Latest Semver // the latest version the constraint allows
+}
+
+// Outdated returns the pins, in order, that are older than the latest of the
+// available versions of their dependency that their constraint allows.
+// available maps names to versions; pins it doesn't list aren't outdated.
+func (ps Pins) Outdated(available map[string][]Semver) []OutdatedPin {
+	var out []OutdatedPin
+	for _, p := range ps {
+		latest, ok := Latest(available[p.Name], WithConstraint(p.Constraint))
+		if ok && latest.Cmp(p.Version) > 0 {
+			out = append(out, OutdatedPin{p, latest})
+		}
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package semver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const testPins = `# pins for the plugin manager
+left-pad 1.3.0 ^1.2.0
+
+requests 2.31.0 >=2.28 <3
+tool v0.4.1-rc.1 *
+`
+
+func TestReadPins(t *testing.T) {
+	pins, err := ReadPins(strings.NewReader(testPins))
+	if err != nil {
+		t.Fatalf("error reading pins: %s", err)
+	}
+	if len(pins) != 3 {
+		t.Fatalf("expected 3 pins, got %d", len(pins))
+	}
+	p, ok := pins.Get("requests")
+	if !ok || p.Version != MustParse("2.31.0") || p.Constraint.String() != ">=2.28 <3" {
+		t.Errorf("requests pin %+v, %t", p, ok)
+	}
+	if _, ok := pins.Get("missing"); ok {
+		t.Errorf("unexpected pin for missing")
+	}
+
+	var buf bytes.Buffer
+	if _, err := pins.WriteTo(&buf); err != nil {
+		t.Fatalf("error writing pins: %s", err)
+	}
+	exp := "left-pad 1.3.0 ^1.2.0\nrequests 2.31.0 >=2.28 <3\ntool 0.4.1-rc.1 *\n"
+	if buf.String() != exp {
+		t.Errorf("%q != %q", buf.String(), exp)
+	}
+	if again, err := ReadPins(&buf); err != nil || len(again) != len(pins) {
+		t.Errorf("pins didn't read back: %v, %v", again, err)
+	}
+
+	bad := []badParseTest{
+		{"left-pad 1.3.0", "missing constraint"},
+		{"left-pad 1.3 ^1", "invalid version"},
+		{"left-pad 1.3.0 >=1.2.3.4", "invalid constraint"},
+		{"a 1.0.0 *\na 2.0.0 *", "pinned twice"},
+	}
+
+	for _, test := range bad {
+		if pins, err := ReadPins(strings.NewReader(test.given)); err == nil {
+			t.Errorf("%s: expected error, returned: %v", test.reason, pins)
+		}
+	}
+}
+
+func TestVerifyPins(t *testing.T) {
+	pins, _ := ReadPins(strings.NewReader(testPins))
+	if err := pins.Verify(); err == nil {
+		t.Errorf("expected the prerelease pin to fail verification")
+	} else if exp := "1 invalid pins: tool 0.4.1-rc.1 does not satisfy *"; err.Error() != exp {
+		t.Errorf("%q != %q", err, exp)
+	}
+	if err := pins[:2].Verify(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestOutdatedPins(t *testing.T) {
+	pins, _ := ReadPins(strings.NewReader(testPins))
+	available := map[string][]Semver{
+		"left-pad": {MustParse("1.3.0"), MustParse("1.3.1"), MustParse("2.0.0")},
+		"requests": {MustParse("2.31.0"), MustParse("3.0.0")},
+	}
+	out := pins.Outdated(available)
+	if len(out) != 1 || out[0].Name != "left-pad" || out[0].Latest != MustParse("1.3.1") {
+		t.Errorf("unexpected outdated pins: %+v", out)
+	}
+}
@@ -0,0 +1,240 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	_ encoding.TextMarshaler   = Pseudo{}
+	_ encoding.TextUnmarshaler = (*Pseudo)(nil)
+	_ json.Marshaler           = Pseudo{}
+	_ json.Unmarshaler         = (*Pseudo)(nil)
+)
+
+var pseudoReg = regexp.MustCompile(`^v?[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// pseudoTimeFormat is the UTC timestamp layout embedded in pseudo-versions.
+const pseudoTimeFormat = "20060102150405"
+
+// Pseudo is a Go module pseudo-version, the form the go command uses to refer
+// to untagged commits, e.g. v0.0.0-20240101120000-abcdef123456 or
+// v1.2.4-0.20240101120000-abcdef123456.
+//
+// A pseudo-version is an ordinary semantic version, so comparing the embedded
+// Semver orders pseudo-versions the way the go command does: after the tag they
+// were derived from and before the next tagged release.
+type Pseudo struct {
+	Semver
+
+	// Base is the tagged version the pseudo-version was derived from. It is
+	// the zero Semver for the vX.0.0-yyyymmddhhmmss-abcdefabcdef form, which
+	// the go command uses when there is no earlier tag.
+	Base Semver
+
+	// Time is the commit time, in UTC.
+	Time time.Time
+
+	// Revision is the (usually 12 character) commit hash prefix.
+	Revision string
+}
+
+// IsPseudo reports whether s is a Go module pseudo-version.
+func IsPseudo(s string) bool {
+	return strings.Count(s, "-") >= 2 && pseudoReg.MatchString(s)
+}
+
+// ParsePseudo parses a Go module pseudo-version into its base version,
+// timestamp and revision. A leading v may be included.
+func ParsePseudo(s string) (p Pseudo, err error) {
+	if !IsPseudo(s) {
+		err = fmt.Errorf("Invalid pseudo-version: %s", s)
+		return
+	}
+	if p.Semver, err = Parse(s); err != nil {
+		return
+	}
+
+	// the timestamp and revision follow the last dot, or make up the whole
+	// prerelease in the untagged form
+	pre, rest := "", p.Prerelease
+	if i := strings.LastIndex(rest, "."); i >= 0 {
+		pre, rest = rest[:i], rest[i+1:]
+	}
+	if p.Time, err = time.Parse(pseudoTimeFormat, rest[:len(pseudoTimeFormat)]); err != nil {
+		err = fmt.Errorf("Invalid pseudo-version timestamp: %s", s)
+		return
+	}
+	p.Revision = rest[len(pseudoTimeFormat)+1:]
+
+	switch {
+	case pre == "":
+		// vX.0.0-yyyymmddhhmmss-abcdefabcdef: no base
+	case pre == "0":
+		// vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef: derived from the release vX.Y.Z
+		if p.Patch == 0 {
+			err = fmt.Errorf("Invalid pseudo-version base: %s", s)
+			return
+		}
+		p.Base = Semver{Major: p.Major, Minor: p.Minor, Patch: p.Patch - 1}
+	default:
+		// vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef: derived from the prerelease vX.Y.Z-pre
+		p.Base = Semver{Major: p.Major, Minor: p.Minor, Patch: p.Patch, Prerelease: strings.TrimSuffix(pre, ".0")}
+	}
+	// a pseudo-version of an incompatible module was derived from an
+	// incompatible tag
+	if p.Incompatible() && p.Base != (Semver{}) {
+		p.Base.Build = incompatible
+	}
+	return
+}
+
+// MarshalText encodes the pseudo-version as its String form.
+//
+// Pseudo embeds Semver, so without its own decoders it would inherit
+// Semver's, which fill in only the embedded Semver and accept any version.
+// It implements every encoding that Semver does, each encoding the
+// pseudo-version as a string and decoding it with ParsePseudo.
+func (p Pseudo) MarshalText() ([]byte, error) {
+	return p.Semver.MarshalText()
+}
+
+// UnmarshalText parses a pseudo-version with ParsePseudo, leaving p
+// unchanged on error.
+func (p *Pseudo) UnmarshalText(arr []byte) error {
+	v, err := ParsePseudo(string(arr))
+	if err == nil {
+		*p = v
+	}
+	return err
+}
+
+// MarshalJSON encodes the pseudo-version as a JSON string.
+func (p Pseudo) MarshalJSON() ([]byte, error) {
+	return marshalTextJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON string with UnmarshalText. null leaves p
+// unchanged.
+func (p *Pseudo) UnmarshalJSON(arr []byte) error {
+	return unmarshalTextJSON(p, "pseudo-version", arr)
+}
+
+// MarshalYAML encodes the pseudo-version as a YAML scalar.
+func (p Pseudo) MarshalYAML() (interface{}, error) {
+	return marshalTextYAML(p)
+}
+
+// UnmarshalYAML decodes a YAML scalar with UnmarshalText.
+func (p *Pseudo) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalTextYAML(p, unmarshal)
+}
+
+// UnmarshalTOML decodes a TOML string with UnmarshalText.
+func (p *Pseudo) UnmarshalTOML(data interface{}) error {
+	return unmarshalTextTOML(p, "pseudo-version", data)
+}
+
+// MarshalBSONValue encodes the pseudo-version as a BSON string.
+func (p Pseudo) MarshalBSONValue() (byte, []byte, error) {
+	return marshalTextBSON(p)
+}
+
+// UnmarshalBSONValue decodes a BSON string with UnmarshalText.
+func (p *Pseudo) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalTextBSON(p, "pseudo-version", typ, data)
+}
+
+// MarshalMsgpack encodes the pseudo-version as a MessagePack string.
+func (p Pseudo) MarshalMsgpack() ([]byte, error) {
+	return marshalTextMsgpack(p)
+}
+
+// UnmarshalMsgpack decodes a MessagePack string with UnmarshalText.
+func (p *Pseudo) UnmarshalMsgpack(data []byte) error {
+	return unmarshalTextMsgpack(p, "pseudo-version", data)
+}
+
+// MarshalCBOR encodes the pseudo-version as a CBOR text string.
+func (p Pseudo) MarshalCBOR() ([]byte, error) {
+	return marshalTextCBOR(p)
+}
+
+// UnmarshalCBOR decodes a CBOR text string with UnmarshalText.
+func (p *Pseudo) UnmarshalCBOR(data []byte) error {
+	return unmarshalTextCBOR(p, data)
+}
+
+// MarshalBinary encodes the pseudo-version as its String form, so that
+// encoding/gob decodes it with ParsePseudo.
+func (p Pseudo) MarshalBinary() ([]byte, error) {
+	return p.MarshalText()
+}
+
+// UnmarshalBinary decodes the output of MarshalBinary.
+func (p *Pseudo) UnmarshalBinary(data []byte) error {
+	return p.UnmarshalText(data)
+}
+
+// Value stores the pseudo-version as TEXT.
+func (p Pseudo) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan reads a pseudo-version from a TEXT column.
+func (p *Pseudo) Scan(src interface{}) error {
+	return scanText(p, "Pseudo", src)
+}
+
+// MarshalGQL writes the pseudo-version as a GraphQL string.
+func (p Pseudo) MarshalGQL(w io.Writer) {
+	marshalTextGQL(p, w)
+}
+
+// UnmarshalGQL parses a custom scalar input value, which must be a string.
+func (p *Pseudo) UnmarshalGQL(v interface{}) error {
+	return unmarshalTextGQL(p, "Pseudo", v)
+}
+
+// Set parses s with UnmarshalText, for flag.Value.
+func (p *Pseudo) Set(s string) error {
+	return p.UnmarshalText([]byte(s))
+}
+
+// PseudoVersion returns the pseudo-version the go command would give the
+// commit rev, made at t, whose most recent tagged ancestor is base:
+//
+//	no base (the zero Semver)  v0.0.0-20240101120000-abcdef123456
+//	a release, v1.2.3          v1.2.4-0.20240101120000-abcdef123456
+//	a prerelease, v1.2.4-rc.1  v1.2.4-rc.1.0.20240101120000-abcdef123456
+//
+// The build metadata of base, such as +incompatible, is kept. rev is usually
+// the 12 character prefix of the commit hash; use UntaggedPseudoVersion for
+// modules at v2 or later with no tags.
+func PseudoVersion(base Semver, t time.Time, rev string) string {
+	if base == (Semver{}) {
+		return UntaggedPseudoVersion(0, t, rev)
+	}
+	segment := t.UTC().Format(pseudoTimeFormat) + "-" + rev
+	v := Semver{Major: base.Major, Minor: base.Minor, Patch: base.Patch, Build: base.Build}
+	if base.Prerelease != "" {
+		v.Prerelease = base.Prerelease + ".0." + segment
+	} else {
+		v.Patch++
+		v.Prerelease = "0." + segment
+	}
+	return "v" + v.String()
+}
+
+// UntaggedPseudoVersion returns the pseudo-version of a commit in a module
+// with no tags, such as v2.0.0-20240101120000-abcdef123456 for a module whose
+// path ends in /v2.
+func UntaggedPseudoVersion(major int, t time.Time, rev string) string {
+	return fmt.Sprintf("v%d.0.0-%s-%s", major, t.UTC().Format(pseudoTimeFormat), rev)
+}
@@ -0,0 +1,136 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type pseudoTest struct {
+	given    string
+	base     Semver
+	revision string
+	reason   string
+}
+
+func TestParsePseudo(t *testing.T) {
+	stamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []pseudoTest{
+		{"v0.0.0-20240101120000-abcdef123456", Semver{}, "abcdef123456", "no base"},
+		{"v2.0.0-20240101120000-abcdef123456", Semver{}, "abcdef123456", "no base, major 2"},
+		{"v1.2.4-0.20240101120000-abcdef123456", Semver{Major: 1, Minor: 2, Patch: 3}, "abcdef123456", "release base"},
+		{"v1.2.4-rc.1.0.20240101120000-abcdef123456", Semver{Major: 1, Minor: 2, Patch: 4, Prerelease: "rc.1"}, "abcdef123456", "prerelease base"},
+		{"1.2.4-0.20240101120000-abcdef123456", Semver{Major: 1, Minor: 2, Patch: 3}, "abcdef123456", "no 'v' prefix"},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePseudo(test.given)
+		if err != nil {
+			t.Errorf("%s: error parsing: %s; given: %s", test.reason, err, test.given)
+			continue
+		}
+		if p.Base != test.base {
+			t.Errorf("%s: base %+v != %+v", test.reason, p.Base, test.base)
+		}
+		if !p.Time.Equal(stamp) {
+			t.Errorf("%s: time %s != %s", test.reason, p.Time, stamp)
+		}
+		if p.Revision != test.revision {
+			t.Errorf("%s: revision %s != %s", test.reason, p.Revision, test.revision)
+		}
+	}
+
+	bad := []badParseTest{
+		{"v1.2.3", "tagged release"},
+		{"v1.2.3-rc.1", "tagged prerelease"},
+		{"v1.2.0-0.20240101120000-abcdef123456", "release base with patch underflow"},
+		{"v1.2.3-20240101120000-abcdef123456", "no base with non-zero minor and patch"},
+		{"v0.0.0-20241301120000-abcdef123456", "bad month"},
+		{"v0.0.0-2024010112000-abcdef123456", "short timestamp"},
+	}
+
+	for _, test := range bad {
+		if p, err := ParsePseudo(test.given); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, p)
+		}
+	}
+}
+
+func TestPseudoCmp(t *testing.T) {
+	// in ascending order, the way the go command sorts them
+	ordered := []string{
+		"v0.0.0-20230101120000-abcdef123456",
+		"v0.0.0-20240101120000-abcdef123456",
+		"v1.2.3",
+		"v1.2.4-0.20230101120000-abcdef123456",
+		"v1.2.4-0.20240101120000-abcdef123456",
+		"v1.2.4-rc.1",
+		"v1.2.4-rc.1.0.20240101120000-abcdef123456",
+		"v1.2.4",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a, b := ordered[i-1], ordered[i]
+		if MustParse(a).Cmp(MustParse(b)) >= 0 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+}
+
+func TestPseudoVersion(t *testing.T) {
+	stamp := time.Date(2024, 1, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		base   Semver
+		exp    string
+		reason string
+	}{
+		{Semver{}, "v0.0.0-20240101120000-abcdef123456", "no base"},
+		{Semver{Major: 1, Minor: 2, Patch: 3}, "v1.2.4-0.20240101120000-abcdef123456", "release base"},
+		{Semver{Major: 1, Minor: 2, Patch: 4, Prerelease: "rc.1"}, "v1.2.4-rc.1.0.20240101120000-abcdef123456", "prerelease base"},
+		{Semver{Major: 2, Build: "incompatible"}, "v2.0.1-0.20240101120000-abcdef123456+incompatible", "incompatible base"},
+	}
+
+	for _, test := range tests {
+		s := PseudoVersion(test.base, stamp, "abcdef123456")
+		if s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+			continue
+		}
+		p, err := ParsePseudo(s)
+		if err != nil {
+			t.Errorf("%s: %s doesn't parse: %s", test.reason, s, err)
+		} else if p.Base != test.base || !p.Time.Equal(stamp) || p.Revision != "abcdef123456" {
+			t.Errorf("%s: parsed back as %+v", test.reason, p)
+		}
+	}
+
+	if s := UntaggedPseudoVersion(2, stamp, "abcdef123456"); s != "v2.0.0-20240101120000-abcdef123456" {
+		t.Errorf("untagged v2: %s", s)
+	}
+}
+
+func TestPseudoEncoding(t *testing.T) {
+	for _, s := range []string{"0.0.0-20240101120000-abcdef123456", "1.2.4-0.20240101120000-abcdef123456", "1.2.4-rc.1.0.20240101120000-abcdef123456", "2.0.1-0.20240101120000-abcdef123456+incompatible"} {
+		p, err := ParsePseudo(s)
+		if err != nil {
+			t.Errorf("%s: error parsing: %s", s, err)
+			continue
+		}
+		testEmbeddedEncodings(t, p, s, func() embeddedDecoder { return new(Pseudo) })
+	}
+
+	type module struct {
+		Version Pseudo `json:"version"`
+	}
+	var m module
+	if err := json.Unmarshal([]byte(`{"version":"v1.2.4-0.20240101120000-abcdef123456"}`), &m); err != nil || m.Version.Base != MustParse("1.2.3") || !m.Version.Time.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) || m.Version.Revision != "abcdef123456" {
+		t.Errorf("json.Unmarshal: %+v, %v", m, err)
+	}
+
+	p := m.Version
+	if err := json.Unmarshal([]byte(`"1.2.3"`), &p); err == nil {
+		t.Errorf("json.Unmarshal accepted a version that isn't a pseudo-version: %+v", p)
+	} else if p != m.Version {
+		t.Errorf("json.Unmarshal changed the value on error: %+v", p)
+	}
+}
@@ -0,0 +1,176 @@
+package semver
+
+import "sync"
+
+// Change describes a version being added to or removed from a Registry.
+type Change struct {
+	Version Semver
+	Removed bool
+}
+
+// Registry is a set of versions that is safe for concurrent use, such as the
+// versions of agents a service has seen, with notifications of changes. The
+// zero Registry is empty and ready to use.
+type Registry struct {
+	mu       sync.RWMutex
+	set      Set
+	watchers map[int]func(Change)
+	nextID   int
+	batches  uint64 // batches of changes published to watchers
+
+	// Watchers run without mu held, so that they can read the registry.
+	// Each batch of changes is numbered under mu, and its publisher waits
+	// for its turn, when delivered reaches the batch before it, so watchers
+	// still see changes in order.
+	deliver   sync.Mutex
+	turn      *sync.Cond // on deliver, created under mu
+	delivered uint64
+}
+
+// Add adds versions to the registry and returns how many weren't already
+// present. Watchers are told about each new version before Add returns.
+func (r *Registry) Add(versions ...Semver) int {
+	r.mu.Lock()
+	var changes []Change
+	for _, v := range versions {
+		if r.set.Add(v) {
+			changes = append(changes, Change{Version: v})
+		}
+	}
+	r.publish(changes)
+	return len(changes)
+}
+
+// Remove removes v from the registry, reporting whether it was present.
+func (r *Registry) Remove(v Semver) bool {
+	r.mu.Lock()
+	var changes []Change
+	if r.set.Remove(v) {
+		changes = append(changes, Change{Version: v, Removed: true})
+	}
+	r.publish(changes)
+	return changes != nil
+}
+
+// publish unlocks r.mu and runs the watchers for changes, once the watchers
+// have been told about every earlier change.
+func (r *Registry) publish(changes []Change) {
+	if len(changes) == 0 || len(r.watchers) == 0 {
+		r.mu.Unlock()
+		return
+	}
+	watchers := make([]func(Change), 0, len(r.watchers))
+	for id := 0; id < r.nextID; id++ {
+		if fn, ok := r.watchers[id]; ok {
+			watchers = append(watchers, fn)
+		}
+	}
+	if r.turn == nil {
+		r.turn = sync.NewCond(&r.deliver)
+	}
+	r.batches++
+	batch := r.batches
+	r.mu.Unlock()
+
+	r.deliver.Lock()
+	for r.delivered != batch-1 {
+		r.turn.Wait()
+	}
+	r.deliver.Unlock()
+	defer func() {
+		r.deliver.Lock()
+		r.delivered = batch
+		r.turn.Broadcast()
+		r.deliver.Unlock()
+	}()
+	for _, c := range changes {
+		for _, fn := range watchers {
+			fn(c)
+		}
+	}
+}
+
+// Has reports whether the registry holds a version Equal to v.
+func (r *Registry) Has(v Semver) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.set.Contains(v)
+}
+
+// Len returns the number of versions in the registry.
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.set.Len()
+}
+
+// Latest returns the newest version that satisfies c, or false if there is
+// none.
+func (r *Registry) Latest(c Constraint) (Semver, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var best Semver
+	found := false
+	for _, v := range r.set.m {
+		if c.Check(v) && (!found || v.Cmp(best) > 0) {
+			best, found = v, true
+		}
+	}
+	return best, found
+}
+
+// Snapshot returns the versions in the registry, in ascending order.
+func (r *Registry) Snapshot() Versions {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.set.Versions()
+}
+
+// OnChange calls fn for every later change, in order, until the returned
+// cancel function is called. fn runs on the goroutine that made the change,
+// without the registry locked, so it may read the registry, though later
+// changes may already have been made by then. It must not call back into Add
+// or Remove, which would wait for fn to return before telling the watchers.
+func (r *Registry) OnChange(fn func(Change)) (cancel func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.watchers == nil {
+		r.watchers = make(map[int]func(Change))
+	}
+	id := r.nextID
+	r.nextID++
+	r.watchers[id] = fn
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.watchers, id)
+	}
+}
+
+// Watch returns a channel that receives every later change, until the
+// returned cancel function is called, which closes it. Changes block the
+// goroutine making them until they're received or the watch is canceled, so
+// give the channel a buffer if the receiver may fall behind.
+func (r *Registry) Watch(buffer int) (<-chan Change, func()) {
+	ch := make(chan Change, buffer)
+	done := make(chan struct{})
+	var mu sync.Mutex
+	stop := r.OnChange(func(c Change) {
+		mu.Lock()
+		defer mu.Unlock()
+		select {
+		case <-done:
+		case ch <- c:
+		}
+	})
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			stop()
+			close(done)
+			mu.Lock()
+			close(ch)
+			mu.Unlock()
+		})
+	}
+}
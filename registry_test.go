@@ -0,0 +1,156 @@
+package semver
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	var r Registry
+	if n := r.Add(MustParse("1.0.0"), MustParse("1.2.0"), MustParse("v1.2.0+build")); n != 2 {
+		t.Errorf("expected 2 new versions, got %d", n)
+	}
+	if !r.Has(MustParse("1.2.0+other")) || r.Has(MustParse("2.0.0")) || r.Len() != 2 {
+		t.Errorf("unexpected membership: %v", r.Snapshot())
+	}
+
+	r.Add(MustParse("2.0.0-rc.1"), MustParse("1.10.0"))
+	if v, ok := r.Latest(MustParseConstraint("^1")); !ok || v != MustParse("1.10.0") {
+		t.Errorf("latest ^1: %s, %v", v, ok)
+	}
+	if v, ok := r.Latest(MustParseConstraint(">=2.0.0-0")); !ok || v != MustParse("2.0.0-rc.1") {
+		t.Errorf("latest prerelease: %s, %v", v, ok)
+	}
+	if _, ok := r.Latest(MustParseConstraint("^3")); ok {
+		t.Errorf("expected nothing for ^3")
+	}
+
+	snap := r.Snapshot()
+	r.Remove(MustParse("1.0.0"))
+	if exp := []string{"1.0.0", "1.2.0", "1.10.0", "2.0.0-rc.1"}; !reflect.DeepEqual(snap.Strings(), exp) {
+		t.Errorf("snapshot should not change: %q", snap.Strings())
+	}
+	if r.Remove(MustParse("1.0.0")) {
+		t.Errorf("removing twice should report false")
+	}
+}
+
+func TestRegistryNotifications(t *testing.T) {
+	var r Registry
+	var got []Change
+	cancel := r.OnChange(func(c Change) {
+		got = append(got, c)
+		if r.Has(c.Version) == c.Removed {
+			t.Errorf("callback should see the change applied: %+v", c)
+		}
+	})
+	ch, stop := r.Watch(10)
+
+	r.Add(MustParse("1.0.0"), MustParse("1.0.0"), MustParse("1.1.0"))
+	r.Remove(MustParse("1.0.0"))
+	cancel()
+	r.Add(MustParse("2.0.0"))
+	stop()
+	stop()
+
+	exp := []Change{{MustParse("1.0.0"), false}, {MustParse("1.1.0"), false}, {MustParse("1.0.0"), true}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("callback: %v != %v", got, exp)
+	}
+	var watched []Change
+	for c := range ch {
+		watched = append(watched, c)
+	}
+	if exp := append(exp, Change{MustParse("2.0.0"), false}); !reflect.DeepEqual(watched, exp) {
+		t.Errorf("channel: %v != %v", watched, exp)
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	var r Registry
+	ch, stop := r.Watch(0)
+	var received int
+	done := make(chan struct{})
+	go func() {
+		for range ch {
+			received++
+		}
+		close(done)
+	}()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				r.Add(MustParse(fmt.Sprintf("%d.%d.0", g%4, i)))
+				r.Latest(MustParseConstraint("^1"))
+				r.Snapshot()
+			}
+		}(g)
+	}
+	wg.Wait()
+	stop()
+	<-done
+
+	if r.Len() != 200 || received != 200 {
+		t.Errorf("expected 200 versions and changes, got %d and %d", r.Len(), received)
+	}
+}
+
+func TestRegistryWatcherReads(t *testing.T) {
+	var r Registry
+	var mu sync.Mutex
+	var seen []Change
+	r.OnChange(func(c Change) {
+		// reading from a watcher must not deadlock with concurrent changes,
+		// which are more likely to be waiting after a pause
+		time.Sleep(10 * time.Microsecond)
+		r.Has(c.Version)
+		r.Snapshot()
+		mu.Lock()
+		seen = append(seen, c)
+		mu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					v := MustParse(fmt.Sprintf("%d.%d.0", g, i))
+					r.Add(v)
+					if i%3 == 0 {
+						r.Remove(v)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("deadlock: a watcher reading the registry blocked concurrent changes")
+	}
+
+	// each version's changes arrive in the order they were made
+	added := make(map[Semver]bool)
+	for _, c := range seen {
+		if c.Removed != added[c.Version] {
+			t.Fatalf("out of order change: %+v", c)
+		}
+		added[c.Version] = !c.Removed
+	}
+	if len(seen) != 400+4*34 {
+		t.Errorf("expected %d changes, got %d", 400+4*34, len(seen))
+	}
+}
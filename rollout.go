@@ -0,0 +1,77 @@
+package semver
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// RolloutBuckets is how many cohort buckets clients are divided into.
+const RolloutBuckets = 100
+
+// RolloutBucket assigns a client to a cohort bucket from 0 to
+// RolloutBuckets-1 by hashing its ID, so that a client is always in the same
+// bucket. The salt is hashed with the ID; using the target version as the
+// salt gives each release different early adopters, and a fixed salt the
+// same ones every time.
+func RolloutBucket(clientID, salt string) int {
+	h := fnv.New32a()
+	h.Write([]byte(salt))
+	h.Write([]byte{0})
+	h.Write([]byte(clientID))
+	return int(h.Sum32() % RolloutBuckets)
+}
+
+// Rollout is a staged rollout of a target version.
+type Rollout struct {
+	Target Semver
+	Stages []RolloutStage
+}
+
+// RolloutStage is one step of a Rollout: the share of clients that get the
+// update, and optionally which versions they may be updating from.
+type RolloutStage struct {
+	// Percent is the percentage of cohort buckets, counting from 0, that
+	// get the update.
+	Percent int
+	// From limits the stage to clients whose versions satisfy it, so that
+	// for example only 1.x clients are updated at first. The zero
+	// Constraint lets any version update, including prereleases.
+	From Constraint
+}
+
+// Validate checks that the target is valid and that the stages only ever
+// widen the rollout.
+func (r Rollout) Validate() error {
+	if err := r.Target.Validate(); err != nil {
+		return err
+	}
+	last := 0
+	for i, s := range r.Stages {
+		if s.Percent < 0 || s.Percent > 100 {
+			return fmt.Errorf("Invalid rollout: stage %d: percent %d is not between 0 and 100", i, s.Percent)
+		} else if s.Percent < last {
+			return fmt.Errorf("Invalid rollout: stage %d: percent %d is lower than the stage before", i, s.Percent)
+		}
+		last = s.Percent
+	}
+	return nil
+}
+
+// ShouldUpdate reports whether a client at version v in cohort bucket
+// bucket, as given by RolloutBucket, should update to the target during the
+// given stage, counting from 0. Clients already at or past the target never
+// update, no one does during a negative stage, and stages past the last
+// are treated as the last.
+func (r Rollout) ShouldUpdate(stage int, v Semver, bucket int) bool {
+	if stage < 0 || len(r.Stages) == 0 || v.Cmp(r.Target) >= 0 {
+		return false
+	}
+	if stage >= len(r.Stages) {
+		stage = len(r.Stages) - 1
+	}
+	s := r.Stages[stage]
+	if s.From.sets != nil && !s.From.Check(v) {
+		return false
+	}
+	return bucket >= 0 && bucket < s.Percent*RolloutBuckets/100
+}
@@ -0,0 +1,80 @@
+package semver
+
+import "testing"
+
+func TestRolloutBucket(t *testing.T) {
+	if a, b := RolloutBucket("client-1", "2.0.0"), RolloutBucket("client-1", "2.0.0"); a != b {
+		t.Errorf("buckets should be stable: %d != %d", a, b)
+	}
+
+	counts := make([]int, RolloutBuckets)
+	moved := 0
+	for i := 0; i < 10000; i++ {
+		id := string(rune('a'+i%26)) + string(rune('0'+i/26%10)) + string(rune('A'+i/260))
+		b := RolloutBucket(id, "2.0.0")
+		if b < 0 || b >= RolloutBuckets {
+			t.Fatalf("bucket %d out of range", b)
+		}
+		counts[b]++
+		if RolloutBucket(id, "2.1.0") != b {
+			moved++
+		}
+	}
+	for b, n := range counts {
+		if n == 0 {
+			t.Errorf("bucket %d is empty", b)
+		}
+	}
+	if moved < 5000 {
+		t.Errorf("a different salt should reshuffle most clients, only moved %d", moved)
+	}
+}
+
+func TestRollout(t *testing.T) {
+	r := Rollout{
+		Target: MustParse("2.1.0"),
+		Stages: []RolloutStage{
+			{Percent: 5, From: MustParseConstraint("^2.0.0")},
+			{Percent: 50},
+			{Percent: 100},
+		},
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tests := []struct {
+		stage   int
+		version string
+		bucket  int
+		exp     bool
+		reason  string
+	}{
+		{0, "2.0.3", 4, true, "canary bucket"},
+		{0, "2.0.3", 5, false, "outside the canary share"},
+		{0, "1.9.0", 0, false, "version gate"},
+		{1, "1.9.0", 49, true, "gate lifted"},
+		{1, "2.1.0-rc.1", 10, true, "prerelease client"},
+		{1, "2.1.0", 10, false, "already updated"},
+		{1, "3.0.0", 10, false, "newer than the target"},
+		{2, "2.0.0", 99, true, "everyone"},
+		{7, "2.0.0", 99, true, "past the last stage"},
+		{-1, "2.0.0", 0, false, "not started"},
+	}
+
+	for _, test := range tests {
+		if ok := r.ShouldUpdate(test.stage, MustParse(test.version), test.bucket); ok != test.exp {
+			t.Errorf("%s: ShouldUpdate(%d, %s, %d) = %t", test.reason, test.stage, test.version, test.bucket, ok)
+		}
+	}
+
+	bad := []Rollout{
+		{Target: MustParse("1.0.0"), Stages: []RolloutStage{{Percent: 101}}},
+		{Target: MustParse("1.0.0"), Stages: []RolloutStage{{Percent: 50}, {Percent: 10}}},
+		{Target: Semver{Major: -1}},
+	}
+	for _, r := range bad {
+		if err := r.Validate(); err == nil {
+			t.Errorf("expected an error validating %+v", r)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EVR is an RPM epoch-version-release triplet, written [epoch:]version-release.
+type EVR struct {
+	Epoch   int
+	Version string
+	Release string
+}
+
+// ParseEVR parses an EVR such as 1:1.2.3~rc.1-2.fc40. The epoch and release
+// are optional.
+func ParseEVR(s string) (EVR, error) {
+	var e EVR
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil || n < 0 {
+			return EVR{}, fmt.Errorf("Invalid RPM epoch in %s", s)
+		}
+		e.Epoch, s = n, s[i+1:]
+	}
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		s, e.Release = s[:i], s[i+1:]
+		if e.Release == "" {
+			return EVR{}, fmt.Errorf("Invalid RPM EVR: empty release")
+		}
+	}
+	e.Version = s
+	if err := e.Validate(); err != nil {
+		return EVR{}, err
+	}
+	return e, nil
+}
+
+// String returns the EVR as [epoch:]version[-release], omitting a zero epoch.
+func (e EVR) String() string {
+	s := e.Version
+	if e.Epoch != 0 {
+		s = strconv.Itoa(e.Epoch) + ":" + s
+	}
+	if e.Release != "" {
+		s += "-" + e.Release
+	}
+	return s
+}
+
+// Validate checks that the version and release only contain the characters
+// RPM allows: letters, digits and . _ + ~ ^. Neither may contain a hyphen.
+func (e EVR) Validate() error {
+	if e.Epoch < 0 {
+		return fmt.Errorf("RPM epoch must be non-negative")
+	} else if e.Version == "" {
+		return fmt.Errorf("Invalid RPM EVR: empty version")
+	} else if !rpmChars(e.Version) {
+		return fmt.Errorf("Invalid characters in RPM version: %q", e.Version)
+	} else if !rpmChars(e.Release) {
+		return fmt.Errorf("Invalid characters in RPM release: %q", e.Release)
+	}
+	return nil
+}
+
+func rpmChars(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case isDigit(c), 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case c == '.', c == '_', c == '+', c == '~', c == '^':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Cmp compares two EVRs as rpm does: epochs numerically, then versions and
+// releases with rpmvercmp.
+func (a EVR) Cmp(b EVR) int {
+	if a.Epoch != b.Epoch {
+		return sign(a.Epoch - b.Epoch)
+	}
+	if c := rpmvercmp(a.Version, b.Version); c != 0 {
+		return c
+	}
+	return rpmvercmp(a.Release, b.Release)
+}
+
+// CompareRPM compares two EVR strings, returning -1, 0 or 1. Strings that
+// don't parse with ParseEVR are compared whole with rpmvercmp.
+func CompareRPM(a, b string) int {
+	ea, errA := ParseEVR(a)
+	eb, errB := ParseEVR(b)
+	if errA != nil || errB != nil {
+		return rpmvercmp(a, b)
+	}
+	return ea.Cmp(eb)
+}
+
+// ToRPM maps v onto an RPM version following the Fedora versioning
+// guidelines: the prerelease goes in the version after a ~, which sorts
+// before the release, so 1.2.3-rc.1 becomes 1.2.3~rc.1, and build metadata
+// goes after a ^, so 1.2.3+git.abc becomes 1.2.3^git.abc. Unlike in semver,
+// rpm orders 1.2.3^git.abc after 1.2.3.
+//
+// The guidelines are enforced rather than worked around: release must be
+// given, a prerelease may not be encoded the old way as a Release starting
+// with "0.", and a prerelease or build containing a hyphen, which rpm doesn't
+// allow in versions, is an error rather than being silently changed.
+//
+// rpm orders numeric segments after alphabetic ones, where semver puts
+// numeric identifiers first, so 1.0.0-1 and 1.0.0-alpha swap order.
+func (v Semver) ToRPM(release string) (EVR, error) {
+	if release == "" {
+		return EVR{}, fmt.Errorf("Cannot convert %s to RPM: a release is required", v)
+	}
+	if v.Prerelease != "" && strings.HasPrefix(release, "0.") {
+		return EVR{}, fmt.Errorf("Cannot convert %s to RPM: put the prerelease in the version with ~, not in release %s", v, release)
+	}
+	if strings.Contains(v.Prerelease, "-") || strings.Contains(v.Build, "-") {
+		return EVR{}, fmt.Errorf("Cannot convert %s to RPM: versions can't contain hyphens", v)
+	}
+	e := EVR{Version: fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch), Release: release}
+	if v.Prerelease != "" {
+		e.Version += "~" + v.Prerelease
+	}
+	if v.Build != "" {
+		e.Version += "^" + v.Build
+	}
+	if err := e.Validate(); err != nil {
+		return EVR{}, err
+	}
+	return e, nil
+}
+
+// FromRPM parses an RPM version of the form ToRPM produces, with or without
+// an epoch and release, such as 1.2.3~rc.1-1.fc40. The release describes the
+// packaging and is dropped. A non-zero epoch can't be represented and is an
+// error.
+func FromRPM(s string) (Semver, error) {
+	e, err := ParseEVR(s)
+	if err != nil {
+		return Semver{}, err
+	}
+	if e.Epoch != 0 {
+		return Semver{}, fmt.Errorf("Cannot convert RPM version %s: epoch %d has no semver equivalent", s, e.Epoch)
+	}
+	core, pre, build := e.Version, "", ""
+	if i := strings.IndexByte(core, '^'); i >= 0 {
+		if core, build = core[:i], core[i+1:]; build == "" {
+			return Semver{}, fmt.Errorf("Invalid RPM version %s: empty build", s)
+		}
+	}
+	if i := strings.IndexByte(core, '~'); i >= 0 {
+		if core, pre = core[:i], core[i+1:]; pre == "" {
+			return Semver{}, fmt.Errorf("Invalid RPM version %s: empty prerelease", s)
+		}
+	}
+	if strings.HasPrefix(core, "v") {
+		return Semver{}, fmt.Errorf("Invalid RPM version: %s", s)
+	}
+	v, err := Parse(core)
+	if err != nil {
+		return Semver{}, fmt.Errorf("Invalid RPM version %s: %s", s, err)
+	}
+	v.Prerelease, v.Build = pre, build
+	if err := v.Validate(); err != nil {
+		return Semver{}, fmt.Errorf("Invalid RPM version %s: %s", s, err)
+	}
+	return v, nil
+}
+
+// rpmvercmp compares versions or releases as rpm does, returning -1, 0 or 1.
+// Strings are split into runs of digits and runs of letters, ignoring other
+// separators; digit runs compare numerically and are newer than letter runs.
+// ~ sorts before everything, even the end of the string, and ^ sorts after
+// the end of the string but before anything else.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	isAlnum := func(c byte) bool {
+		return isDigit(c) || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+	}
+	isAlpha := func(c byte) bool {
+		return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+	}
+	for len(a) > 0 || len(b) > 0 {
+		for len(a) > 0 && !isAlnum(a[0]) && a[0] != '~' && a[0] != '^' {
+			a = a[1:]
+		}
+		for len(b) > 0 && !isAlnum(b[0]) && b[0] != '~' && b[0] != '^' {
+			b = b[1:]
+		}
+
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			} else if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if strings.HasPrefix(a, "^") || strings.HasPrefix(b, "^") {
+			switch {
+			case a == "":
+				return -1
+			case b == "":
+				return 1
+			case a[0] != '^':
+				return 1
+			case b[0] != '^':
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if a == "" || b == "" {
+			break
+		}
+
+		class := isAlpha
+		numeric := isDigit(a[0])
+		if numeric {
+			class = isDigit
+		}
+		i, j := 0, 0
+		for i < len(a) && class(a[i]) {
+			i++
+		}
+		for j < len(b) && class(b[j]) {
+			j++
+		}
+		segA, segB := a[:i], b[:j]
+		a, b = a[i:], b[j:]
+		if segB == "" {
+			// segments of different types: numbers are newer
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+		if numeric {
+			segA, segB = strings.TrimLeft(segA, "0"), strings.TrimLeft(segB, "0")
+			if len(segA) != len(segB) {
+				return sign(len(segA) - len(segB))
+			}
+		}
+		if c := strings.Compare(segA, segB); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a != "":
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,177 @@
+package semver
+
+import "testing"
+
+func TestParseEVR(t *testing.T) {
+	tests := []struct {
+		given  string
+		exp    EVR
+		reason string
+	}{
+		{"1.2.3", EVR{Version: "1.2.3"}, "version only"},
+		{"1.2.3-1.fc40", EVR{Version: "1.2.3", Release: "1.fc40"}, "release"},
+		{"2:1.2.3~rc.1-3", EVR{2, "1.2.3~rc.1", "3"}, "epoch"},
+	}
+
+	for _, test := range tests {
+		e, err := ParseEVR(test.given)
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+			continue
+		} else if e != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, e, test.exp)
+		}
+		if e.String() != test.given {
+			t.Errorf("%s: String() = %s", test.reason, e)
+		}
+	}
+
+	for _, s := range []string{"", "x:1.2.3", "-1:1.2.3", "1.2.3-", "1.2.3-a-b-", "1.2/3"} {
+		if e, err := ParseEVR(s); err == nil {
+			t.Errorf("%q: expected error, returned: %+v", s, e)
+		}
+	}
+}
+
+func TestRpmvercmp(t *testing.T) {
+	// from the rpm test suite
+	tests := []struct {
+		a, b string
+		exp  int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "2.0", -1},
+		{"2.0.1", "2.0.1", 0},
+		{"2.0", "2.0.1", -1},
+		{"2.0.1a", "2.0.1a", 0},
+		{"2.0.1a", "2.0.1", 1},
+		{"5.5p1", "5.5p2", -1},
+		{"5.5p10", "5.5p1", 1},
+		{"10xyz", "10.1xyz", -1},
+		{"xyz10", "xyz10.1", -1},
+		{"xyz.4", "8", -1},
+		{"xyz.4", "2", -1},
+		{"5.5p2", "5.6p1", -1},
+		{"5.6p1", "6.5p1", -1},
+		{"6.0.rc1", "6.0", 1},
+		{"10b2", "10a1", 1},
+		{"1.0aa", "1.0a", 1},
+		{"10.0001", "10.1", 0},
+		{"10.0001", "10.0039", -1},
+		{"4.999.9", "5.0", -1},
+		{"20101121", "20101122", -1},
+		{"2_0", "2_0", 0},
+		{"2.0", "2_0", 0},
+		{"a", "a", 0},
+		{"a+", "a_", 0},
+		{"+", "_", 0},
+		{"1.0~rc1", "1.0~rc1", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~rc1~git123", "1.0~rc1", -1},
+		{"1.0^", "1.0", 1},
+		{"1.0^git1", "1.0", 1},
+		{"1.0^git1", "1.0^git2", -1},
+		{"1.0^git1", "1.01", -1},
+		{"1.0^20160101", "1.0.1", -1},
+		{"1.0~rc1^git1", "1.0~rc1", 1},
+		{"1.0^git1~pre", "1.0^git1", -1},
+	}
+
+	for _, test := range tests {
+		if c := rpmvercmp(test.a, test.b); c != test.exp {
+			t.Errorf("rpmvercmp(%q, %q) = %d, expected %d", test.a, test.b, c, test.exp)
+		}
+		if c := rpmvercmp(test.b, test.a); c != -test.exp {
+			t.Errorf("rpmvercmp(%q, %q) = %d, expected %d", test.b, test.a, c, -test.exp)
+		}
+	}
+}
+
+func TestCompareRPM(t *testing.T) {
+	ordered := []string{"1.2.3~rc.1-1", "1.2.3-1", "1.2.3-2.fc40", "1.2.3^git.1-1", "1.10.0-1", "1:0.1-1"}
+	for i := 1; i < len(ordered); i++ {
+		if c := CompareRPM(ordered[i-1], ordered[i]); c != -1 {
+			t.Errorf("CompareRPM(%q, %q) = %d, expected -1", ordered[i-1], ordered[i], c)
+		}
+	}
+}
+
+func TestToRPM(t *testing.T) {
+	tests := []struct {
+		given, exp string
+		reason     string
+	}{
+		{"1.2.3", "1.2.3-1", "release"},
+		{"1.2.3-rc.1", "1.2.3~rc.1-1", "prerelease"},
+		{"1.2.3+git.abc", "1.2.3^git.abc-1", "build"},
+		{"1.2.3-beta.2+git.abc", "1.2.3~beta.2^git.abc-1", "prerelease and build"},
+	}
+
+	for _, test := range tests {
+		v := MustParse(test.given)
+		e, err := v.ToRPM("1")
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+			continue
+		} else if e.String() != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, e, test.exp)
+		}
+		if back, err := FromRPM(e.String()); err != nil || back != v {
+			t.Errorf("%s: round trip gave %+v, %v", test.reason, back, err)
+		}
+	}
+
+	bad := []struct {
+		given, release string
+		reason         string
+	}{
+		{"1.2.3", "", "no release"},
+		{"1.2.3-rc.1", "0.1.rc1", "legacy prerelease release"},
+		{"1.2.3-rc-1", "1", "hyphen in prerelease"},
+		{"1.2.3+a-b", "1", "hyphen in build"},
+		{"1.2.3", "1/2", "invalid release"},
+	}
+	for _, test := range bad {
+		if e, err := MustParse(test.given).ToRPM(test.release); err == nil {
+			t.Errorf("%s: expected error, returned: %s", test.reason, e)
+		}
+	}
+}
+
+func TestFromRPM(t *testing.T) {
+	if v, err := FromRPM("1.2.3~rc.1"); err != nil || v != (Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}) {
+		t.Errorf("no release: %+v, %v", v, err)
+	}
+	for _, s := range []string{"1:1.2.3-1", "1.2-1", "v1.2.3-1", "1.2.3~-1", "1.2.3^-1", "1.2.3_1-1"} {
+		if v, err := FromRPM(s); err == nil {
+			t.Errorf("%q: expected error, returned: %+v", s, v)
+		}
+	}
+}
+
+func TestCompareRPMMatchesCmp(t *testing.T) {
+	// semver precedence survives the conversion, except that rpm sorts
+	// numeric segments after alphabetic ones
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a, _ := MustParse(ordered[i-1]).ToRPM("1")
+		b, _ := MustParse(ordered[i]).ToRPM("1")
+		if c := a.Cmp(b); c != -1 {
+			t.Errorf("%s vs %s: Cmp gives %d", a, b, c)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package semver
+
+import "sort"
+
+// Search finds target in versions, which must be sorted in ascending order.
+// It returns the index of the first version with the same precedence as
+// target and true, or the index target would be inserted at and false.
+func Search(sorted Versions, target Semver) (index int, found bool) {
+	i := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].Cmp(target) >= 0
+	})
+	return i, i < len(sorted) && sorted[i].Cmp(target) == 0
+}
+
+// SearchConstraint returns the smallest window sorted[lo:hi] of versions, which
+// must be sorted in ascending order, that holds every version satisfying c.
+// It takes O(log n) time.
+//
+// A constraint with alternatives, such as "^1 || ^3", or the prerelease rule
+// can leave versions inside the window that don't satisfy c, so check each
+// one if that matters.
+func SearchConstraint(sorted Versions, c Constraint) (lo, hi int) {
+	lower, upper := c.bounds()
+	lo = sort.Search(len(sorted), func(i int) bool {
+		return !lower.below(sorted[i])
+	})
+	hi = sort.Search(len(sorted), func(i int) bool {
+		return upper.above(sorted[i])
+	})
+	if hi < lo {
+		hi = lo
+	}
+	return
+}
+
+// bound is one end of an interval of versions; the zero bound is unbounded.
+type bound struct {
+	v         Semver
+	inclusive bool
+	bounded   bool
+}
+
+// below reports whether v falls below b as a lower bound.
+func (b bound) below(v Semver) bool {
+	if !b.bounded {
+		return false
+	}
+	n := v.Cmp(b.v)
+	return n < 0 || n == 0 && !b.inclusive
+}
+
+// above reports whether v falls above b as an upper bound.
+func (b bound) above(v Semver) bool {
+	if !b.bounded {
+		return false
+	}
+	n := v.Cmp(b.v)
+	return n > 0 || n == 0 && !b.inclusive
+}
+
+// tighter reports whether b excludes more than o, as a lower bound if
+// sign > 0 or an upper bound if sign < 0.
+func (b bound) tighter(o bound, sign int) bool {
+	switch {
+	case !b.bounded:
+		return false
+	case !o.bounded:
+		return true
+	}
+	if n := b.v.Cmp(o.v) * sign; n != 0 {
+		return n > 0
+	}
+	return !b.inclusive && o.inclusive
+}
+
+// bounds returns the interval that holds every version satisfying c.
+func (c Constraint) bounds() (lower, upper bound) {
+	for i, set := range c.sets {
+		var lo, hi bound
+		for _, comp := range set {
+			var l, u bound
+			switch comp.op {
+			case opGT, opGTE:
+				l = bound{comp.v, comp.op == opGTE, true}
+			case opLT, opLTE:
+				u = bound{comp.v, comp.op == opLTE, true}
+			default:
+				l = bound{comp.v, true, true}
+				u = l
+			}
+			if l.tighter(lo, 1) {
+				lo = l
+			}
+			if u.tighter(hi, -1) {
+				hi = u
+			}
+		}
+		// the union of the sets is bounded by the loosest of each
+		if i == 0 || lower.tighter(lo, 1) {
+			lower = lo
+		}
+		if i == 0 || upper.tighter(hi, -1) {
+			upper = hi
+		}
+	}
+	return
+}
+
+// Slice returns the sub-slice of vs, which must be sorted in ascending order,
+// between from and to: the closed interval [from, to] if inclusive is set,
+// or the open interval (from, to) if not. The result shares vs's backing
+// array.
+func (vs Versions) Slice(from, to Semver, inclusive bool) Versions {
+	lower := bound{from, inclusive, true}
+	upper := bound{to, inclusive, true}
+	lo := sort.Search(len(vs), func(i int) bool {
+		return !lower.below(vs[i])
+	})
+	hi := sort.Search(len(vs), func(i int) bool {
+		return upper.above(vs[i])
+	})
+	if hi < lo {
+		hi = lo
+	}
+	return vs[lo:hi]
+}
@@ -0,0 +1,94 @@
+package semver
+
+import "testing"
+
+func TestSearch(t *testing.T) {
+	vs, _ := ParseVersions([]string{"0.9.0", "1.0.0-rc.1", "1.0.0", "1.2.0", "1.10.0", "2.0.0"})
+
+	tests := []struct {
+		target string
+		index  int
+		found  bool
+		reason string
+	}{
+		{"1.2.0", 3, true, "present"},
+		{"1.2.0+build", 3, true, "build metadata"},
+		{"1.0.0-rc.1", 1, true, "prerelease"},
+		{"1.3.0", 4, false, "absent"},
+		{"0.1.0", 0, false, "before all"},
+		{"3.0.0", 6, false, "after all"},
+	}
+
+	for _, test := range tests {
+		if i, ok := Search(vs, MustParse(test.target)); i != test.index || ok != test.found {
+			t.Errorf("%s: %d, %v != %d, %v", test.reason, i, ok, test.index, test.found)
+		}
+	}
+}
+
+func TestSearchConstraint(t *testing.T) {
+	vs, _ := ParseVersions([]string{"0.9.0", "1.0.0-rc.1", "1.0.0", "1.2.0", "1.10.0", "2.0.0", "2.1.0", "3.0.0"})
+
+	tests := []struct {
+		constraint string
+		lo, hi     int
+		reason     string
+	}{
+		{"^1", 2, 5, "caret"},
+		{">=1.0.0-rc.1 <=1.2.0", 1, 4, "inclusive bounds"},
+		{">1.0.0 <2.0.0", 3, 5, "exclusive bounds"},
+		{"1.2.0", 3, 4, "exact"},
+		{"^1.2 || ^2", 3, 7, "alternatives"},
+		{">=2", 5, 8, "no upper bound"},
+		{"<1", 0, 1, "no lower bound"},
+		{"*", 0, 8, "everything"},
+		{"^5", 8, 8, "nothing"},
+		{">2 <1", 7, 7, "impossible"},
+	}
+
+	for _, test := range tests {
+		c := MustParseConstraint(test.constraint)
+		lo, hi := SearchConstraint(vs, c)
+		if lo != test.lo || hi != test.hi {
+			t.Errorf("%s: [%d:%d] != [%d:%d]", test.reason, lo, hi, test.lo, test.hi)
+		}
+		// the window must hold every match
+		for i, v := range vs {
+			if c.Check(v) && (i < lo || i >= hi) {
+				t.Errorf("%s: %s is outside [%d:%d]", test.reason, v, lo, hi)
+			}
+		}
+	}
+}
+
+func TestVersionsSlice(t *testing.T) {
+	vs, _ := ParseVersions([]string{"0.9.0", "1.0.0-rc.1", "1.0.0", "1.2.0", "1.10.0", "2.0.0"})
+
+	tests := []struct {
+		from, to  string
+		inclusive bool
+		exp       []string
+		reason    string
+	}{
+		{"1.0.0", "1.10.0", true, []string{"1.0.0", "1.2.0", "1.10.0"}, "closed"},
+		{"1.0.0", "1.10.0", false, []string{"1.2.0"}, "open"},
+		{"1.0.0-0", "1.5.0", false, []string{"1.0.0-rc.1", "1.0.0", "1.2.0"}, "bounds between members"},
+		{"0.0.0", "9.0.0", true, []string{"0.9.0", "1.0.0-rc.1", "1.0.0", "1.2.0", "1.10.0", "2.0.0"}, "everything"},
+		{"1.3.0", "1.4.0", true, nil, "empty window"},
+		{"2.0.0", "1.0.0", true, nil, "reversed bounds"},
+	}
+
+	for _, test := range tests {
+		got := vs.Slice(MustParse(test.from), MustParse(test.to), test.inclusive).Strings()
+		if len(got) != len(test.exp) {
+			t.Errorf("%s: %q != %q", test.reason, got, test.exp)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.exp[i] {
+				t.Errorf("%s: %q != %q", test.reason, got, test.exp)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package semver
+
+// A FilterOption restricts which versions a selector such as Latest
+// considers.
+type FilterOption func(*filterOptions)
+
+type filterOptions struct {
+	noPrerelease bool
+	constraints  []Constraint
+	predicates   []func(Semver) bool
+}
+
+func newFilterOptions(opts []FilterOption) *filterOptions {
+	o := new(filterOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithoutPrerelease skips prerelease versions.
+func WithoutPrerelease() FilterOption {
+	return func(o *filterOptions) { o.noPrerelease = true }
+}
+
+// WithConstraint skips versions that don't satisfy c. If given more than
+// once, versions must satisfy every constraint.
+func WithConstraint(c Constraint) FilterOption {
+	return func(o *filterOptions) { o.constraints = append(o.constraints, c) }
+}
+
+func (o *filterOptions) match(v Semver) bool {
+	if o.noPrerelease && v.Prerelease != "" {
+		return false
+	}
+	for _, c := range o.constraints {
+		if !c.Check(v) {
+			return false
+		}
+	}
+	for _, pred := range o.predicates {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns the versions the options allow, in their original order.
+func filter(versions []Semver, o *filterOptions) []Semver {
+	var out []Semver
+	for _, v := range versions {
+		if o.match(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Latest returns the version with the highest precedence among those the
+// options allow, or false if there are none. Of versions that differ only in
+// build metadata, the first is returned.
+func Latest(versions []Semver, opts ...FilterOption) (Semver, bool) {
+	return pick(versions, newFilterOptions(opts), 1)
+}
+
+// Earliest returns the version with the lowest precedence among those the
+// options allow, or false if there are none.
+func Earliest(versions []Semver, opts ...FilterOption) (Semver, bool) {
+	return pick(versions, newFilterOptions(opts), -1)
+}
+
+// pick returns the first matching version with the highest precedence, or
+// the lowest if sign is negative.
+func pick(versions []Semver, o *filterOptions, sign int) (best Semver, found bool) {
+	for _, v := range versions {
+		if !o.match(v) {
+			continue
+		}
+		if !found || v.Cmp(best)*sign > 0 {
+			best, found = v, true
+		}
+	}
+	return
+}
+
+// Filter returns the versions that satisfy c, in ascending order, as a new
+// slice. Prereleases are included only as c's prerelease rule allows.
+func Filter(versions []Semver, c Constraint) []Semver {
+	var out Versions
+	for _, v := range versions {
+		if c.Check(v) {
+			out = append(out, v)
+		}
+	}
+	out.Sort()
+	return out
+}
+
+// NextAfter returns the version in versions that comes right after v, the
+// one with the lowest precedence greater than v's, or false if v is the
+// latest. v itself needn't be in versions.
+func NextAfter(v Semver, versions []Semver, opts ...FilterOption) (Semver, bool) {
+	return pick(versions, newFilterOptions(append(opts[:len(opts):len(opts)], func(o *filterOptions) {
+		o.predicates = append(o.predicates, func(u Semver) bool { return u.Cmp(v) > 0 })
+	})), -1)
+}
+
+// PreviousBefore returns the version in versions that comes right before v,
+// the one with the highest precedence less than v's, or false if v is the
+// earliest.
+func PreviousBefore(v Semver, versions []Semver, opts ...FilterOption) (Semver, bool) {
+	return pick(versions, newFilterOptions(append(opts[:len(opts):len(opts)], func(o *filterOptions) {
+		o.predicates = append(o.predicates, func(u Semver) bool { return u.Cmp(v) < 0 })
+	})), 1)
+}
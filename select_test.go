@@ -0,0 +1,106 @@
+package semver
+
+import "testing"
+
+type selectTest struct {
+	opts   []FilterOption
+	latest string
+	first  string
+	reason string
+}
+
+func TestLatestEarliest(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.2.0", "2.0.0-rc.1", "1.10.0+b", "0.9.0-beta", "1.10.0+a", "0.9.0", "1.3.0"})
+
+	tests := []selectTest{
+		{nil, "2.0.0-rc.1", "0.9.0-beta", "no options"},
+		{[]FilterOption{WithoutPrerelease()}, "1.10.0+b", "0.9.0", "stable only"},
+		{[]FilterOption{WithConstraint(MustParseConstraint("~1.2"))}, "1.2.0", "1.2.0", "constraint"},
+		{[]FilterOption{WithConstraint(MustParseConstraint("^1")), WithConstraint(MustParseConstraint("<1.5"))}, "1.3.0", "1.2.0", "two constraints"},
+		{[]FilterOption{WithConstraint(MustParseConstraint(">=0.9.0-beta", IncludePrerelease()))}, "2.0.0-rc.1", "0.9.0-beta", "constraint including prereleases"},
+	}
+
+	for _, test := range tests {
+		if v, ok := Latest(vs, test.opts...); !ok || v.String() != test.latest {
+			t.Errorf("%s: latest %s, %v != %s", test.reason, v, ok, test.latest)
+		}
+		if v, ok := Earliest(vs, test.opts...); !ok || v.String() != test.first {
+			t.Errorf("%s: earliest %s, %v != %s", test.reason, v, ok, test.first)
+		}
+	}
+
+	if v, ok := Latest(vs, WithConstraint(MustParseConstraint("^3"))); ok {
+		t.Errorf("expected nothing, returned %s", v)
+	}
+	if v, ok := Earliest(nil); ok {
+		t.Errorf("expected nothing, returned %s", v)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.10.0", "2.0.0", "1.2.0", "1.5.0-rc.1", "1.2.0-rc.2", "0.9.0"})
+	orig := append([]Semver(nil), vs...)
+
+	tests := []struct {
+		constraint Constraint
+		exp        []string
+		reason     string
+	}{
+		{MustParseConstraint("^1.2"), []string{"1.2.0", "1.10.0"}, "caret"},
+		{MustParseConstraint("^1.2.0-rc.1"), []string{"1.2.0-rc.2", "1.2.0", "1.10.0"}, "prerelease rule"},
+		{MustParseConstraint("^1.2", IncludePrerelease()), []string{"1.2.0", "1.5.0-rc.1", "1.10.0"}, "including prereleases"},
+		{MustParseConstraint(">3"), nil, "nothing"},
+	}
+
+	for _, test := range tests {
+		got := Versions(Filter(vs, test.constraint)).Strings()
+		if len(got) != len(test.exp) {
+			t.Errorf("%s: %q != %q", test.reason, got, test.exp)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.exp[i] {
+				t.Errorf("%s: %q != %q", test.reason, got, test.exp)
+				break
+			}
+		}
+	}
+
+	for i := range vs {
+		if vs[i] != orig[i] {
+			t.Errorf("input should not be modified: %v", vs)
+			break
+		}
+	}
+}
+
+func TestNextPrevious(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.2.0", "2.0.0-rc.1", "1.10.0", "0.9.0", "2.0.0", "1.3.0"})
+
+	tests := []struct {
+		given      string
+		opts       []FilterOption
+		next, prev string
+		reason     string
+	}{
+		{"1.3.0", nil, "1.10.0", "1.2.0", "member"},
+		{"1.5.0", nil, "1.10.0", "1.3.0", "not a member"},
+		{"1.10.0", nil, "2.0.0-rc.1", "1.3.0", "next is a prerelease"},
+		{"1.10.0", []FilterOption{WithoutPrerelease()}, "2.0.0", "1.3.0", "stable only"},
+		{"1.2.0+build", nil, "1.3.0", "0.9.0", "build metadata is ignored"},
+		{"0.9.0", nil, "1.2.0", "", "earliest"},
+		{"2.0.0", nil, "", "2.0.0-rc.1", "latest"},
+	}
+
+	for _, test := range tests {
+		v := MustParse(test.given)
+		next, ok := NextAfter(v, vs, test.opts...)
+		if ok != (test.next != "") || ok && next.String() != test.next {
+			t.Errorf("%s: next %s, %v != %q", test.reason, next, ok, test.next)
+		}
+		prev, ok := PreviousBefore(v, vs, test.opts...)
+		if ok != (test.prev != "") || ok && prev.String() != test.prev {
+			t.Errorf("%s: previous %s, %v != %q", test.reason, prev, ok, test.prev)
+		}
+	}
+}
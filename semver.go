@@ -1,15 +1,25 @@
 package semver
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
 	"unicode"
 )
 
-var semverReg = regexp.MustCompile(`^v?(\d+).(\d+).(\d+)(?:-([0-9A-Za-z-.]+))?(?:\+([0-9A-Za-z-.]+))?$`)
+var (
+	_ encoding.TextMarshaler   = Semver{}
+	_ encoding.TextUnmarshaler = (*Semver)(nil)
+	_ json.Marshaler           = Semver{}
+	_ json.Unmarshaler         = (*Semver)(nil)
+)
+
+var semverReg = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z-.]+))?(?:\+([0-9A-Za-z-.]+))?$`)
 
 type Semver struct {
 	Major      int
@@ -21,6 +31,35 @@ type Semver struct {
 
 // Parse parses semver into a Semver. A leading v may be included.
 func Parse(semver string) (v Semver, err error) {
+	if v, err = parse(semver); err != nil {
+		return
+	}
+	err = v.Validate()
+	return
+}
+
+// ParseAll parses every string in semvers. The returned slice always has one
+// element per input, left as the zero Semver where parsing failed; if any
+// failed, the error is a ParseErrors reporting each failure with its index.
+func ParseAll(semvers []string) ([]Semver, error) {
+	vs := make([]Semver, len(semvers))
+	var errs ParseErrors
+	for i, s := range semvers {
+		v, err := Parse(s)
+		if err != nil {
+			errs = append(errs, &ParseError{Index: i, Input: s, Err: err})
+			continue
+		}
+		vs[i] = v
+	}
+	if errs != nil {
+		return vs, errs
+	}
+	return vs, nil
+}
+
+// parse splits semver into its components without validating the result.
+func parse(semver string) (v Semver, err error) {
 	pieces := semverReg.FindStringSubmatch(semver)
 	if pieces == nil {
 		err = fmt.Errorf("Invalid semver string: %s", semver)
@@ -32,7 +71,6 @@ func Parse(semver string) (v Semver, err error) {
 	v.Patch, _ = strconv.Atoi(pieces[3])
 	v.Prerelease = pieces[4]
 	v.Build = pieces[5]
-	err = v.Validate()
 	return
 }
 
@@ -47,54 +85,169 @@ func MustParse(semver string) Semver {
 
 // String produces a Semver string.
 func (v Semver) String() string {
-	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	var buf [32]byte
+	b, _ := v.AppendText(buf[:0])
+	return string(b)
+}
+
+// AppendText appends the String form of the version to b, so that hot paths
+// can format into a reused buffer without allocating. It has the signature of
+// encoding.TextAppender and never returns an error.
+func (v Semver) AppendText(b []byte) ([]byte, error) {
+	b = strconv.AppendInt(b, int64(v.Major), 10)
+	b = append(b, '.')
+	b = strconv.AppendInt(b, int64(v.Minor), 10)
+	b = append(b, '.')
+	b = strconv.AppendInt(b, int64(v.Patch), 10)
 	if v.Prerelease != "" {
-		s += "-" + v.Prerelease
+		b = append(b, '-')
+		b = append(b, v.Prerelease...)
 	}
 	if v.Build != "" {
-		s += "+" + v.Build
+		b = append(b, '+')
+		b = append(b, v.Build...)
 	}
-	return s
+	return b, nil
 }
 
 // Validate checks a semver for appropriate values.
 // The outputs of String(), MarshalJSON and MarshalText are only guaranteed to be valid
 // semvers if this function does not return an error.
+//
+// 0.0.0 is a valid version; the go command uses it for pseudo-versions of
+// modules that have never been tagged.
 func (v Semver) Validate() error {
 	if v.Major < 0 || v.Minor < 0 || v.Patch < 0 {
 		return fmt.Errorf("Major, minor and patch version numbers must be non-negative")
-	} else if v.Major == 0 && v.Minor == 0 && v.Patch == 0 {
-		return fmt.Errorf("Must supply at least one of: major, minor, patch")
+	} else if v.Prerelease != "" && !identifierChars(v.Prerelease) {
+		return fmt.Errorf("Invalid characters in prerelease: %q", v.Prerelease)
+	} else if v.Build != "" && !identifierChars(v.Build) {
+		return fmt.Errorf("Invalid characters in build metadata: %q", v.Build)
 	}
 	return nil
 }
 
+// MarshalJSON encodes the version as a plain JSON string, e.g. "1.2.3-rc.1".
 func (ver Semver) MarshalJSON() ([]byte, error) {
 	b, err := ver.MarshalText()
-	return []byte(fmt.Sprintf(`"%s"`, string(b))), err
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
 }
 
+// UnmarshalJSON decodes either a JSON string ("1.2.3") or, for backwards
+// compatibility with older versions of this package, an object with major,
+// minor, patch, prerelease and build keys. The form is chosen by the leading
+// token. As usual for unmarshalers, null leaves ver unchanged.
+//
+// The object form is strict: keys are matched case-insensitively, as they
+// always have been, but major, minor and patch are required, values must have
+// the right types, and unknown or repeated keys are errors.
 func (ver *Semver) UnmarshalJSON(arr []byte) error {
-	for _, c := range arr {
-		// TODO: this is completely gross (backwards compatibility for older version)
-		if !unicode.IsSpace(rune(c)) {
-			if c == '{' {
-				// we can't just unmarshal into a Semver because
-				// we'd end up with infinite recursion
-				type semver Semver
-				var sem semver
-				if err := json.Unmarshal(arr, &sem); err != nil {
-					return err
-				}
-				*ver = Semver(sem)
-				return ver.Validate()
-			}
-			break
+	trimmed := bytes.TrimLeftFunc(arr, unicode.IsSpace)
+	if len(trimmed) == 0 {
+		return fmt.Errorf("Invalid semver JSON: empty input")
+	}
+	switch trimmed[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(arr, &s); err != nil {
+			return err
+		}
+		return ver.UnmarshalText([]byte(s))
+	case '{':
+		return ver.unmarshalObject(arr)
+	case 'n':
+		if string(bytes.TrimSpace(trimmed)) == "null" {
+			return nil
 		}
 	}
-	return ver.UnmarshalText(arr[1 : len(arr)-1])
+	return fmt.Errorf("Invalid semver JSON: expected a string or an object, got %s", arr)
 }
 
+// unmarshalObject decodes the legacy object form of a Semver.
+func (ver *Semver) unmarshalObject(arr []byte) error {
+	return ver.decodeObject(arr, defaultFields, "major", "minor", "patch")
+}
+
+// decodeObject decodes an object with the keys given by names, which are
+// matched case-insensitively. Components that aren't in required, given by
+// their default field names, default to zero.
+func (ver *Semver) decodeObject(arr []byte, names objectFields, required ...string) error {
+	invalid := func(err error) error {
+		return fmt.Errorf("Invalid semver JSON: %s", err)
+	}
+	// walk the tokens rather than decoding into a map, which would keep only
+	// the last of two identical keys
+	dec := json.NewDecoder(bytes.NewReader(arr))
+	if tok, err := dec.Token(); err != nil {
+		return invalid(err)
+	} else if tok != json.Delim('{') {
+		return invalid(fmt.Errorf("expected an object, got %s", arr))
+	}
+
+	var v Semver
+	seen := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return invalid(err)
+		}
+		key := tok.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return invalid(err)
+		}
+
+		name := names.canonical(key)
+		if seen[name] {
+			return fmt.Errorf("Invalid semver JSON: repeated key %q", key)
+		}
+		seen[name] = true
+
+		var dst interface{}
+		switch name {
+		case "major":
+			dst = &v.Major
+		case "minor":
+			dst = &v.Minor
+		case "patch":
+			dst = &v.Patch
+		case "prerelease":
+			dst = &v.Prerelease
+		case "build":
+			dst = &v.Build
+		default:
+			return fmt.Errorf("Invalid semver JSON: unknown key %q", key)
+		}
+		if string(raw) == "null" {
+			return fmt.Errorf("Invalid semver JSON: %s is null", key)
+		}
+		if err := json.Unmarshal(raw, dst); err != nil {
+			return fmt.Errorf("Invalid semver JSON: %s: %s", key, err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return invalid(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return invalid(fmt.Errorf("unexpected data after the object"))
+	}
+	for _, name := range required {
+		if !seen[name] {
+			return fmt.Errorf("Invalid semver JSON: missing %s", name)
+		}
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	*ver = v
+	return nil
+}
+
+// UnmarshalText parses arr with Parse, leaving ver unchanged on error.
 func (ver *Semver) UnmarshalText(arr []byte) error {
 	v, err := Parse(string(arr))
 	if err == nil {
@@ -103,8 +256,12 @@ func (ver *Semver) UnmarshalText(arr []byte) error {
 	return err
 }
 
+// MarshalText encodes the version as its String form. Together with
+// UnmarshalText this lets any encoder that honors the encoding text
+// interfaces (JSON map keys, XML attributes, most config libraries) handle a
+// Semver without custom glue.
 func (ver Semver) MarshalText() ([]byte, error) {
-	return []byte(ver.String()), nil
+	return ver.AppendText(nil)
 }
 
 // Cmp compares two semantic versions:
@@ -112,10 +269,11 @@ func (ver Semver) MarshalText() ([]byte, error) {
 // - > 0 if a > b
 // - == 0 if a == b
 //
-// In order of importance: Major > Minor > Patch > Prerelease (Build ignored)
+// In order of importance: Major > Minor > Patch > Prerelease (Build ignored,
+// including the +incompatible suffix used by Go modules)
 //
 // Major, Minor and Patch are compared numerically.
-// Prerelease is compared by splitting on the . and:
+// Prerelease is compared by splitting on the . and comparing each Identifier:
 // - comparing identifiers lexically (in ASCII sort order)
 // - comparing numeric identifiers numerically
 // Numeric identifiers have lower precedence
@@ -130,43 +288,49 @@ func (a Semver) Cmp(b Semver) int {
 		return a.Patch - b.Patch
 	}
 
-	if a.Prerelease == "" {
-		if b.Prerelease == "" {
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+// comparePrerelease compares two prerelease strings identifier by identifier,
+// without splitting them up front. A missing prerelease has higher precedence.
+func comparePrerelease(a, b string) int {
+	if a == "" {
+		if b == "" {
 			return 0
 		}
 		return 1
-	} else if b.Prerelease == "" {
-		// a.Prerelease != ""
+	} else if b == "" {
+		// a != ""
 		return -1
 	}
+	return compareIdentifiers(a, b)
+}
 
-	partsA := strings.Split(a.Prerelease, ".")
-	partsB := strings.Split(b.Prerelease, ".")
-	total := len(partsA)
-	if len(partsB) < total {
-		total = len(partsB)
-	}
-	for i := 0; i < total; i++ {
-		sa, sb := partsA[i], partsB[i]
-		ai, errA := strconv.Atoi(sa)
-		bi, errB := strconv.Atoi(sb)
-
-		if errA != nil && errB != nil {
-			if sa < sb {
-				return -1
-			} else if sa > sb {
+// compareIdentifiers compares non-empty dot-separated identifier lists by
+// prerelease precedence.
+func compareIdentifiers(a, b string) int {
+	for {
+		ia, restA, moreA := cutIdentifier(a)
+		ib, restB, moreB := cutIdentifier(b)
+		if c := ia.Cmp(ib); c != 0 {
+			return c
+		}
+		if !moreA || !moreB {
+			if moreA {
 				return 1
+			} else if moreB {
+				return -1
 			}
-		} else if errA == nil && errB == nil {
-			if ai != bi {
-				return ai - bi
-			}
-		} else if errA != nil {
-			return 1
-		} else if errB != nil {
-			return -1
+			return 0
 		}
+		a, b = restA, restB
 	}
+}
 
-	return len(partsA) - len(partsB)
+// cutIdentifier slices the first identifier off a dot-separated list.
+func cutIdentifier(s string) (id Identifier, rest string, more bool) {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return Identifier(s[:i]), s[i+1:], true
+	}
+	return Identifier(s), "", false
 }
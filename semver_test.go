@@ -2,6 +2,8 @@ package semver
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"testing"
 )
 
@@ -45,6 +47,7 @@ func TestParseInvalid(t *testing.T) {
 		{"-1.0.0", "negative major version"},
 		{"0.-1.0", "negative minor version"},
 		{"0.0.-1", "negative patch version"},
+		{"1x2x3", "non-dot separators"},
 	}
 
 	for _, test := range tests {
@@ -122,6 +125,10 @@ func TestUnmarshalJson(t *testing.T) {
 		{`"1.0.0+test"`, Semver{Major: 1, Build: "build"}, "build"},
 		{`"1.0.0-blah+test"`, Semver{Major: 1, Prerelease: "blah", Build: "build"}, "prerelease and build"},
 		{`{"major": 1, "minor": 0, "patch": 0, "prerelease": "blah", "build": "test"}`, Semver{Major: 1, Prerelease: "blah", Build: "build"}, "prerelease and build"},
+		{` "1.2.3"`, Semver{Major: 1, Minor: 2, Patch: 3}, "leading whitespace"},
+		{`{"Major": 1, "Minor": 2, "Patch": 3}`, Semver{Major: 1, Minor: 2, Patch: 3}, "capitalized keys"},
+		{`{"major": 0, "minor": 0, "patch": 0}`, Semver{}, "0.0.0"},
+		{`"1.2.3\u002drc.1"`, Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, "escaped string"},
 	}
 
 	for _, test := range good {
@@ -138,6 +145,18 @@ func TestUnmarshalJson(t *testing.T) {
 		{`"a.0.0"`, "major not a number"},
 		{`"1.b.0"`, "minor not a number"},
 		{`"1.0.c"`, "patch not a number"},
+		{`123`, "wrong type (number)"},
+		{`true`, "wrong type (bool)"},
+		{`"1.2.3`, "unterminated string"},
+		{`{"major": 1, "minor": 0}`, "missing patch"},
+		{`{"major": 1, "minor": 0, "patch": 0, "extra": true}`, "unknown key"},
+		{`{"major": 1, "minor": 0, "patch": 0, "Major": 2}`, "repeated key"},
+		{`{"major": 1, "major": 2, "minor": 0, "patch": 0}`, "exact repeated key"},
+		{`{"major": "1", "minor": 0, "patch": 0}`, "major is a string"},
+		{`{"major": 1.5, "minor": 0, "patch": 0}`, "major is fractional"},
+		{`{"major": null, "minor": 0, "patch": 0}`, "major is null"},
+		{`{"major": 1, "minor": 0, "patch": 0, "prerelease": "a b"}`, "invalid prerelease"},
+		{`{"major": 1, "minor": 0, "patch": 0, "build": 5}`, "build is a number"},
 	}
 
 	for _, test := range bad {
@@ -148,6 +167,62 @@ func TestUnmarshalJson(t *testing.T) {
 	}
 }
 
+func TestMarshalJson(t *testing.T) {
+	tests := []stringTest{
+		{Semver{1, 2, 3, "", ""}, `"1.2.3"`, "basic"},
+		{Semver{1, 2, 3, "rc.1", "build.5"}, `"1.2.3-rc.1+build.5"`, "prerelease and build"},
+		{Semver{1, 2, 3, `"quoted"`, ""}, `"1.2.3-\"quoted\""`, "escaped"},
+	}
+
+	for _, test := range tests {
+		b, err := json.Marshal(test.given)
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+		} else if string(b) != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, b, test.exp)
+		}
+	}
+
+	// and as a field, round tripping through a plain string
+	type release struct {
+		Version Semver `json:"version"`
+	}
+	b, _ := json.Marshal(release{MustParse("1.2.3-rc.1")})
+	if string(b) != `{"version":"1.2.3-rc.1"}` {
+		t.Errorf("unexpected field encoding: %s", b)
+	}
+	var r release
+	if err := json.Unmarshal(b, &r); err != nil || r.Version != MustParse("1.2.3-rc.1") {
+		t.Errorf("round trip: %+v, %v", r, err)
+	}
+}
+
+// Semver has no cached copy of its input, so the encoding always reflects the
+// current components, even ones modified after parsing.
+func TestMarshalJsonModified(t *testing.T) {
+	v := MustParse("v1.2.3-rc.1+build.5")
+	v.Minor = 4
+	v.Prerelease = ""
+	b, err := json.Marshal(v)
+	if err != nil || string(b) != `"1.4.3+build.5"` {
+		t.Errorf("unexpected encoding: %s, %v", b, err)
+	}
+	var back Semver
+	if err := json.Unmarshal(b, &back); err != nil || back != v {
+		t.Errorf("round trip: %+v, %v", back, err)
+	}
+}
+
+func TestUnmarshalJsonNull(t *testing.T) {
+	ver := MustParse("1.2.3")
+	if err := json.Unmarshal([]byte(`null`), &ver); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if ver != MustParse("1.2.3") {
+		t.Errorf("null should leave the value unchanged: %s", ver)
+	}
+}
+
 func TestUnmarshalText(t *testing.T) {
 	good := []goodJsonTest{
 		{"1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}, "basic"},
@@ -230,3 +305,91 @@ func TestMustParseInvalid(t *testing.T) {
 	MustParse("1.3.4.0.2")
 	t.Error("MustParse should have panicked for invalid semver string")
 }
+
+func TestParseAll(t *testing.T) {
+	vs, err := ParseAll([]string{"1.0.0", "a.0.0", "v2.0.0", "-1.0.0"})
+	if len(vs) != 4 {
+		t.Fatalf("expected one result per input, got %d", len(vs))
+	}
+	if vs[0] != (Semver{Major: 1}) || vs[2] != (Semver{Major: 2}) {
+		t.Errorf("valid inputs not parsed: %+v", vs)
+	}
+
+	var errs ParseErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ParseErrors, got %T: %v", err, err)
+	}
+	if len(errs) != 2 || errs[0].Index != 1 || errs[0].Input != "a.0.0" || errs[1].Index != 3 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Index != 1 {
+		t.Errorf("expected first *ParseError to be reachable through Unwrap, got %v", perr)
+	}
+
+	if _, err := ParseAll([]string{"1.0.0", "1.2.3-rc.1"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTextInterfaces(t *testing.T) {
+	// map keys use the text interfaces
+	m := map[Semver]string{MustParse("1.2.3-rc.1"): "rc"}
+	b, err := json.Marshal(m)
+	if err != nil || string(b) != `{"1.2.3-rc.1":"rc"}` {
+		t.Errorf("map key encoding: %s, %v", b, err)
+	}
+	var m2 map[Semver]string
+	if err := json.Unmarshal(b, &m2); err != nil || m2[MustParse("1.2.3-rc.1")] != "rc" {
+		t.Errorf("map key decoding: %v, %v", m2, err)
+	}
+	if err := json.Unmarshal([]byte(`{"bogus":"x"}`), &m2); err == nil {
+		t.Errorf("expected error for invalid map key")
+	}
+
+	// as do XML attributes
+	type release struct {
+		Version Semver `xml:"version,attr"`
+	}
+	b, err = xml.Marshal(release{MustParse("2.0.0+build.1")})
+	if err != nil || string(b) != `<release version="2.0.0+build.1"></release>` {
+		t.Errorf("xml attribute encoding: %s, %v", b, err)
+	}
+	var r release
+	if err := xml.Unmarshal(b, &r); err != nil || r.Version != MustParse("2.0.0+build.1") {
+		t.Errorf("xml attribute decoding: %+v, %v", r, err)
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	buf := []byte("version=")
+	for _, s := range []string{"0.0.0", "1.2.3", "1.2.3-rc.1", "1.2.3+build.5", "10.20.30-alpha.1+sha.abc"} {
+		b, err := MustParse(s).AppendText(buf)
+		if err != nil || string(b) != "version="+s {
+			t.Errorf("%s: appended %q, %v", s, b, err)
+		}
+	}
+
+	v := MustParse("1.2.3-rc.1+build.5")
+	buf = make([]byte, 0, 64)
+	if n := testing.AllocsPerRun(100, func() { v.AppendText(buf[:0]) }); n != 0 {
+		t.Errorf("AppendText allocated %v times", n)
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = v.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = v.String()
+	}
+}
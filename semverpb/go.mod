@@ -0,0 +1,10 @@
+module github.com/beatgammit/semver/semverpb
+
+go 1.25.0
+
+require (
+	github.com/beatgammit/semver v0.0.0-00010101000000-000000000000
+	google.golang.org/protobuf v1.36.11
+)
+
+replace github.com/beatgammit/semver => ..
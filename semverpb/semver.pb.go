@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: semver.proto
+
+package semverpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Version is a semantic version, see http://semver.org
+type Version struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Major uint64                 `protobuf:"varint,1,opt,name=major,proto3" json:"major,omitempty"`
+	Minor uint64                 `protobuf:"varint,2,opt,name=minor,proto3" json:"minor,omitempty"`
+	Patch uint64                 `protobuf:"varint,3,opt,name=patch,proto3" json:"patch,omitempty"`
+	// Prerelease identifiers, dot separated, without the leading -.
+	Prerelease string `protobuf:"bytes,4,opt,name=prerelease,proto3" json:"prerelease,omitempty"`
+	// Build metadata, dot separated, without the leading +.
+	Build         string `protobuf:"bytes,5,opt,name=build,proto3" json:"build,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Version) Reset() {
+	*x = Version{}
+	mi := &file_semver_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Version) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Version) ProtoMessage() {}
+
+func (x *Version) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Version.ProtoReflect.Descriptor instead.
+func (*Version) Descriptor() ([]byte, []int) {
+	return file_semver_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Version) GetMajor() uint64 {
+	if x != nil {
+		return x.Major
+	}
+	return 0
+}
+
+func (x *Version) GetMinor() uint64 {
+	if x != nil {
+		return x.Minor
+	}
+	return 0
+}
+
+func (x *Version) GetPatch() uint64 {
+	if x != nil {
+		return x.Patch
+	}
+	return 0
+}
+
+func (x *Version) GetPrerelease() string {
+	if x != nil {
+		return x.Prerelease
+	}
+	return ""
+}
+
+func (x *Version) GetBuild() string {
+	if x != nil {
+		return x.Build
+	}
+	return ""
+}
+
+var File_semver_proto protoreflect.FileDescriptor
+
+const file_semver_proto_rawDesc = "" +
+	"\n" +
+	"\fsemver.proto\x12\x06semver\"\x81\x01\n" +
+	"\aVersion\x12\x14\n" +
+	"\x05major\x18\x01 \x01(\x04R\x05major\x12\x14\n" +
+	"\x05minor\x18\x02 \x01(\x04R\x05minor\x12\x14\n" +
+	"\x05patch\x18\x03 \x01(\x04R\x05patch\x12\x1e\n" +
+	"\n" +
+	"prerelease\x18\x04 \x01(\tR\n" +
+	"prerelease\x12\x14\n" +
+	"\x05build\x18\x05 \x01(\tR\x05buildB'Z%github.com/beatgammit/semver/semverpbb\x06proto3"
+
+var (
+	file_semver_proto_rawDescOnce sync.Once
+	file_semver_proto_rawDescData []byte
+)
+
+func file_semver_proto_rawDescGZIP() []byte {
+	file_semver_proto_rawDescOnce.Do(func() {
+		file_semver_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_semver_proto_rawDesc), len(file_semver_proto_rawDesc)))
+	})
+	return file_semver_proto_rawDescData
+}
+
+var file_semver_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_semver_proto_goTypes = []any{
+	(*Version)(nil), // 0: semver.Version
+}
+var file_semver_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_semver_proto_init() }
+func file_semver_proto_init() {
+	if File_semver_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_semver_proto_rawDesc), len(file_semver_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_semver_proto_goTypes,
+		DependencyIndexes: file_semver_proto_depIdxs,
+		MessageInfos:      file_semver_proto_msgTypes,
+	}.Build()
+	File_semver_proto = out.File
+	file_semver_proto_goTypes = nil
+	file_semver_proto_depIdxs = nil
+}
@@ -0,0 +1,50 @@
+// Package semverpb carries semantic versions over protocol buffers, as the
+// Version message defined in semver.proto. The message is generated by
+// protoc-gen-go into semver.pb.go; ToProto and FromProto convert between it
+// and semver.Semver, validating the version.
+package semverpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative semver.proto
+
+import (
+	"fmt"
+
+	"github.com/beatgammit/semver"
+)
+
+const maxInt = int(^uint(0) >> 1)
+
+// ToProto converts v to a message, after validating it.
+func ToProto(v semver.Semver) (*Version, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return &Version{
+		Major:      uint64(v.Major),
+		Minor:      uint64(v.Minor),
+		Patch:      uint64(v.Patch),
+		Prerelease: v.Prerelease,
+		Build:      v.Build,
+	}, nil
+}
+
+// FromProto converts a message to a Semver and validates it. A nil message is
+// an error.
+func FromProto(m *Version) (semver.Semver, error) {
+	if m == nil {
+		return semver.Semver{}, fmt.Errorf("Missing version")
+	}
+	for _, n := range []uint64{m.Major, m.Minor, m.Patch} {
+		if n > uint64(maxInt) {
+			return semver.Semver{}, fmt.Errorf("Version number out of range: %d", n)
+		}
+	}
+	v := semver.Semver{
+		Major:      int(m.Major),
+		Minor:      int(m.Minor),
+		Patch:      int(m.Patch),
+		Prerelease: m.Prerelease,
+		Build:      m.Build,
+	}
+	return v, v.Validate()
+}
@@ -0,0 +1,83 @@
+package semverpb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/beatgammit/semver"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ proto.Message = (*Version)(nil)
+
+func TestRoundTrip(t *testing.T) {
+	v := semver.MustParse("1.2.300-rc.1+build.5")
+	m, err := ToProto(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// as protoc would encode it
+	exp := []byte("\x08\x01\x10\x02\x18\xac\x02\x22\x04rc.1\x2a\x07build.5")
+	if !bytes.Equal(b, exp) {
+		t.Errorf("%q != %q", b, exp)
+	}
+
+	var out Version
+	if err := proto.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	w, err := FromProto(&out)
+	if err != nil || w != v {
+		t.Errorf("round trip: %+v, %v", w, err)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	// unknown fields of every wire type are kept
+	b := []byte("\x08\x01\x30\x05\x39\x00\x00\x00\x00\x00\x00\x00\x00\x42\x01x\x4d\x00\x00\x00\x00\x10\x02")
+	var m Version
+	if err := proto.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.GetMajor() != 1 || m.GetMinor() != 2 || m.GetPatch() != 0 || m.GetPrerelease() != "" {
+		t.Errorf("unexpected message: %v", &m)
+	}
+	if n := len(m.ProtoReflect().GetUnknown()); n != 19 {
+		t.Errorf("expected the unknown fields to be kept, got %d bytes", n)
+	}
+
+	bad := [][]byte{
+		[]byte("\x08"),
+		[]byte("\x22\x05rc"),
+		[]byte("\x22\x01\xff"),
+		[]byte("\x0b"),
+	}
+	for _, b := range bad {
+		if err := proto.Unmarshal(b, &m); err == nil {
+			t.Errorf("%q: expected error", b)
+		}
+	}
+}
+
+func TestValidation(t *testing.T) {
+	if _, err := ToProto(semver.Semver{Major: -1}); err == nil {
+		t.Errorf("expected error converting an invalid Semver")
+	}
+	if _, err := FromProto(nil); err == nil {
+		t.Errorf("expected error for nil message")
+	}
+	if _, err := FromProto(&Version{Major: 1, Prerelease: "a b"}); err == nil {
+		t.Errorf("expected error for invalid prerelease")
+	}
+	if _, err := FromProto(&Version{Major: 1 << 63}); err == nil {
+		t.Errorf("expected error for out of range major")
+	}
+	var m *Version
+	if m.GetMajor() != 0 || m.GetPrerelease() != "" {
+		t.Errorf("getters should be nil-safe")
+	}
+}
@@ -0,0 +1,107 @@
+// Package semvertest provides a seed corpus and invariant checks for fuzzing
+// code that parses semantic versions, whether that's semver.Parse itself or a
+// wrapper around it.
+package semvertest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+// Valid are version strings semver.Parse accepts.
+var Valid = []string{
+	"1.0.0",
+	"v1.0.0",
+	"0.0.1",
+	"0.0.0",
+	"0.0.0-20240101120000-abcdef123456",
+	"1.2.3-rc.1",
+	"1.2.3-alpha.beta.1",
+	"1.2.3-0.3.7",
+	"1.2.3-x-y-z.--",
+	"1.2.3+build.5",
+	"1.2.3-rc.1+build.5",
+	"1.2.3+001",
+	"2.0.1+incompatible",
+	"1.2.4-0.20240101120000-abcdef123456",
+	"10.20.30",
+	"9223372036854775807.0.0",
+}
+
+// Adversarial are edge cases that have tripped up version parsers. Some are
+// accepted, most are not; either way parsing them must not panic, and anything
+// accepted must satisfy CheckRoundTrip.
+var Adversarial = []string{
+	"",
+	"v",
+	"1",
+	"1.2",
+	"1.2.3.4",
+	"1x2x3",
+	"1.2.3-",
+	"1.2.3+",
+	"1.2.3-+",
+	"1.2.3-rc..1",
+	"1.2.3-rc.1+",
+	"01.02.03",
+	"-1.0.0",
+	"1.-2.3",
+	"+1.2.3",
+	" 1.2.3",
+	"1.2.3 ",
+	"V1.2.3",
+	"vv1.2.3",
+	"1.2.3-é",
+	"1.2.3\u20101",
+	"\uFEFF1.2.3",
+	"99999999999999999999.0.0",
+	"1.2.3-99999999999999999999",
+	"1.2.3\x00",
+	"\"1.2.3\"",
+	"{}",
+}
+
+// Seeds returns the whole corpus, valid inputs first.
+func Seeds() []string {
+	return append(append([]string(nil), Valid...), Adversarial...)
+}
+
+// CheckRoundTrip reports an error if v doesn't survive formatting and
+// reparsing: Parse(v.String()) must succeed, equal v and compare equal to it.
+func CheckRoundTrip(v semver.Semver) error {
+	s := v.String()
+	w, err := semver.Parse(s)
+	if err != nil {
+		return fmt.Errorf("%+v formats as %q, which doesn't parse: %s", v, s, err)
+	}
+	if w != v {
+		return fmt.Errorf("%+v formats as %q, which parses as %+v", v, s, w)
+	}
+	if c := v.Cmp(w); c != 0 {
+		return fmt.Errorf("%+v compares %d to its own round trip", v, c)
+	}
+	return nil
+}
+
+// FuzzParse seeds f with the corpus and fuzzes parse, checking that every
+// version it accepts satisfies CheckRoundTrip. Call it from a fuzz target:
+//
+//	func FuzzParse(f *testing.F) {
+//		semvertest.FuzzParse(f, mypkg.ParseVersion)
+//	}
+func FuzzParse(f *testing.F, parse func(string) (semver.Semver, error)) {
+	for _, s := range Seeds() {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := parse(s)
+		if err != nil {
+			return
+		}
+		if err := CheckRoundTrip(v); err != nil {
+			t.Errorf("given %q: %s", s, err)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package semvertest
+
+import (
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+func TestValid(t *testing.T) {
+	for _, s := range Valid {
+		v, err := semver.Parse(s)
+		if err != nil {
+			t.Errorf("%q: %s", s, err)
+			continue
+		}
+		if err := CheckRoundTrip(v); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAdversarial(t *testing.T) {
+	for _, s := range Adversarial {
+		if v, err := semver.Parse(s); err == nil {
+			if err := CheckRoundTrip(v); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/beatgammit/semver/semveryaml
+
+go 1.25.0
+
+require (
+	github.com/beatgammit/semver v0.0.0-00010101000000-000000000000
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/beatgammit/semver => ..
@@ -0,0 +1,67 @@
+// Package semveryaml decodes versions with gopkg.in/yaml.v3 through its node
+// interface, so that a bad version in a config file is reported with the
+// line and column it appears at.
+//
+// semver.Semver decodes from YAML without this package: it implements the
+// callback form of UnmarshalYAML, which yaml.v3 still calls, so that the
+// semver package itself doesn't depend on either YAML library. Use Version
+// for fields where the position of an error matters.
+package semveryaml
+
+import (
+	"fmt"
+
+	"github.com/beatgammit/semver"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ yaml.Marshaler   = Version{}
+	_ yaml.Unmarshaler = (*Version)(nil)
+)
+
+// Version is a semver.Semver that decodes from a yaml.v3 node.
+type Version semver.Semver
+
+// Semver returns v as a semver.Semver.
+func (v Version) Semver() semver.Semver {
+	return semver.Semver(v)
+}
+
+func (v Version) String() string {
+	return semver.Semver(v).String()
+}
+
+// MarshalYAML encodes the version as a string scalar.
+func (v Version) MarshalYAML() (interface{}, error) {
+	return Node(semver.Semver(v)), nil
+}
+
+// UnmarshalYAML decodes a scalar node with semver.Parse, leaving v unchanged
+// on error. Errors give the node's position.
+func (v *Version) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := Decode(node)
+	if err == nil {
+		*v = Version(parsed)
+	}
+	return err
+}
+
+// Decode parses the version in a scalar node. Errors give the node's
+// position.
+func Decode(node *yaml.Node) (semver.Semver, error) {
+	if node.Kind != yaml.ScalarNode {
+		return semver.Semver{}, fmt.Errorf("Invalid version at line %d, column %d: expected a scalar", node.Line, node.Column)
+	}
+	v, err := semver.Parse(node.Value)
+	if err != nil {
+		return semver.Semver{}, fmt.Errorf("Invalid version at line %d, column %d: %s", node.Line, node.Column, err)
+	}
+	return v, nil
+}
+
+// Node returns v as a string scalar node, for building documents with
+// yaml.v3's node API.
+func Node(v semver.Semver) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.String()}
+}
@@ -0,0 +1,86 @@
+package semveryaml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beatgammit/semver"
+	"gopkg.in/yaml.v3"
+)
+
+type config struct {
+	Version Version       `yaml:"version"`
+	Min     semver.Semver `yaml:"min"`
+	Tag     semver.Tag    `yaml:"tag"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	tag, _ := semver.ParseTag("v1.2.3")
+	in := config{
+		Version: Version(semver.MustParse("1.2.3-rc.1+build.5")),
+		Min:     semver.MustParse("1.0.0"),
+		Tag:     tag,
+	}
+	b, err := yaml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "version: 1.2.3-rc.1+build.5\nmin: 1.0.0\ntag: v1.2.3\n"; string(b) != exp {
+		t.Errorf("%q != %q", b, exp)
+	}
+
+	var out config
+	if err := yaml.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("%+v != %+v", out, in)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		given  string
+		exp    string
+		reason string
+	}{
+		{"version: 1.2.3\nmin: 1.0.0\n---\n", "", "valid"},
+		{"min: 1.0.0\nversion: 1.2\n", "line 2, column 10", "partial version"},
+		{"version:\n  major: 1\n", "line 2, column 3", "mapping"},
+		{"version: [1, 2, 3]\n", "line 1, column 10", "sequence"},
+	}
+
+	for _, test := range tests {
+		var c config
+		err := yaml.Unmarshal([]byte(test.given), &c)
+		if test.exp == "" {
+			if err != nil {
+				t.Errorf("%s: %s", test.reason, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.exp) {
+			t.Errorf("%s: expected an error at %s, got %v", test.reason, test.exp, err)
+		}
+	}
+
+	// the callback form still validates under yaml.v3
+	var c config
+	if err := yaml.Unmarshal([]byte("min: 1.2\n"), &c); err == nil {
+		t.Errorf("expected error, got %+v", c)
+	}
+}
+
+func TestNode(t *testing.T) {
+	doc := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "version"},
+		Node(semver.MustParse("1.10.0")),
+	}}
+	b, err := yaml.Marshal(doc)
+	if err != nil || string(b) != "version: 1.10.0\n" {
+		t.Errorf("%q, %v", b, err)
+	}
+	if v, err := Decode(doc.Content[1]); err != nil || v != semver.MustParse("1.10.0") {
+		t.Errorf("Decode: %s, %v", v, err)
+	}
+}
@@ -0,0 +1,121 @@
+package semver
+
+// Set is a set of versions. Members are compared by Equal, so adding
+// v1.2.3+build to a set holding 1.2.3 does nothing; the version first added
+// is the one kept. The zero Set is empty and ready to use.
+type Set struct {
+	m map[Key]Semver
+}
+
+// NewSet returns a set holding versions.
+func NewSet(versions ...Semver) *Set {
+	s := &Set{m: make(map[Key]Semver, len(versions))}
+	for _, v := range versions {
+		s.Add(v)
+	}
+	return s
+}
+
+// Add adds v to the set, reporting whether it wasn't already a member.
+func (s *Set) Add(v Semver) bool {
+	k := v.Key()
+	if _, ok := s.m[k]; ok {
+		return false
+	}
+	if s.m == nil {
+		s.m = make(map[Key]Semver)
+	}
+	s.m[k] = v
+	return true
+}
+
+// Remove removes v from the set, reporting whether it was a member.
+func (s *Set) Remove(v Semver) bool {
+	k := v.Key()
+	if _, ok := s.m[k]; !ok {
+		return false
+	}
+	delete(s.m, k)
+	return true
+}
+
+// Contains reports whether v is a member of the set.
+func (s *Set) Contains(v Semver) bool {
+	_, ok := s.m[v.Key()]
+	return ok
+}
+
+// Len returns the number of versions in the set.
+func (s *Set) Len() int {
+	return len(s.m)
+}
+
+// Versions returns the members in ascending order.
+func (s *Set) Versions() Versions {
+	vs := make(Versions, 0, len(s.m))
+	for _, v := range s.m {
+		vs = append(vs, v)
+	}
+	vs.Sort()
+	return vs
+}
+
+// Match returns the members that satisfy c, in ascending order.
+func (s *Set) Match(c Constraint) Versions {
+	var vs Versions
+	for _, v := range s.m {
+		if c.Check(v) {
+			vs = append(vs, v)
+		}
+	}
+	vs.Sort()
+	return vs
+}
+
+// Any reports whether any member satisfies c.
+func (s *Set) Any(c Constraint) bool {
+	for _, v := range s.m {
+		if c.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns a new set with the members of either s or t. Where both hold
+// a version, s's is kept.
+func (s *Set) Union(t *Set) *Set {
+	u := NewSet()
+	for k, v := range s.m {
+		u.m[k] = v
+	}
+	for k, v := range t.m {
+		if _, ok := u.m[k]; !ok {
+			u.m[k] = v
+		}
+	}
+	return u
+}
+
+// Intersect returns a new set with the members of both s and t, as they
+// appear in s.
+func (s *Set) Intersect(t *Set) *Set {
+	u := NewSet()
+	for k, v := range s.m {
+		if _, ok := t.m[k]; ok {
+			u.m[k] = v
+		}
+	}
+	return u
+}
+
+// Difference returns a new set with the members of s that aren't in t.
+func (s *Set) Difference(t *Set) *Set {
+	u := NewSet()
+	for k, v := range s.m {
+		if _, ok := t.m[k]; !ok {
+			u.m[k] = v
+		}
+	}
+	return u
+}
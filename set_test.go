@@ -0,0 +1,62 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func parseSet(ss ...string) *Set {
+	s := NewSet()
+	for _, v := range ss {
+		s.Add(MustParse(v))
+	}
+	return s
+}
+
+func TestSet(t *testing.T) {
+	var s Set
+	if !s.Add(MustParse("1.2.3")) || s.Add(MustParse("v1.2.3+build")) {
+		t.Errorf("equal versions should only be added once")
+	}
+	if !s.Contains(MustParse("1.2.3+other")) || s.Contains(MustParse("1.2.4")) {
+		t.Errorf("unexpected membership")
+	}
+	if got := s.Versions(); len(got) != 1 || got[0].Build != "" {
+		t.Errorf("the first version added should be kept: %v", got)
+	}
+	if !s.Remove(MustParse("1.2.3+build")) || s.Remove(MustParse("1.2.3")) || s.Len() != 0 {
+		t.Errorf("unexpected removal")
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	mirror := parseSet("1.0.0", "1.1.0", "1.2.0", "2.0.0-rc.1")
+	origin := parseSet("1.1.0+build", "1.2.0", "1.3.0", "2.0.0-rc.1")
+
+	tests := []struct {
+		set    *Set
+		exp    []string
+		reason string
+	}{
+		{mirror.Union(origin), []string{"1.0.0", "1.1.0", "1.2.0", "1.3.0", "2.0.0-rc.1"}, "union"},
+		{origin.Intersect(mirror), []string{"1.1.0+build", "1.2.0", "2.0.0-rc.1"}, "intersection"},
+		{origin.Difference(mirror), []string{"1.3.0"}, "origin minus mirror"},
+		{mirror.Difference(origin), []string{"1.0.0"}, "mirror minus origin"},
+	}
+
+	for _, test := range tests {
+		if got := test.set.Versions().Strings(); !reflect.DeepEqual(got, test.exp) {
+			t.Errorf("%s: %q != %q", test.reason, got, test.exp)
+		}
+	}
+	if mirror.Len() != 4 || origin.Len() != 4 {
+		t.Errorf("operands should not be modified")
+	}
+
+	if got := origin.Match(MustParseConstraint("~1.1 || >=1.3")).Strings(); !reflect.DeepEqual(got, []string{"1.1.0+build", "1.3.0"}) {
+		t.Errorf("unexpected match: %q", got)
+	}
+	if !origin.Any(MustParseConstraint("^1.3")) || origin.Any(MustParseConstraint("^2")) {
+		t.Errorf("unexpected Any")
+	}
+}
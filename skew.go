@@ -0,0 +1,58 @@
+package semver
+
+import "fmt"
+
+// SkewPolicy bounds how far apart the minor versions of two components, such
+// as a client and the server it talks to, may drift. Kubernetes' rule that a
+// kubelet may be up to three minor versions older than the API server, but
+// never newer, is SkewPolicy{MaxBehind: 3}. Components with different major
+// versions are always out of policy.
+type SkewPolicy struct {
+	MaxBehind int // how many minor versions the client may be older
+	MaxAhead  int // how many minor versions the client may be newer
+}
+
+// SkewVerdict is the result of checking two versions against a SkewPolicy.
+type SkewVerdict struct {
+	Allowed bool
+	// Skew is how many minor versions the client is ahead of the server,
+	// negative if it's behind. It's 0 if the major versions differ.
+	Skew int
+	// MajorMismatch is true if the major versions differ.
+	MajorMismatch bool
+	// Reason explains why the versions are out of policy, and is empty if
+	// they're allowed.
+	Reason string
+}
+
+// Err returns an error with the verdict's reason, or nil if the versions are
+// allowed.
+func (v SkewVerdict) Err() error {
+	if v.Allowed {
+		return nil
+	}
+	return fmt.Errorf("Version skew not allowed: %s", v.Reason)
+}
+
+// Validate checks the skew between a client at version client and a server
+// at version server. Patch versions, prereleases and build metadata don't
+// count.
+func (p SkewPolicy) Validate(client, server Semver) SkewVerdict {
+	if client.Major != server.Major {
+		return SkewVerdict{
+			MajorMismatch: true,
+			Reason:        fmt.Sprintf("client %d.x and server %d.x have different major versions", client.Major, server.Major),
+		}
+	}
+	verdict := SkewVerdict{Allowed: true, Skew: client.Minor - server.Minor}
+	c, s := fmt.Sprintf("%d.%d", client.Major, client.Minor), fmt.Sprintf("%d.%d", server.Major, server.Minor)
+	switch {
+	case -verdict.Skew > p.MaxBehind:
+		verdict.Allowed = false
+		verdict.Reason = fmt.Sprintf("client %s is %d minor versions behind server %s, more than %d", c, -verdict.Skew, s, p.MaxBehind)
+	case verdict.Skew > p.MaxAhead:
+		verdict.Allowed = false
+		verdict.Reason = fmt.Sprintf("client %s is %d minor versions ahead of server %s, more than %d", c, verdict.Skew, s, p.MaxAhead)
+	}
+	return verdict
+}
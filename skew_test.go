@@ -0,0 +1,38 @@
+package semver
+
+import "testing"
+
+func TestSkewPolicy(t *testing.T) {
+	p := SkewPolicy{MaxBehind: 2, MaxAhead: 1}
+	tests := []struct {
+		client  string
+		server  string
+		allowed bool
+		skew    int
+		reason  string
+	}{
+		{"1.28.3", "1.28.0", true, 0, ""},
+		{"1.26.0-rc.1", "1.28.4", true, -2, ""},
+		{"1.25.9", "1.28.0", false, -3, "client 1.25 is 3 minor versions behind server 1.28, more than 2"},
+		{"1.29.0", "1.28.0", true, 1, ""},
+		{"1.30.0", "1.28.0", false, 2, "client 1.30 is 2 minor versions ahead of server 1.28, more than 1"},
+		{"2.0.0", "1.28.0", false, 0, "client 2.x and server 1.x have different major versions"},
+	}
+
+	for _, test := range tests {
+		v := p.Validate(MustParse(test.client), MustParse(test.server))
+		if v.Allowed != test.allowed || v.Skew != test.skew || v.Reason != test.reason {
+			t.Errorf("%s vs %s: %+v", test.client, test.server, v)
+		}
+		if (v.Err() == nil) != test.allowed {
+			t.Errorf("%s vs %s: Err() = %v", test.client, test.server, v.Err())
+		}
+	}
+
+	if v := (SkewPolicy{MaxBehind: 3}).Validate(MustParse("1.29.0"), MustParse("1.28.0")); v.Allowed {
+		t.Errorf("a client may never be ahead with MaxAhead 0: %+v", v)
+	}
+	if v := p.Validate(MustParse("2.0.0"), MustParse("1.28.0")); !v.MajorMismatch {
+		t.Errorf("expected a major mismatch: %+v", v)
+	}
+}
@@ -0,0 +1,270 @@
+// Package solver chooses versions of packages whose dependencies are given as
+// constraints, such as plugins that need ^1.2 of a shared library. Unlike
+// minimal version selection (see package mvs) the constraints may conflict,
+// so Solve searches for an assignment that satisfies all of them, preferring
+// the highest versions, and explains the conflict when there is none.
+package solver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beatgammit/semver"
+)
+
+// Deps maps the names of the packages a version depends on to the versions
+// of them it accepts.
+type Deps map[string]semver.Constraint
+
+// Source lists the available versions of packages and their dependencies.
+type Source interface {
+	// Versions returns the available versions of a package, in any order.
+	Versions(pkg string) ([]semver.Semver, error)
+	// Dependencies returns the dependencies of a version of a package.
+	Dependencies(pkg string, v semver.Semver) (Deps, error)
+}
+
+// Index is a Source held in memory, giving the dependencies of every
+// available version of each package.
+type Index map[string]map[semver.Semver]Deps
+
+// Versions returns the versions of pkg in the index.
+func (idx Index) Versions(pkg string) ([]semver.Semver, error) {
+	versions, ok := idx[pkg]
+	if !ok {
+		return nil, fmt.Errorf("Unknown package %s", pkg)
+	}
+	var vs []semver.Semver
+	for v := range versions {
+		vs = append(vs, v)
+	}
+	return vs, nil
+}
+
+// Dependencies returns the dependencies of pkg at version v.
+func (idx Index) Dependencies(pkg string, v semver.Semver) (Deps, error) {
+	deps, ok := idx[pkg][v]
+	if !ok {
+		return nil, fmt.Errorf("Unknown package %s@%s", pkg, v)
+	}
+	return deps, nil
+}
+
+// Requirement is a constraint on a package, made by another package's
+// version, or by the caller of Solve if From is empty.
+type Requirement struct {
+	From       string // e.g. "a@1.2.0", or "" for the root requirements
+	Constraint semver.Constraint
+}
+
+func (r Requirement) String() string {
+	from := r.From
+	if from == "" {
+		from = "root"
+	}
+	return fmt.Sprintf("%s requires %s", from, r.Constraint)
+}
+
+// Conflict is the error Solve returns when no assignment satisfies every
+// constraint. It names a package and a set of requirements on it that no
+// available version satisfies together. The set is minimal: dropping any
+// one of them leaves a version that satisfies the rest.
+type Conflict struct {
+	Package      string
+	Requirements []Requirement
+}
+
+func (c *Conflict) Error() string {
+	if len(c.Requirements) == 0 {
+		return fmt.Sprintf("No versions of %s are available", c.Package)
+	}
+	var reqs []string
+	for _, r := range c.Requirements {
+		reqs = append(reqs, r.String())
+	}
+	return fmt.Sprintf("No version of %s satisfies: %s", c.Package, strings.Join(reqs, ", "))
+}
+
+// Solve chooses a version of every package the root constraints need,
+// directly or through dependencies, such that every dependency of every
+// chosen version is satisfied. If there is no such assignment the error is
+// a *Conflict; errors from src are returned as they are.
+//
+// The search tries the highest versions first, always deciding next the
+// package with the fewest versions left, and after each decision checks
+// that every package it constrains still has a version available, so that
+// dead ends are abandoned early.
+func Solve(src Source, root Deps) (map[string]semver.Semver, error) {
+	s := &solver{
+		src:      src,
+		versions: make(map[string][]semver.Semver),
+		assigned: make(map[string]semver.Semver),
+		reqs:     make(map[string][]Requirement),
+		rejected: make(map[string][]Requirement),
+	}
+	ok, err := s.add("", root)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if ok, err = s.solve(); err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		return nil, s.conflict
+	}
+	return s.assigned, nil
+}
+
+type solver struct {
+	src      Source
+	versions map[string][]semver.Semver // available versions, highest first
+	assigned map[string]semver.Semver
+	reqs     map[string][]Requirement
+	rejected map[string][]Requirement // requirements that ruled out an assigned version
+	conflict *Conflict                // the smallest conflict found so far
+}
+
+// solve assigns versions to every package that has requirements but no
+// version yet, reporting whether it succeeded.
+func (s *solver) solve() (bool, error) {
+	next, candidates := "", []semver.Semver(nil)
+	for pkg, reqs := range s.reqs {
+		if _, ok := s.assigned[pkg]; ok {
+			continue
+		}
+		cs := s.candidates(pkg, reqs)
+		if next == "" || len(cs) < len(candidates) || len(cs) == len(candidates) && pkg < next {
+			next, candidates = pkg, cs
+		}
+	}
+	if next == "" {
+		return true, nil
+	}
+
+	for _, v := range candidates {
+		deps, err := s.src.Dependencies(next, v)
+		if err != nil {
+			return false, err
+		}
+		s.assigned[next] = v
+		saved := make(map[string]int, len(deps))
+		for pkg := range deps {
+			saved[pkg] = len(s.reqs[pkg])
+		}
+		ok, err := s.add(next+"@"+v.String(), deps)
+		if err == nil && ok {
+			ok, err = s.solve()
+		}
+		if err != nil || ok {
+			return ok, err
+		}
+		for pkg, n := range saved {
+			if n == 0 {
+				delete(s.reqs, pkg)
+			} else {
+				s.reqs[pkg] = s.reqs[pkg][:n]
+			}
+		}
+		delete(s.assigned, next)
+	}
+	// Every candidate has failed. Where each was ruled out by a dependency
+	// of a version chosen later, no single set of requirements conflicts,
+	// so record the requirements that ruled them out along with those in
+	// force.
+	s.record(next, append(append([]Requirement(nil), s.reqs[next]...), s.rejected[next]...))
+	return false, nil
+}
+
+// add records the requirements that from makes, reporting whether every
+// package they constrain can still be satisfied.
+func (s *solver) add(from string, deps Deps) (bool, error) {
+	ok := true
+	for _, pkg := range names(deps) {
+		s.reqs[pkg] = append(s.reqs[pkg], Requirement{from, deps[pkg]})
+		if !ok {
+			continue
+		}
+		if _, err := s.available(pkg); err != nil {
+			return false, err
+		}
+		if v, assigned := s.assigned[pkg]; assigned {
+			if ok = deps[pkg].Check(v); !ok {
+				s.rejected[pkg] = append(s.rejected[pkg], Requirement{from, deps[pkg]})
+				s.record(pkg, s.reqs[pkg])
+			}
+		} else if len(s.candidates(pkg, s.reqs[pkg])) == 0 {
+			s.record(pkg, s.reqs[pkg])
+			ok = false
+		}
+	}
+	return ok, nil
+}
+
+// available returns the versions of pkg, highest first, asking the source
+// only once.
+func (s *solver) available(pkg string) ([]semver.Semver, error) {
+	if vs, ok := s.versions[pkg]; ok {
+		return vs, nil
+	}
+	vs, err := s.src.Versions(pkg)
+	if err != nil {
+		return nil, err
+	}
+	sorted := append([]semver.Semver(nil), vs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) > 0 })
+	s.versions[pkg] = sorted
+	return sorted, nil
+}
+
+// candidates returns the versions of pkg that satisfy every one of reqs.
+func (s *solver) candidates(pkg string, reqs []Requirement) []semver.Semver {
+	var out []semver.Semver
+next:
+	for _, v := range s.versions[pkg] {
+		for _, r := range reqs {
+			if !r.Constraint.Check(v) {
+				continue next
+			}
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// record notes that no version of pkg satisfies reqs, keeping the smallest
+// conflict seen. reqs is first reduced to a minimal conflicting set by
+// dropping each requirement the rest conflict without. If some version does
+// satisfy reqs there is no conflict to record: the requirements only rule
+// out the version already chosen for pkg, and the search goes on to try the
+// others.
+func (s *solver) record(pkg string, reqs []Requirement) {
+	if len(s.candidates(pkg, reqs)) > 0 {
+		return
+	}
+	minimal := append([]Requirement(nil), reqs...)
+	for i := 0; i < len(minimal); {
+		rest := append(append([]Requirement(nil), minimal[:i]...), minimal[i+1:]...)
+		if len(s.candidates(pkg, rest)) == 0 {
+			minimal = rest
+		} else {
+			i++
+		}
+	}
+	if s.conflict == nil || len(minimal) < len(s.conflict.Requirements) {
+		s.conflict = &Conflict{pkg, minimal}
+	}
+}
+
+// names returns the names of the packages in deps, sorted so that the
+// search is deterministic.
+func names(deps Deps) []string {
+	var out []string
+	for pkg := range deps {
+		out = append(out, pkg)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,139 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+// index builds an Index from lines of "pkg@version: dep constraint; dep constraint"
+func index(lines ...string) Index {
+	idx := Index{}
+	for _, line := range lines {
+		parts := strings.SplitN(line, ":", 2)
+		at := strings.IndexByte(parts[0], '@')
+		pkg, v := parts[0][:at], semver.MustParse(parts[0][at+1:])
+		if idx[pkg] == nil {
+			idx[pkg] = map[semver.Semver]Deps{}
+		}
+		idx[pkg][v] = deps(parts[1])
+	}
+	return idx
+}
+
+// deps parses "dep constraint; dep constraint"
+func deps(s string) Deps {
+	d := Deps{}
+	for _, dep := range strings.Split(s, ";") {
+		if dep = strings.TrimSpace(dep); dep == "" {
+			continue
+		}
+		i := strings.IndexByte(dep, ' ')
+		d[dep[:i]] = semver.MustParseConstraint(dep[i+1:])
+	}
+	return d
+}
+
+func TestSolve(t *testing.T) {
+	idx := index(
+		"app@1.0.0: lib ^1.0.0; log ^1.0.0",
+		"app@2.0.0: lib ^2.0.0; log ^1.1.0",
+		"lib@1.0.0: util ^1.0.0",
+		"lib@1.5.0: util ^1.2.0",
+		"lib@2.0.0: util ^2.0.0",
+		"lib@2.1.0-rc.1: util ^2.0.0",
+		"log@1.0.0:",
+		"log@1.1.0: util ^1.0.0",
+		"log@1.2.0: util >=1.0.0",
+		"util@1.0.0:",
+		"util@1.3.0:",
+		"util@2.0.0:",
+	)
+	tests := []struct {
+		root   string
+		exp    map[string]string
+		reason string
+	}{
+		{"app ^1.0.0", map[string]string{"app": "1.0.0", "lib": "1.5.0", "log": "1.2.0", "util": "1.3.0"}, "highest versions"},
+		{"app ^2.0.0", map[string]string{"app": "2.0.0", "lib": "2.0.0", "log": "1.2.0", "util": "2.0.0"}, "prerelease skipped"},
+		{"app *; util ^1.0.0", map[string]string{"app": "1.0.0", "lib": "1.5.0", "log": "1.2.0", "util": "1.3.0"}, "backtracks to an older app"},
+		{"log <1.2.0; util ^2.0.0", map[string]string{"log": "1.0.0", "util": "2.0.0"}, "backtracks to a log that doesn't need util"},
+		{"", map[string]string{}, "nothing required"},
+	}
+
+	for _, test := range tests {
+		got, err := Solve(idx, deps(test.root))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+			continue
+		}
+		exp := map[string]semver.Semver{}
+		for pkg, v := range test.exp {
+			exp[pkg] = semver.MustParse(v)
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf("%s: %v != %v", test.reason, got, exp)
+		}
+	}
+}
+
+func TestSolveConflict(t *testing.T) {
+	idx := index(
+		"a@1.0.0: c ^1.0.0; d *",
+		"b@1.0.0: c ^2.0.0",
+		"c@1.0.0:",
+		"c@2.0.0:",
+		"d@1.0.0:",
+	)
+
+	_, err := Solve(idx, deps("a *; b *; d >=1"))
+	var c *Conflict
+	if !errors.As(err, &c) {
+		t.Fatalf("expected a *Conflict, got %v", err)
+	}
+	if exp := "No version of c satisfies: a@1.0.0 requires ^1.0.0, b@1.0.0 requires ^2.0.0"; err.Error() != exp {
+		t.Errorf("%q != %q", err, exp)
+	}
+
+	_, err = Solve(idx, deps("a *; c ^2.0.0; d >=1"))
+	if exp := "No version of c satisfies: root requires ^2.0.0, a@1.0.0 requires ^1.0.0"; err == nil || err.Error() != exp {
+		t.Errorf("%v != %q", err, exp)
+	}
+
+	_, err = Solve(idx, deps("d >=2"))
+	if exp := "No version of d satisfies: root requires >=2"; err == nil || err.Error() != exp {
+		t.Errorf("%v != %q", err, exp)
+	}
+
+	// every dead end is a dependency rejecting a version already chosen
+	cycle := index(
+		"a@1.0.0: b 1.0.0",
+		"a@2.0.0:",
+		"b@1.0.0: a 2.0.0",
+	)
+	_, err = Solve(cycle, deps("a ^1; b ^1"))
+	if exp := "No version of a satisfies: root requires ^1, b@1.0.0 requires 2.0.0"; err == nil || err.Error() != exp {
+		t.Errorf("%v != %q", err, exp)
+	}
+
+	// each version of a is ruled out by the version of b it leads to
+	crossed := index(
+		"a@1.0.0: b 1.0.0",
+		"a@2.0.0: b 2.0.0",
+		"b@1.0.0: a 2.0.0",
+		"b@2.0.0: a 1.0.0",
+	)
+	_, err = Solve(crossed, deps("a *"))
+	if !errors.As(err, &c) {
+		t.Errorf("expected a *Conflict, got %v", err)
+	} else if exp := "No version of a satisfies: b@2.0.0 requires 1.0.0, b@1.0.0 requires 2.0.0"; c.Error() != exp {
+		t.Errorf("%v != %q", c, exp)
+	}
+
+	if _, err := Solve(idx, deps("e *")); err == nil || errors.As(err, &c) {
+		t.Errorf("expected a source error for an unknown package, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package semver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+var (
+	_ driver.Valuer = Semver{}
+	_ sql.Scanner   = (*Semver)(nil)
+	_ driver.Valuer = NullSemver{}
+	_ sql.Scanner   = (*NullSemver)(nil)
+)
+
+// Value stores the version as TEXT.
+func (ver Semver) Value() (driver.Value, error) {
+	return ver.String(), nil
+}
+
+// Scan reads a version from a TEXT column. Use NullSemver for nullable
+// columns.
+func (ver *Semver) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return ver.UnmarshalText([]byte(src))
+	case []byte:
+		return ver.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("Cannot scan NULL into a Semver; use NullSemver")
+	}
+	return fmt.Errorf("Cannot scan %T into a Semver", src)
+}
+
+// NullSemver is a Semver that may be absent, in the style of sql.NullString.
+type NullSemver struct {
+	Semver Semver
+	Valid  bool // Valid is true if Semver is not NULL
+}
+
+// Value stores the version as TEXT, or NULL if it isn't Valid.
+func (n NullSemver) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Semver.Value()
+}
+
+// Scan reads a version from a nullable TEXT column.
+func (n *NullSemver) Scan(src interface{}) error {
+	if src == nil {
+		n.Semver, n.Valid = Semver{}, false
+		return nil
+	}
+	if err := n.Semver.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
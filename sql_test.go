@@ -0,0 +1,43 @@
+package semver
+
+import "testing"
+
+func TestSQL(t *testing.T) {
+	val, err := MustParse("1.2.3-rc.1").Value()
+	if err != nil || val != "1.2.3-rc.1" {
+		t.Errorf("Value: %v, %v", val, err)
+	}
+
+	for _, src := range []interface{}{"1.2.3-rc.1", []byte("v1.2.3-rc.1")} {
+		var ver Semver
+		if err := ver.Scan(src); err != nil || ver != MustParse("1.2.3-rc.1") {
+			t.Errorf("Scan(%#v): %+v, %v", src, ver, err)
+		}
+	}
+	for _, src := range []interface{}{nil, int64(1), "1.2"} {
+		var ver Semver
+		if err := ver.Scan(src); err == nil {
+			t.Errorf("Scan(%#v): expected error, got %+v", src, ver)
+		}
+	}
+}
+
+func TestNullSemverSQL(t *testing.T) {
+	if val, err := (NullSemver{}).Value(); err != nil || val != nil {
+		t.Errorf("invalid Value: %v, %v", val, err)
+	}
+	if val, err := (NullSemver{MustParse("1.0.0"), true}).Value(); err != nil || val != "1.0.0" {
+		t.Errorf("valid Value: %v, %v", val, err)
+	}
+
+	n := NullSemver{MustParse("1.0.0"), true}
+	if err := n.Scan(nil); err != nil || n.Valid || n.Semver != (Semver{}) {
+		t.Errorf("Scan(nil): %+v, %v", n, err)
+	}
+	if err := n.Scan("0.0.0"); err != nil || !n.Valid || n.Semver != (Semver{}) {
+		t.Errorf("Scan(0.0.0) should be distinguishable from NULL: %+v, %v", n, err)
+	}
+	if err := n.Scan("bogus"); err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,62 @@
+package semver
+
+// Stats summarizes a list of versions.
+type Stats struct {
+	Count      int
+	PerMajor   map[int]int // number of versions on each major line
+	Stable     int         // number of releases
+	Prerelease int         // number of prereleases
+
+	// Min and Max are the versions with the lowest and highest precedence.
+	// They're the zero Semver if the list is empty.
+	Min, Max Semver
+
+	// LatestStable and LatestPrerelease are the newest release and the
+	// newest prerelease, if there are any.
+	LatestStable, LatestPrerelease       Semver
+	HasLatestStable, HasLatestPrerelease bool
+
+	// LatestPerChannel is the newest version on each channel with any
+	// versions, as classified by DefaultClassifier.
+	LatestPerChannel map[Channel]Semver
+}
+
+// StableShare returns the fraction of the versions that are releases, or 0
+// for an empty list.
+func (s Stats) StableShare() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Stable) / float64(s.Count)
+}
+
+// Summarize computes Stats over versions in a single pass.
+func Summarize(versions []Semver) Stats {
+	s := Stats{PerMajor: make(map[int]int), LatestPerChannel: make(map[Channel]Semver)}
+	for i, v := range versions {
+		s.Count++
+		s.PerMajor[v.Major]++
+		if i == 0 || v.Cmp(s.Min) < 0 {
+			s.Min = v
+		}
+		if i == 0 || v.Cmp(s.Max) > 0 {
+			s.Max = v
+		}
+		ch := v.Channel()
+		if latest, ok := s.LatestPerChannel[ch]; !ok || v.Cmp(latest) > 0 {
+			s.LatestPerChannel[ch] = v
+		}
+		if v.Prerelease == "" {
+			s.Stable++
+			if !s.HasLatestStable || v.Cmp(s.LatestStable) > 0 {
+				s.LatestStable, s.HasLatestStable = v, true
+			}
+		} else {
+			s.Prerelease++
+			if !s.HasLatestPrerelease || v.Cmp(s.LatestPrerelease) > 0 {
+				s.LatestPrerelease, s.HasLatestPrerelease = v, true
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,36 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.2.0", "2.0.0-rc.1", "1.10.0", "0.9.0-beta", "2.0.0-rc.2", "1.3.0"})
+
+	s := Summarize(vs)
+	if s.Count != 6 || s.Stable != 3 || s.Prerelease != 3 || s.StableShare() != 0.5 {
+		t.Errorf("unexpected counts: %+v", s)
+	}
+	if exp := map[int]int{0: 1, 1: 3, 2: 2}; !reflect.DeepEqual(s.PerMajor, exp) {
+		t.Errorf("%v != %v", s.PerMajor, exp)
+	}
+	if s.Min != MustParse("0.9.0-beta") || s.Max != MustParse("2.0.0-rc.2") {
+		t.Errorf("unexpected min/max: %s, %s", s.Min, s.Max)
+	}
+	if !s.HasLatestStable || s.LatestStable != MustParse("1.10.0") {
+		t.Errorf("unexpected latest stable: %s", s.LatestStable)
+	}
+	if !s.HasLatestPrerelease || s.LatestPrerelease != MustParse("2.0.0-rc.2") {
+		t.Errorf("unexpected latest prerelease: %s", s.LatestPrerelease)
+	}
+
+	if exp := map[Channel]Semver{Stable: MustParse("1.10.0"), RC: MustParse("2.0.0-rc.2"), Beta: MustParse("0.9.0-beta")}; !reflect.DeepEqual(s.LatestPerChannel, exp) {
+		t.Errorf("%v != %v", s.LatestPerChannel, exp)
+	}
+
+	empty := Summarize(nil)
+	if empty.Count != 0 || empty.StableShare() != 0 || empty.HasLatestStable || empty.HasLatestPrerelease {
+		t.Errorf("unexpected empty stats: %+v", empty)
+	}
+}
@@ -0,0 +1,61 @@
+package semver
+
+import (
+	"strings"
+	"text/template"
+)
+
+// Style renders versions with a text/template layout whose dot is the
+// Semver, so a layout can use {{.Major}}, {{.Minor}}, {{.Patch}},
+// {{.Prerelease}}, {{.Build}} and {{.}} for the String form.
+type Style struct {
+	tmpl *template.Template
+}
+
+// Predefined styles.
+var (
+	// ShortStyle is the major and minor version, e.g. 1.2.
+	ShortStyle = MustStyle("{{.Major}}.{{.Minor}}")
+	// FullStyle is the String form, including prerelease and build metadata.
+	FullStyle = MustStyle("{{.}}")
+	// DockerTagStyle is the String form with the + before build metadata,
+	// which isn't allowed in image tags, replaced by _.
+	DockerTagStyle = MustStyle("{{.Major}}.{{.Minor}}.{{.Patch}}{{with .Prerelease}}-{{.}}{{end}}{{with .Build}}_{{.}}{{end}}")
+)
+
+// NewStyle parses a text/template layout.
+func NewStyle(layout string) (*Style, error) {
+	tmpl, err := template.New("semver").Option("missingkey=error").Parse(layout)
+	if err != nil {
+		return nil, err
+	}
+	return &Style{tmpl}, nil
+}
+
+// MustStyle is like NewStyle, but panics if the layout doesn't parse.
+func MustStyle(layout string) *Style {
+	s, err := NewStyle(layout)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Render formats v with the style. The only errors come from executing the
+// template, e.g. for a field that doesn't exist.
+func (s *Style) Render(v Semver) (string, error) {
+	var b strings.Builder
+	if err := s.tmpl.Execute(&b, v); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Render formats v with a text/template layout; see Style.
+func (v Semver) Render(layout string) (string, error) {
+	s, err := NewStyle(layout)
+	if err != nil {
+		return "", err
+	}
+	return s.Render(v)
+}
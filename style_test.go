@@ -0,0 +1,40 @@
+package semver
+
+import "testing"
+
+type styleTest struct {
+	style  *Style
+	given  string
+	exp    string
+	reason string
+}
+
+func TestStyle(t *testing.T) {
+	tests := []styleTest{
+		{ShortStyle, "1.2.3-rc.1+build.5", "1.2", "short"},
+		{FullStyle, "v1.2.3-rc.1+build.5", "1.2.3-rc.1+build.5", "full"},
+		{DockerTagStyle, "1.2.3-rc.1+build.5", "1.2.3-rc.1_build.5", "docker tag"},
+		{DockerTagStyle, "1.2.3", "1.2.3", "docker tag of a release"},
+		{MustStyle("app-{{.Major}}.{{.Minor}}.{{.Patch}}.tar.gz"), "1.2.3", "app-1.2.3.tar.gz", "artifact name"},
+		{MustStyle("{{if .Prerelease}}Preview {{.}}{{else}}Version {{.Major}}.{{.Minor}}{{end}}"), "2.0.0-beta.1", "Preview 2.0.0-beta.1", "conditional"},
+	}
+
+	for _, test := range tests {
+		s, err := test.style.Render(MustParse(test.given))
+		if err != nil {
+			t.Errorf("%s: %s", test.reason, err)
+		} else if s != test.exp {
+			t.Errorf("%s: %s != %s", test.reason, s, test.exp)
+		}
+	}
+
+	if s, err := MustParse("1.2.3").Render("v{{.Major}}"); err != nil || s != "v1" {
+		t.Errorf("Render: %s, %v", s, err)
+	}
+	if _, err := NewStyle("{{.Major"); err == nil {
+		t.Errorf("expected error for an unterminated action")
+	}
+	if _, err := MustParse("1.2.3").Render("{{.Epoch}}"); err == nil {
+		t.Errorf("expected error for an unknown field")
+	}
+}
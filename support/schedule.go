@@ -0,0 +1,116 @@
+package support
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/beatgammit/semver"
+)
+
+// Schedule is a regular cadence of minor releases, such as a minor version
+// every six weeks with each supported until three newer ones are out.
+type Schedule struct {
+	// Start is a minor version of the major version the schedule is for,
+	// and StartDate the day it was or will be released.
+	Start     semver.Semver
+	StartDate time.Time
+
+	// Months and Days are the time between minor releases, added with
+	// time.AddDate, so six weeks is Days: 42 and quarterly is Months: 3.
+	Months, Days int
+
+	// Supported is how many newer minor versions a minor version is
+	// supported for: with Supported 3, 1.4 reaches end of life when 1.7 is
+	// released.
+	Supported int
+}
+
+// Release is a minor version with its release and end-of-life dates.
+type Release struct {
+	Version semver.Semver
+	Date    time.Time
+	EOL     time.Time
+}
+
+func (s Schedule) validate() error {
+	if s.Months < 0 || s.Days < 0 || s.Months == 0 && s.Days == 0 {
+		return fmt.Errorf("Invalid schedule: the time between releases must be positive")
+	} else if s.Supported < 1 {
+		return fmt.Errorf("Invalid schedule: each minor version must be supported for at least one release")
+	}
+	return nil
+}
+
+// date returns the release date of the minor version n releases after
+// Start, or before it if n is negative.
+func (s Schedule) date(n int) time.Time {
+	return s.StartDate.AddDate(0, n*s.Months, n*s.Days)
+}
+
+// release returns the minor version n releases after Start.
+func (s Schedule) release(n int) Release {
+	return Release{
+		Version: semver.Semver{Major: s.Start.Major, Minor: s.Start.Minor + n},
+		Date:    s.date(n),
+		EOL:     s.date(n + s.Supported),
+	}
+}
+
+// Release returns the dates of the minor version v belongs to, so that 1.4.2
+// and 1.4.0-rc.1 both give the dates of 1.4.0. Versions before Start are
+// given the dates they would have had if the schedule had always held. v
+// must have the same major version as Start.
+func (s Schedule) Release(v semver.Semver) (Release, error) {
+	if err := s.validate(); err != nil {
+		return Release{}, err
+	}
+	if v.Major != s.Start.Major {
+		return Release{}, fmt.Errorf("Version %s is not on the schedule for %d.x", v, s.Start.Major)
+	}
+	return s.release(v.Minor - s.Start.Minor), nil
+}
+
+// Project returns the next n minor releases scheduled after the given time.
+func (s Schedule) Project(after time.Time, n int) ([]Release, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("Invalid number of releases: %d", n)
+	}
+	// find the first release after the time, stepping a release at a time
+	// from Start since months vary in length
+	i := 0
+	for !s.date(i).After(after) {
+		i++
+	}
+	for i > -s.Start.Minor && s.date(i-1).After(after) {
+		i--
+	}
+	out := make([]Release, n)
+	for j := range out {
+		out[j] = s.release(i + j)
+	}
+	return out, nil
+}
+
+// Lifecycles returns the lifecycle of each minor version from Start through
+// the n after it, for use in a Policy, with no deprecation dates.
+func (s Schedule) Lifecycles(n int) ([]Lifecycle, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("Invalid number of releases: %d", n)
+	}
+	out := make([]Lifecycle, n+1)
+	for i := range out {
+		r := s.release(i)
+		c, err := semver.ParseConstraint(fmt.Sprintf("%d.%d.x", r.Version.Major, r.Version.Minor))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = Lifecycle{Range: c, EOL: r.EOL}
+	}
+	return out, nil
+}
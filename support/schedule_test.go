@@ -0,0 +1,104 @@
+package support
+
+import (
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+func TestSchedule(t *testing.T) {
+	s := Schedule{
+		Start:     semver.MustParse("1.4.0"),
+		StartDate: day("2024-01-09"),
+		Days:      42,
+		Supported: 3,
+	}
+	tests := []struct {
+		given  string
+		date   string
+		eol    string
+		reason string
+	}{
+		{"1.4.0", "2024-01-09", "2024-05-14", "start"},
+		{"1.6.3", "2024-04-02", "2024-08-06", "patch of a later minor"},
+		{"1.5.0-rc.1", "2024-02-20", "2024-06-25", "prerelease"},
+		{"1.2.0", "2023-10-17", "2024-02-20", "before the start"},
+	}
+
+	for _, test := range tests {
+		r, err := s.Release(semver.MustParse(test.given))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.reason, err)
+			continue
+		}
+		if d := date(r.Date); d != test.date {
+			t.Errorf("%s: release date %s != %s", test.reason, d, test.date)
+		}
+		if d := date(r.EOL); d != test.eol {
+			t.Errorf("%s: EOL %s != %s", test.reason, d, test.eol)
+		}
+		if r.Version.Prerelease != "" || r.Version.Patch != 0 {
+			t.Errorf("%s: release %s should be a minor version", test.reason, r.Version)
+		}
+	}
+	if _, err := s.Release(semver.MustParse("2.0.0")); err == nil {
+		t.Errorf("expected an error for another major version")
+	}
+
+	projected, err := s.Project(day("2024-03-01"), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	exp := []string{"1.6.0 2024-04-02", "1.7.0 2024-05-14", "1.8.0 2024-06-25"}
+	for i, r := range projected {
+		if got := r.Version.String() + " " + date(r.Date); got != exp[i] {
+			t.Errorf("projected %s != %s", got, exp[i])
+		}
+	}
+	if early, _ := s.Project(day("2023-11-01"), 1); early[0].Version.String() != "1.3.0" {
+		t.Errorf("projecting from before the start: %+v", early)
+	}
+	if on, _ := s.Project(day("2024-01-09"), 1); on[0].Version.String() != "1.5.0" {
+		t.Errorf("projecting from a release day: %+v", on)
+	}
+
+	if _, err := s.Project(day("2024-03-01"), -1); err == nil {
+		t.Errorf("expected an error for a negative number of releases")
+	}
+	if none, err := s.Project(day("2024-03-01"), 0); err != nil || len(none) != 0 {
+		t.Errorf("projecting no releases: %+v, %v", none, err)
+	}
+	if _, err := (Schedule{Supported: 1}).Project(day("2024-01-01"), 1); err == nil {
+		t.Errorf("expected an error for a schedule without a cadence")
+	}
+	if _, err := (Schedule{Months: 3}).Release(semver.MustParse("0.1.0")); err == nil {
+		t.Errorf("expected an error for a schedule without support")
+	}
+}
+
+func TestScheduleLifecycles(t *testing.T) {
+	s := Schedule{Start: semver.MustParse("2.0.0"), StartDate: day("2024-01-01"), Months: 3, Supported: 2}
+	lifecycles, err := s.Lifecycles(4)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := s.Lifecycles(-2); err == nil {
+		t.Errorf("expected an error for a negative number of releases")
+	}
+	p := &Policy{Lifecycles: lifecycles}
+	tests := []struct {
+		given  string
+		status Status
+	}{
+		{"2.0.5", EOL},
+		{"2.1.0", EOL},
+		{"2.2.0", Supported},
+		{"2.4.0", Supported},
+	}
+
+	for _, test := range tests {
+		if v := p.StatusAt(semver.MustParse(test.given), day("2024-10-01")); v.Status != test.status {
+			t.Errorf("%s: %s != %s (%s)", test.given, v.Status, test.status, v.Reason)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package support decides whether versions of a product are still supported,
+// from a policy of explicit end-of-life dates and rules such as "the latest
+// two minor versions of each supported major version", so that an inventory
+// of deployed versions can be checked against it. A Schedule works out the
+// release and end-of-life dates of a regular release cadence.
+package support
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/beatgammit/semver"
+)
+
+// Status is how supported a version is, ordered from best to worst, so
+// s >= Deprecated means "deprecated or worse".
+type Status int
+
+const (
+	Supported Status = iota
+	Deprecated
+	EOL
+)
+
+var statusNames = [...]string{"supported", "deprecated", "eol"}
+
+func (s Status) String() string {
+	if s < Supported || s > EOL {
+		return "unknown"
+	}
+	return statusNames[s]
+}
+
+// MarshalText encodes the status as its String form.
+func (s Status) MarshalText() ([]byte, error) {
+	if s < Supported || s > EOL {
+		return nil, fmt.Errorf("Invalid status: %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses a status as written by String, leaving s unchanged on
+// error.
+func (s *Status) UnmarshalText(arr []byte) error {
+	for i, name := range statusNames {
+		if string(arr) == name {
+			*s = Status(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid status: %q", arr)
+}
+
+// Verdict is the status of a version and, unless it's Supported, why.
+type Verdict struct {
+	Status Status
+	Reason string
+}
+
+// Lifecycle gives the dates a range of versions, usually a release line
+// such as 1.2.x, is deprecated and reaches end of life. Either may be zero
+// if it hasn't been decided.
+type Lifecycle struct {
+	Range      semver.Constraint
+	Deprecated time.Time
+	EOL        time.Time
+}
+
+// Policy declares which versions are supported. A version's status is the
+// worst that any of the rules give it, and versions no rule applies to are
+// supported.
+type Policy struct {
+	// Lifecycles are explicit dates for ranges of versions. Ranges are
+	// checked against the release of each version, so 1.2.0-rc.1 is in
+	// 1.2.x.
+	Lifecycles []Lifecycle
+
+	// Releases are the versions that have been released, which the window
+	// rules below count from. Prereleases among them are ignored.
+	Releases []semver.Semver
+
+	// Majors is how many of the latest major versions are supported; older
+	// ones are EOL. Zero means no limit.
+	Majors int
+	// Minors is how many of the latest minor versions of each major version
+	// are supported. The GraceMinors minor versions after those are
+	// deprecated, and older ones are EOL. Zero means no limit.
+	Minors      int
+	GraceMinors int
+}
+
+// Status returns the status of v now.
+func (p *Policy) Status(v semver.Semver) Verdict {
+	return p.StatusAt(v, time.Now())
+}
+
+// StatusAt returns the status of v at time t.
+func (p *Policy) StatusAt(v semver.Semver, t time.Time) Verdict {
+	verdict := Verdict{Status: Supported}
+	worse := func(s Status, reason string) {
+		if s > verdict.Status {
+			verdict = Verdict{s, reason}
+		}
+	}
+
+	release := semver.Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	for _, l := range p.Lifecycles {
+		if !l.Range.Check(release) {
+			continue
+		}
+		if !l.EOL.IsZero() && !t.Before(l.EOL) {
+			worse(EOL, fmt.Sprintf("%s reached end of life on %s", l.Range, date(l.EOL)))
+		} else if !l.Deprecated.IsZero() && !t.Before(l.Deprecated) {
+			reason := fmt.Sprintf("%s is deprecated since %s", l.Range, date(l.Deprecated))
+			if !l.EOL.IsZero() {
+				reason += fmt.Sprintf(" and reaches end of life on %s", date(l.EOL))
+			}
+			worse(Deprecated, reason)
+		}
+	}
+
+	majors, minors := p.newer(v)
+	if p.Majors > 0 && majors >= p.Majors {
+		worse(EOL, fmt.Sprintf("%d.x is older than the latest %d major versions", v.Major, p.Majors))
+	}
+	if p.Minors > 0 && minors >= p.Minors {
+		reason := fmt.Sprintf("%d.%d is older than the latest %d minor versions of %d.x", v.Major, v.Minor, p.Minors, v.Major)
+		if minors < p.Minors+p.GraceMinors {
+			worse(Deprecated, reason)
+		} else {
+			worse(EOL, reason)
+		}
+	}
+	return verdict
+}
+
+// newer counts the major versions released after v's, and the minor
+// versions of v's major version released after v's.
+func (p *Policy) newer(v semver.Semver) (majors, minors int) {
+	seenMajor := make(map[int]bool)
+	seenMinor := make(map[int]bool)
+	for _, r := range p.Releases {
+		if r.Prerelease != "" {
+			continue
+		}
+		if r.Major > v.Major && !seenMajor[r.Major] {
+			seenMajor[r.Major] = true
+			majors++
+		}
+		if r.Major == v.Major && r.Minor > v.Minor && !seenMinor[r.Minor] {
+			seenMinor[r.Minor] = true
+			minors++
+		}
+	}
+	return majors, minors
+}
+
+func date(t time.Time) string {
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,82 @@
+package support
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beatgammit/semver"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestStatusAt(t *testing.T) {
+	var releases []semver.Semver
+	for _, s := range []string{"1.0.0", "1.1.0", "2.0.0", "2.1.0", "2.2.0", "2.3.0", "2.3.1", "3.0.0", "4.0.0-rc.1"} {
+		releases = append(releases, semver.MustParse(s))
+	}
+	p := &Policy{
+		Lifecycles: []Lifecycle{
+			{Range: semver.MustParseConstraint("3.0.x"), Deprecated: day("2024-06-01"), EOL: day("2025-01-01")},
+			{Range: semver.MustParseConstraint("2.3.x"), EOL: day("2024-03-01")},
+		},
+		Releases:    releases,
+		Majors:      2,
+		Minors:      2,
+		GraceMinors: 1,
+	}
+	now := day("2024-07-01")
+	tests := []struct {
+		given  string
+		status Status
+		reason string
+	}{
+		{"3.0.0-rc.1", Deprecated, "3.0.x is deprecated since 2024-06-01 and reaches end of life on 2025-01-01"},
+		{"2.2.0", Supported, ""},
+		{"2.1.4", Deprecated, "2.1 is older than the latest 2 minor versions of 2.x"},
+		{"2.0.0", EOL, "2.0 is older than the latest 2 minor versions of 2.x"},
+		{"2.3.1", EOL, "2.3.x reached end of life on 2024-03-01"},
+		{"1.1.0", EOL, "1.x is older than the latest 2 major versions"},
+		{"4.0.0-rc.1", Supported, ""},
+	}
+
+	for _, test := range tests {
+		verdict := p.StatusAt(semver.MustParse(test.given), now)
+		if verdict.Status != test.status || verdict.Reason != test.reason {
+			t.Errorf("%s: %s %q != %s %q", test.given, verdict.Status, verdict.Reason, test.status, test.reason)
+		}
+	}
+
+	if verdict := p.StatusAt(semver.MustParse("3.0.0"), day("2024-01-01")); verdict.Status != Supported {
+		t.Errorf("before its deprecation, 3.0.0 should be supported: %+v", verdict)
+	}
+	if verdict := (&Policy{}).Status(semver.MustParse("0.1.0")); verdict.Status != Supported {
+		t.Errorf("an empty policy should support everything: %+v", verdict)
+	}
+}
+
+func TestStatusText(t *testing.T) {
+	for _, s := range []Status{Supported, Deprecated, EOL} {
+		text, err := s.MarshalText()
+		if err != nil {
+			t.Errorf("%s: %s", s, err)
+			continue
+		}
+		var parsed Status
+		if err := parsed.UnmarshalText(text); err != nil || parsed != s {
+			t.Errorf("%s: parsed back as %s, %v", s, parsed, err)
+		}
+	}
+	if _, err := Status(7).MarshalText(); err == nil {
+		t.Errorf("expected an error for an unknown status")
+	}
+	var s Status
+	if err := s.UnmarshalText([]byte("retired")); err == nil {
+		t.Errorf("expected an error for an unknown name")
+	}
+}
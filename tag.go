@@ -0,0 +1,181 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	_ fmt.Formatter            = Tag{}
+	_ fmt.GoStringer           = Tag{}
+	_ encoding.TextMarshaler   = Tag{}
+	_ encoding.TextUnmarshaler = (*Tag)(nil)
+	_ json.Marshaler           = Tag{}
+	_ json.Unmarshaler         = (*Tag)(nil)
+)
+
+// Tag is a version together with the prefix it was tagged with, such as
+// "release-1.2.3" or "tools/gopls/v0.14.0".
+type Tag struct {
+	// Prefix is everything before the version, including a leading v.
+	Prefix string
+	Semver
+}
+
+// ParseTag parses a tag, stripping the first prefix configured with
+// WithPrefixes and recording it, along with any leading v, so that String
+// reproduces the tag.
+func ParseTag(tag string, opts ...ParseOption) (t Tag, err error) {
+	var rest string
+	t.Prefix, rest, t.Semver, err = newParseOptions(opts).parse(tag)
+	if err == nil && strings.HasPrefix(rest, "v") {
+		t.Prefix += "v"
+	}
+	return
+}
+
+// String produces the tag, re-applying its prefix to the Semver string.
+func (t Tag) String() string {
+	b, _ := t.AppendText(nil)
+	return string(b)
+}
+
+// AppendText appends the String form of the tag to b.
+func (t Tag) AppendText(b []byte) ([]byte, error) {
+	return t.Semver.AppendText(append(b, t.Prefix...))
+}
+
+// MarshalText encodes the tag as its String form, prefix included.
+//
+// Tag embeds Semver, so without its own encoders it would inherit Semver's,
+// which drop the prefix. It implements every encoding that Semver does, each
+// encoding the tag as a string.
+func (t Tag) MarshalText() ([]byte, error) {
+	return t.AppendText(nil)
+}
+
+// UnmarshalText parses a tag as written by String, leaving t unchanged on
+// error. Since the possible prefixes aren't known here, the prefix is
+// everything before the first number from which the rest of the tag parses
+// as a version, so "tools/gopls/v0.14.0" has the prefix "tools/gopls/v". Use
+// ParseTag to accept only known prefixes.
+func (t *Tag) UnmarshalText(arr []byte) error {
+	s := string(arr)
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) || i > 0 && isDigit(s[i-1]) {
+			continue
+		}
+		if v, err := Parse(s[i:]); err == nil {
+			*t = Tag{Prefix: s[:i], Semver: v}
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid tag: %q", s)
+}
+
+// MarshalJSON encodes the tag as a JSON string.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	return marshalTextJSON(t)
+}
+
+// UnmarshalJSON decodes a JSON string with UnmarshalText. null leaves t
+// unchanged.
+func (t *Tag) UnmarshalJSON(arr []byte) error {
+	return unmarshalTextJSON(t, "tag", arr)
+}
+
+// MarshalYAML encodes the tag as a YAML scalar.
+func (t Tag) MarshalYAML() (interface{}, error) {
+	return marshalTextYAML(t)
+}
+
+// UnmarshalYAML decodes a YAML scalar with UnmarshalText.
+func (t *Tag) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalTextYAML(t, unmarshal)
+}
+
+// UnmarshalTOML decodes a TOML string with UnmarshalText.
+func (t *Tag) UnmarshalTOML(data interface{}) error {
+	return unmarshalTextTOML(t, "tag", data)
+}
+
+// MarshalBSONValue encodes the tag as a BSON string.
+func (t Tag) MarshalBSONValue() (byte, []byte, error) {
+	return marshalTextBSON(t)
+}
+
+// UnmarshalBSONValue decodes a BSON string with UnmarshalText.
+func (t *Tag) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalTextBSON(t, "tag", typ, data)
+}
+
+// MarshalMsgpack encodes the tag as a MessagePack string.
+func (t Tag) MarshalMsgpack() ([]byte, error) {
+	return marshalTextMsgpack(t)
+}
+
+// UnmarshalMsgpack decodes a MessagePack string with UnmarshalText.
+func (t *Tag) UnmarshalMsgpack(data []byte) error {
+	return unmarshalTextMsgpack(t, "tag", data)
+}
+
+// MarshalCBOR encodes the tag as a CBOR text string.
+func (t Tag) MarshalCBOR() ([]byte, error) {
+	return marshalTextCBOR(t)
+}
+
+// UnmarshalCBOR decodes a CBOR text string with UnmarshalText.
+func (t *Tag) UnmarshalCBOR(data []byte) error {
+	return unmarshalTextCBOR(t, data)
+}
+
+// MarshalBinary encodes the tag as its String form, so that encoding/gob
+// keeps the prefix.
+func (t Tag) MarshalBinary() ([]byte, error) {
+	return t.MarshalText()
+}
+
+// UnmarshalBinary decodes the output of MarshalBinary.
+func (t *Tag) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// Value stores the tag as TEXT.
+func (t Tag) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// Scan reads a tag from a TEXT column.
+func (t *Tag) Scan(src interface{}) error {
+	return scanText(t, "Tag", src)
+}
+
+// MarshalGQL writes the tag as a GraphQL string.
+func (t Tag) MarshalGQL(w io.Writer) {
+	marshalTextGQL(t, w)
+}
+
+// UnmarshalGQL parses a custom scalar input value, which must be a string.
+func (t *Tag) UnmarshalGQL(v interface{}) error {
+	return unmarshalTextGQL(t, "Tag", v)
+}
+
+// Set parses s with UnmarshalText, for flag.Value.
+func (t *Tag) Set(s string) error {
+	return t.UnmarshalText([]byte(s))
+}
+
+// Format implements fmt.Formatter, so that %v and %s print the tag rather
+// than the embedded Semver. The other verbs are as for Semver.
+func (t Tag) Format(f fmt.State, verb rune) {
+	formatEmbedded(f, verb, t.String(), t.GoString(), t.Semver)
+}
+
+// GoString formats the tag as a Go struct literal, which is what %#v prints.
+func (t Tag) GoString() string {
+	return fmt.Sprintf("semver.Tag{Prefix:%q, Semver:%#v}", t.Prefix, t.Semver)
+}
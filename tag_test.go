@@ -0,0 +1,79 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type tagTest struct {
+	given  string
+	prefix string
+	exp    Semver
+	reason string
+}
+
+func TestParseTag(t *testing.T) {
+	opts := []ParseOption{WithPrefixes("release-", "ver", "version-", "tools/gopls/")}
+	tests := []tagTest{
+		{"1.2.3", "", Semver{Major: 1, Minor: 2, Patch: 3}, "no prefix"},
+		{"v1.2.3", "v", Semver{Major: 1, Minor: 2, Patch: 3}, "only 'v' prefix"},
+		{"release-1.2.3", "release-", Semver{Major: 1, Minor: 2, Patch: 3}, "release prefix"},
+		{"ver1.2.3", "ver", Semver{Major: 1, Minor: 2, Patch: 3}, "short prefix"},
+		{"version-1.2.3-rc.1", "version-", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, "longest prefix wins"},
+		{"tools/gopls/v0.14.0", "tools/gopls/v", Semver{Minor: 14}, "path prefix followed by 'v'"},
+	}
+
+	for _, test := range tests {
+		tag, err := ParseTag(test.given, opts...)
+		if err != nil {
+			t.Errorf("%s: error parsing: %s; given: %s", test.reason, err, test.given)
+			continue
+		}
+		if tag.Prefix != test.prefix || tag.Semver != test.exp {
+			t.Errorf("%s: %+v != {Prefix:%s Semver:%+v}", test.reason, tag, test.prefix, test.exp)
+		}
+		if s := tag.String(); s != test.given {
+			t.Errorf("%s: %s != %s", test.reason, s, test.given)
+		}
+		if v, err := ParseWith(test.given, opts...); err != nil || v != test.exp {
+			t.Errorf("%s: ParseWith returned %+v, %v", test.reason, v, err)
+		}
+	}
+
+	bad := []badParseTest{
+		{"rel-1.2.3", "unconfigured prefix"},
+		{"release-", "prefix only"},
+	}
+
+	for _, test := range bad {
+		if tag, err := ParseTag(test.given, opts...); err == nil {
+			t.Errorf("%s: expected error, returned: %+v", test.reason, tag)
+		}
+	}
+}
+
+func TestTagEncoding(t *testing.T) {
+	for _, s := range []string{"1.2.3", "v1.2.3", "release-1.2.3-rc.1+build.5", "tools/gopls/v0.14.0", "go1.21-v2.0.0", "v1.2.3-4"} {
+		tag, err := ParseTag(s, WithPrefixes("release-", "tools/gopls/", "go1.21-"))
+		if err != nil {
+			t.Errorf("%s: error parsing: %s", s, err)
+			continue
+		}
+		testEmbeddedEncodings(t, tag, s, func() embeddedDecoder { return new(Tag) })
+	}
+
+	type release struct {
+		Tag Tag `json:"tag"`
+	}
+	var r release
+	if err := json.Unmarshal([]byte(`{"tag":"release-1.2.3"}`), &r); err != nil || r.Tag.Prefix != "release-" || r.Tag.Semver != MustParse("1.2.3") {
+		t.Errorf("json.Unmarshal: %+v, %v", r, err)
+	}
+
+	for _, s := range []string{"", "release-", "release-1.2", "v1"} {
+		var tag Tag
+		if err := tag.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf("%q: expected error, got %+v", s, tag)
+		}
+	}
+}
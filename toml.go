@@ -0,0 +1,28 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalTOML decodes a TOML value for github.com/BurntSushi/toml, which
+// passes it already decoded: a string such as version = "1.2.3", or a table
+// such as version = {major = 1, minor = 2, patch = 3} that follows the same
+// rules as the JSON object form.
+//
+// Decoders built on the encoding text interfaces, such as
+// github.com/pelletier/go-toml/v2, use UnmarshalText instead, and both use
+// MarshalText for encoding, so versions are written as TOML strings.
+func (ver *Semver) UnmarshalTOML(data interface{}) error {
+	switch data := data.(type) {
+	case string:
+		return ver.UnmarshalText([]byte(data))
+	case map[string]interface{}:
+		b, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		return ver.unmarshalObject(b)
+	}
+	return fmt.Errorf("Invalid semver TOML: expected a string or a table, got %T", data)
+}
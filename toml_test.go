@@ -0,0 +1,41 @@
+package semver
+
+import "testing"
+
+type tomlTest struct {
+	given  interface{}
+	exp    Semver
+	reason string
+}
+
+func TestUnmarshalTOML(t *testing.T) {
+	good := []tomlTest{
+		{"1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}, "string"},
+		{"v1.2.3-rc.1", Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, "prerelease"},
+		{map[string]interface{}{"major": int64(1), "minor": int64(2), "patch": int64(3), "build": "5"}, Semver{Major: 1, Minor: 2, Patch: 3, Build: "5"}, "inline table"},
+	}
+
+	for _, test := range good {
+		var ver Semver
+		if err := ver.UnmarshalTOML(test.given); err != nil {
+			t.Errorf("%s: %s; given: %#v", test.reason, err, test.given)
+		} else if ver != test.exp {
+			t.Errorf("%s: %+v != %+v", test.reason, ver, test.exp)
+		}
+	}
+
+	bad := []tomlTest{
+		{"1.2", Semver{}, "partial version"},
+		{1.2, Semver{}, "float"},
+		{int64(1), Semver{}, "integer"},
+		{map[string]interface{}{"major": int64(1)}, Semver{}, "incomplete table"},
+		{map[string]interface{}{"major": int64(1), "minor": int64(2), "patch": int64(3), "name": "x"}, Semver{}, "unknown key"},
+	}
+
+	for _, test := range bad {
+		var ver Semver
+		if err := ver.UnmarshalTOML(test.given); err == nil {
+			t.Errorf("%s: expected error, got %+v", test.reason, ver)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package semver
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// ranked is a version and its index in the input, which breaks ties between
+// versions of equal precedence.
+type ranked struct {
+	v Semver
+	i int
+}
+
+// better reports whether a ranks above b: higher precedence, or equal
+// precedence and earlier in the input.
+func (a ranked) better(b ranked) bool {
+	if c := a.v.Cmp(b.v); c != 0 {
+		return c > 0
+	}
+	return a.i < b.i
+}
+
+// minHeap is a heap of versions with the lowest ranked at the root.
+type minHeap []ranked
+
+func (h minHeap) Len() int            { return len(h) }
+func (h minHeap) Less(i, j int) bool  { return h[j].better(h[i]) }
+func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(ranked)) }
+func (h *minHeap) Pop() interface{} {
+	old := *h
+	v := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return v
+}
+
+// TopN returns the n versions with the highest precedence, newest first. It
+// keeps a heap of n versions rather than sorting the whole slice, so it runs
+// in O(len(versions) log n). Of versions with equal precedence, such as
+// versions differing only in build metadata, the earlier ones are preferred
+// and come first.
+func TopN(versions []Semver, n int) []Semver {
+	if n <= 0 {
+		return nil
+	}
+	if n > len(versions) {
+		n = len(versions)
+	}
+	h := make(minHeap, 0, n)
+	for i, v := range versions {
+		r := ranked{v, i}
+		if len(h) < n {
+			heap.Push(&h, r)
+		} else if r.better(h[0]) {
+			h[0] = r
+			heap.Fix(&h, 0)
+		}
+	}
+	sort.Slice(h, func(i, j int) bool { return h[i].better(h[j]) })
+	out := make([]Semver, len(h))
+	for i, r := range h {
+		out[i] = r.v
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package semver
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestTopN(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.2.0", "2.0.0-rc.1", "1.10.0", "0.9.0", "2.0.0", "1.3.0"})
+
+	tests := []struct {
+		n      int
+		exp    []string
+		reason string
+	}{
+		{3, []string{"2.0.0", "2.0.0-rc.1", "1.10.0"}, "top three"},
+		{1, []string{"2.0.0"}, "top one"},
+		{10, []string{"2.0.0", "2.0.0-rc.1", "1.10.0", "1.3.0", "1.2.0", "0.9.0"}, "more than there are"},
+		{0, nil, "none"},
+	}
+
+	for _, test := range tests {
+		got := TopN(vs, test.n)
+		if len(got) == 0 && len(test.exp) == 0 {
+			continue
+		}
+		if s := Versions(got).Strings(); !reflect.DeepEqual(s, test.exp) {
+			t.Errorf("%s: %q != %q", test.reason, s, test.exp)
+		}
+	}
+	if vs[0] != MustParse("1.2.0") {
+		t.Errorf("input should not be modified")
+	}
+}
+
+func TestTopNTies(t *testing.T) {
+	tests := []struct {
+		given  []string
+		n      int
+		exp    []string
+		reason string
+	}{
+		{[]string{"1.0.0+a", "1.0.0+b", "2.0.0"}, 2, []string{"2.0.0", "1.0.0+a"}, "earlier of a tie kept"},
+		{[]string{"1.0.0+b", "1.0.0+a", "2.0.0"}, 2, []string{"2.0.0", "1.0.0+b"}, "input order, not build order"},
+		{[]string{"1.0.0+a", "1.0.0+b", "1.0.0+c"}, 3, []string{"1.0.0+a", "1.0.0+b", "1.0.0+c"}, "ties in input order"},
+		{[]string{"1.0.0+c", "0.1.0", "1.0.0+b", "1.0.0+a"}, 2, []string{"1.0.0+c", "1.0.0+b"}, "later tie displaces nothing"},
+	}
+
+	for _, test := range tests {
+		vs, _ := ParseVersions(test.given)
+		if s := Versions(TopN(vs, test.n)).Strings(); !reflect.DeepEqual(s, test.exp) {
+			t.Errorf("%s: %q != %q", test.reason, s, test.exp)
+		}
+	}
+}
+
+func TestTopNMatchesSort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vs := make([]Semver, 1000)
+	for i := range vs {
+		vs[i] = Semver{Major: r.Intn(5), Minor: r.Intn(20), Patch: r.Intn(50)}
+	}
+	sorted := append(Versions(nil), vs...)
+	sorted.Sort()
+
+	top := TopN(vs, 25)
+	for i, v := range top {
+		if exp := sorted[len(sorted)-1-i]; v.Cmp(exp) != 0 {
+			t.Fatalf("position %d: %s != %s", i, v, exp)
+		}
+	}
+}
+
+func BenchmarkTopN(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	vs := make([]Semver, 100000)
+	for i := range vs {
+		vs[i] = Semver{Major: r.Intn(10), Minor: r.Intn(100), Patch: r.Intn(100)}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		TopN(vs, 5)
+	}
+}
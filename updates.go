@@ -0,0 +1,66 @@
+package semver
+
+// UpdateReport describes the versions a user could update to, for showing
+// "update available" messages. The version lists are in ascending order.
+type UpdateReport struct {
+	Current Semver
+
+	// Patch, Minor and Major are the releases newer than Current, split by
+	// how much they differ from it. The release of a prerelease Current,
+	// such as 1.2.0 for 1.2.0-rc.1, is a Patch update.
+	Patch, Minor, Major []Semver
+
+	// Prerelease is the prereleases newer than every newer release, which
+	// would be reachable only by opting in to prereleases.
+	Prerelease []Semver
+
+	// Best is the newest version satisfying the constraint, and Latest the
+	// newest release, ignoring it; either is nil if there's no such update.
+	Best, Latest *Semver
+
+	// HeldBack is true if Latest is newer than Best, so that the constraint
+	// keeps the user from the latest release.
+	HeldBack bool
+}
+
+// Available reports whether there's any update within the constraint.
+func (r UpdateReport) Available() bool {
+	return r.Best != nil
+}
+
+// CheckUpdates sorts the versions in available that are newer than current
+// into an UpdateReport. c is the range the user is meant to update within,
+// such as ^1.2.0; its usual prerelease rule applies to Best.
+func CheckUpdates(current Semver, available []Semver, c Constraint) UpdateReport {
+	r := UpdateReport{Current: current}
+	var newer Versions
+	for _, v := range available {
+		if v.Cmp(current) > 0 {
+			newer = append(newer, v)
+		}
+	}
+	newer.Sort()
+
+	for i, v := range newer {
+		if c.Check(v) {
+			r.Best = &newer[i]
+		}
+		if v.Prerelease != "" {
+			r.Prerelease = append(r.Prerelease, v)
+			continue
+		}
+		// prereleases older than this release are superseded by it
+		r.Prerelease = nil
+		r.Latest = &newer[i]
+		switch Diff(current, v) {
+		case MajorChange:
+			r.Major = append(r.Major, v)
+		case MinorChange:
+			r.Minor = append(r.Minor, v)
+		default:
+			r.Patch = append(r.Patch, v)
+		}
+	}
+	r.HeldBack = r.Latest != nil && (r.Best == nil || r.Latest.Cmp(*r.Best) > 0)
+	return r
+}
@@ -0,0 +1,60 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckUpdates(t *testing.T) {
+	available := parseVersions(t,
+		"1.1.0", "1.2.3", "1.2.4", "1.2.5-rc.1", "1.2.5", "1.3.0", "1.4.0-beta.1",
+		"1.4.0", "2.0.0-rc.1", "2.0.0", "2.1.0", "3.0.0-alpha.1", "3.0.0-beta.2",
+	)
+
+	r := CheckUpdates(MustParse("1.2.3"), available, MustParseConstraint("^1.2.3"))
+	if exp := parseVersions(t, "1.2.4", "1.2.5"); !reflect.DeepEqual(r.Patch, exp) {
+		t.Errorf("patch %v != %v", r.Patch, exp)
+	}
+	if exp := parseVersions(t, "1.3.0", "1.4.0"); !reflect.DeepEqual(r.Minor, exp) {
+		t.Errorf("minor %v != %v", r.Minor, exp)
+	}
+	if exp := parseVersions(t, "2.0.0", "2.1.0"); !reflect.DeepEqual(r.Major, exp) {
+		t.Errorf("major %v != %v", r.Major, exp)
+	}
+	if exp := parseVersions(t, "3.0.0-alpha.1", "3.0.0-beta.2"); !reflect.DeepEqual(r.Prerelease, exp) {
+		t.Errorf("prerelease %v != %v", r.Prerelease, exp)
+	}
+	if r.Best == nil || r.Best.String() != "1.4.0" {
+		t.Errorf("best %v != 1.4.0", r.Best)
+	}
+	if r.Latest == nil || r.Latest.String() != "2.1.0" {
+		t.Errorf("latest %v != 2.1.0", r.Latest)
+	}
+	if !r.HeldBack || !r.Available() {
+		t.Errorf("expected an update held back by the constraint: %+v", r)
+	}
+
+	r = CheckUpdates(MustParse("2.1.0"), available, MustParseConstraint("*"))
+	if r.Available() || r.HeldBack || r.Latest != nil || len(r.Patch)+len(r.Minor)+len(r.Major) != 0 {
+		t.Errorf("expected no release updates: %+v", r)
+	}
+	if len(r.Prerelease) != 2 {
+		t.Errorf("expected prerelease-only updates: %v", r.Prerelease)
+	}
+
+	r = CheckUpdates(MustParse("2.0.0-rc.1"), available, MustParseConstraint(">=2.0.0-rc.1 <3"))
+	if exp := parseVersions(t, "2.0.0"); !reflect.DeepEqual(r.Patch, exp) {
+		t.Errorf("release of the current prerelease: %v != %v", r.Patch, exp)
+	}
+	if r.Best == nil || r.Best.String() != "2.1.0" || r.HeldBack {
+		t.Errorf("best %v, held back %t", r.Best, r.HeldBack)
+	}
+}
+
+func parseVersions(t *testing.T, ss ...string) []Semver {
+	vs, err := ParseAll(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vs
+}
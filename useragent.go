@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Product is a product token such as "MyApp/1.4.2" from a User-Agent header.
+type Product struct {
+	Name    string
+	Version Semver
+	Text    string // the version as written, e.g. "120.0.6099.109"
+}
+
+// ParseUserAgent returns the products named in a User-Agent header, in order,
+// with their versions read leniently: "MyApp/1.4" is version 1.4.0, as Coerce
+// reads it, and components past the patch version, as in
+// "Chrome/120.0.6099.109", are dropped. Comments such as "(linux; amd64)" and
+// products without a version, or whose version can't be read, are skipped.
+func ParseUserAgent(ua string) []Product {
+	var out []Product
+	for len(ua) > 0 {
+		switch c := ua[0]; {
+		case c == ' ' || c == '\t':
+			ua = ua[1:]
+		case c == '(':
+			ua = skipComment(ua)
+		default:
+			end := strings.IndexAny(ua, " \t(")
+			if end < 0 {
+				end = len(ua)
+			}
+			token := ua[:end]
+			ua = ua[end:]
+			i := strings.IndexByte(token, '/')
+			if i <= 0 {
+				continue
+			}
+			if v, err := coerceProductVersion(token[i+1:]); err == nil {
+				out = append(out, Product{token[:i], v, token[i+1:]})
+			}
+		}
+	}
+	return out
+}
+
+// UserAgentVersion returns the version of the named product in a User-Agent
+// header, matching the name case-insensitively, so that a server can check a
+// client's version against a minimum with Cmp or a Constraint.
+func UserAgentVersion(ua, product string) (Semver, error) {
+	for _, p := range ParseUserAgent(ua) {
+		if strings.EqualFold(p.Name, product) {
+			return p.Version, nil
+		}
+	}
+	return Semver{}, fmt.Errorf("No %s version in User-Agent %q", product, ua)
+}
+
+// skipComment returns s after the parenthesized comment it starts with.
+// Comments may nest and use backslash escapes.
+func skipComment(s string) string {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return s[i+1:]
+			}
+		}
+	}
+	return ""
+}
+
+// coerceProductVersion reads a product version with Coerce, dropping any
+// numeric components after the third.
+func coerceProductVersion(s string) (Semver, error) {
+	end := len(s)
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		end = i
+	}
+	if parts := strings.Split(s[:end], "."); len(parts) > 3 {
+		s = strings.Join(parts[:3], ".") + s[end:]
+	}
+	return Coerce(s)
+}
@@ -0,0 +1,54 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		given  string
+		exp    []Product
+		reason string
+	}{
+		{"MyApp/1.4.2 (linux; amd64)", []Product{{"MyApp", MustParse("1.4.2"), "1.4.2"}}, "product and comment"},
+		{"MyApp/v2.0.0-rc.1 lib/1.3", []Product{
+			{"MyApp", MustParse("2.0.0-rc.1"), "v2.0.0-rc.1"},
+			{"lib", MustParse("1.3.0"), "1.3"},
+		}, "lenient versions"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.109 Safari/537.36", []Product{
+			{"Mozilla", MustParse("5.0.0"), "5.0"},
+			{"AppleWebKit", MustParse("537.36.0"), "537.36"},
+			{"Chrome", MustParse("120.0.6099"), "120.0.6099.109"},
+			{"Safari", MustParse("537.36.0"), "537.36"},
+		}, "browser"},
+		{"curl/8.4.0", []Product{{"curl", MustParse("8.4.0"), "8.4.0"}}, "curl"},
+		{"tool (nested (comment) with \\) escape) tool/2", []Product{{"tool", MustParse("2.0.0"), "2"}}, "nested comment"},
+		{"bot/latest other/", nil, "no versions"},
+		{"", nil, "empty"},
+	}
+
+	for _, test := range tests {
+		ps := ParseUserAgent(test.given)
+		if !reflect.DeepEqual(ps, test.exp) {
+			t.Errorf("%s: %+v != %+v", test.reason, ps, test.exp)
+		}
+	}
+}
+
+func TestUserAgentVersion(t *testing.T) {
+	ua := "MyApp/1.4.2 (linux; amd64) Go-http-client/1.1"
+	v, err := UserAgentVersion(ua, "myapp")
+	if err != nil {
+		t.Fatalf("error finding version: %s", err)
+	}
+	if exp := MustParse("1.4.2"); v != exp {
+		t.Errorf("%s != %s", v, exp)
+	}
+	if !MustParseConstraint(">=1.4").Check(v) {
+		t.Errorf("expected %s to satisfy >=1.4", v)
+	}
+	if v, err := UserAgentVersion(ua, "linux"); err == nil {
+		t.Errorf("expected an error for a product in a comment, returned: %s", v)
+	}
+}
@@ -0,0 +1,7 @@
+module github.com/beatgammit/semver/v2
+
+go 1.25.0
+
+require github.com/beatgammit/semver v0.0.0-00010101000000-000000000000
+
+replace github.com/beatgammit/semver => ..
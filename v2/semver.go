@@ -0,0 +1,158 @@
+// Package semver is version 2 of github.com/beatgammit/semver. A Version's
+// fields are unexported, so every Version is built by Parse or New and is
+// always valid; accessors expose the components.
+//
+// FromV1 and Version.V1 convert to and from the version 1 Semver struct,
+// so the two packages can be used side by side while callers migrate.
+//
+// Example:
+//
+//	ver, _ := semver.Parse("v1.2.3-rc.1")
+//	fmt.Println(ver.Major(), ver.Prerelease()) // prints 1 rc.1
+package semver
+
+import (
+	"encoding"
+	"fmt"
+
+	v1 "github.com/beatgammit/semver"
+)
+
+var (
+	_ encoding.TextMarshaler   = Version{}
+	_ encoding.TextUnmarshaler = (*Version)(nil)
+)
+
+// Version is a valid semantic version. The zero Version is 0.0.0.
+type Version struct {
+	major      int
+	minor      int
+	patch      int
+	prerelease string
+	build      string
+
+	// str caches the String form, which is fixed at construction. It is
+	// derived from the other fields, so == still compares versions.
+	str string
+}
+
+// Parse parses a semver string, which may have a leading v.
+func Parse(s string) (Version, error) {
+	old, err := v1.Parse(s)
+	if err != nil {
+		return Version{}, err
+	}
+	return fromV1(old), nil
+}
+
+// MustParse is like Parse, but panics if s is invalid.
+func MustParse(s string) Version {
+	if ver, err := Parse(s); err != nil {
+		panic(err)
+	} else {
+		return ver
+	}
+}
+
+// New builds a release version from its components, which must be
+// non-negative.
+func New(major, minor, patch int) (Version, error) {
+	return FromV1(v1.Semver{Major: major, Minor: minor, Patch: patch})
+}
+
+// FromV1 converts a version 1 Semver, returning an error for the values
+// Semver.Validate rejects.
+func FromV1(old v1.Semver) (Version, error) {
+	if err := old.Validate(); err != nil {
+		return Version{}, err
+	}
+	return fromV1(old), nil
+}
+
+func fromV1(old v1.Semver) Version {
+	if old == (v1.Semver{}) {
+		// leave 0.0.0 equal to the zero Version
+		return Version{}
+	}
+	return Version{
+		major:      old.Major,
+		minor:      old.Minor,
+		patch:      old.Patch,
+		prerelease: old.Prerelease,
+		build:      old.Build,
+		str:        old.String(),
+	}
+}
+
+// V1 converts the version to a version 1 Semver.
+func (v Version) V1() v1.Semver {
+	return v1.Semver{
+		Major:      v.major,
+		Minor:      v.minor,
+		Patch:      v.patch,
+		Prerelease: v.prerelease,
+		Build:      v.build,
+	}
+}
+
+// Major returns the major version.
+func (v Version) Major() int { return v.major }
+
+// Minor returns the minor version.
+func (v Version) Minor() int { return v.minor }
+
+// Patch returns the patch version.
+func (v Version) Patch() int { return v.patch }
+
+// Prerelease returns the prerelease, without the leading -, or "".
+func (v Version) Prerelease() string { return v.prerelease }
+
+// Build returns the build metadata, without the leading +, or "".
+func (v Version) Build() string { return v.build }
+
+// WithPrerelease returns a copy of v with the given prerelease, or "" to
+// make it a release.
+func (v Version) WithPrerelease(pre string) (Version, error) {
+	old := v.V1()
+	old.Prerelease = pre
+	return FromV1(old)
+}
+
+// WithBuild returns a copy of v with the given build metadata, or "" to
+// remove it.
+func (v Version) WithBuild(build string) (Version, error) {
+	old := v.V1()
+	old.Build = build
+	return FromV1(old)
+}
+
+// String produces a semver string, without a leading v. It doesn't allocate,
+// as the string is computed once when the Version is created.
+func (v Version) String() string {
+	if v.str == "" {
+		return "0.0.0"
+	}
+	return v.str
+}
+
+// Cmp compares v to b by semver precedence, returning a negative number if
+// v < b, 0 if they're equal and a positive number if v > b. Build metadata is
+// ignored.
+func (v Version) Cmp(b Version) int {
+	return v.V1().Cmp(b.V1())
+}
+
+// MarshalText encodes the version as its String form.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText parses a semver string. v is left unchanged on error.
+func (v *Version) UnmarshalText(b []byte) error {
+	ver, err := Parse(string(b))
+	if err != nil {
+		return fmt.Errorf("Invalid semver: %s", err)
+	}
+	*v = ver
+	return nil
+}
@@ -0,0 +1,121 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+
+	v1 "github.com/beatgammit/semver"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.2.3-rc.1+build.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v.Major() != 1 || v.Minor() != 2 || v.Patch() != 3 || v.Prerelease() != "rc.1" || v.Build() != "build.5" {
+		t.Errorf("unexpected components: %s", v)
+	}
+	if s := v.String(); s != "1.2.3-rc.1+build.5" {
+		t.Errorf("unexpected string: %s", s)
+	}
+
+	for _, s := range []string{"1.2", "1.2.3-", "-1.2.3", "1.2.3-rc_1"} {
+		if v, err := Parse(s); err == nil {
+			t.Errorf("%s: expected error, returned: %s", s, v)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if v, err := New(1, 2, 3); err != nil || v != MustParse("1.2.3") {
+		t.Errorf("New: %s, %v", v, err)
+	}
+	if v, err := New(1, -2, 3); err == nil {
+		t.Errorf("expected error for a negative component, returned: %s", v)
+	}
+	if (Version{}).String() != "0.0.0" {
+		t.Errorf("zero Version should be 0.0.0")
+	}
+
+	v := MustParse("1.2.3")
+	pre, err := v.WithPrerelease("rc.1")
+	if err != nil || pre.String() != "1.2.3-rc.1" || v.String() != "1.2.3" {
+		t.Errorf("WithPrerelease: %s, %v; original %s", pre, err, v)
+	}
+	if _, err := v.WithPrerelease("rc 1"); err == nil {
+		t.Errorf("expected error for an invalid prerelease")
+	}
+	if b, err := pre.WithBuild("sha.abc"); err != nil || b.String() != "1.2.3-rc.1+sha.abc" {
+		t.Errorf("WithBuild: %s, %v", b, err)
+	}
+}
+
+func TestV1(t *testing.T) {
+	old := v1.MustParse("1.2.3-rc.1+build.5")
+	v, err := FromV1(old)
+	if err != nil || v.V1() != old {
+		t.Errorf("round trip: %+v, %v", v.V1(), err)
+	}
+	if v, err := FromV1(v1.Semver{Major: -1}); err == nil {
+		t.Errorf("expected error for an invalid Semver, returned: %s", v)
+	}
+}
+
+func TestCmp(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-rc.1", "1.0.0", "1.0.1", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, b := MustParse(ordered[i-1]), MustParse(ordered[i])
+		if a.Cmp(b) >= 0 || b.Cmp(a) <= 0 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+	if MustParse("1.0.0+a").Cmp(MustParse("1.0.0+b")) != 0 {
+		t.Errorf("build metadata should be ignored")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type release struct {
+		Version Version `json:"version"`
+	}
+	b, err := json.Marshal(release{MustParse("1.2.3-rc.1")})
+	if err != nil || string(b) != `{"version":"1.2.3-rc.1"}` {
+		t.Errorf("marshal: %s, %v", b, err)
+	}
+	var r release
+	if err := json.Unmarshal(b, &r); err != nil || r.Version != MustParse("1.2.3-rc.1") {
+		t.Errorf("unmarshal: %+v, %v", r, err)
+	}
+	if err := json.Unmarshal([]byte(`{"version":"1.2"}`), &r); err == nil {
+		t.Errorf("expected error for an invalid version")
+	}
+}
+
+func TestStringCached(t *testing.T) {
+	if MustParse("0.0.0") != (Version{}) {
+		t.Errorf("parsed 0.0.0 should equal the zero Version")
+	}
+	if MustParse("v1.2.3") != MustParse("1.2.3") {
+		t.Errorf("equal versions should be ==")
+	}
+
+	v := MustParse("1.2.3-rc.1+build.5")
+	var s string
+	if n := testing.AllocsPerRun(100, func() { s = v.String() }); n != 0 {
+		t.Errorf("String allocated %v times", n)
+	}
+	if s != "1.2.3-rc.1+build.5" {
+		t.Errorf("unexpected string: %s", s)
+	}
+	if pre, _ := v.WithPrerelease(""); pre.String() != "1.2.3+build.5" {
+		t.Errorf("derived versions should have their own string: %s", pre)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = v.String()
+	}
+}
@@ -0,0 +1,222 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	_ fmt.Formatter            = Vendored{}
+	_ fmt.GoStringer           = Vendored{}
+	_ encoding.TextMarshaler   = Vendored{}
+	_ encoding.TextUnmarshaler = (*Vendored)(nil)
+	_ json.Marshaler           = Vendored{}
+	_ json.Unmarshaler         = (*Vendored)(nil)
+)
+
+// DefaultVendors are the identifiers Kubernetes distributions put at the start
+// of the prerelease to mark their own builds of an upstream release, as in
+// v1.28.3-gke.100 or v1.27.9-eks-5e0fdde.
+var DefaultVendors = []string{"gke", "eks", "aks", "oke", "iks", "do"}
+
+// Vendored is a version built by a vendor from an upstream release. Semver
+// holds the upstream version and Vendor the suffix the vendor added.
+type Vendored struct {
+	Semver
+	Vendor string
+}
+
+// ParseVendored parses a version with Parse, so a leading v is allowed,
+// treating a prerelease that starts with one of vendors (DefaultVendors if
+// none are given) as a vendor suffix rather than a prerelease. Matching is
+// case-insensitive.
+func ParseVendored(s string, vendors ...string) (Vendored, error) {
+	if len(vendors) == 0 {
+		vendors = DefaultVendors
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return Vendored{}, err
+	}
+	vv := Vendored{Semver: v}
+	name := vendorName(v.Prerelease)
+	for _, vendor := range vendors {
+		if strings.EqualFold(name, vendor) {
+			vv.Vendor, vv.Prerelease = v.Prerelease, ""
+			break
+		}
+	}
+	return vv, nil
+}
+
+// vendorName returns the leading identifier of a vendor suffix.
+func vendorName(suffix string) string {
+	if i := strings.IndexAny(suffix, ".-"); i >= 0 {
+		return suffix[:i]
+	}
+	return suffix
+}
+
+// VendorName returns the vendor's identifier, e.g. "gke" for v1.28.3-gke.100.
+func (v Vendored) VendorName() string {
+	return vendorName(v.Vendor)
+}
+
+// String produces the vendored version string.
+func (v Vendored) String() string {
+	b, _ := v.AppendText(nil)
+	return string(b)
+}
+
+// AppendText appends the String form of the version to b.
+func (v Vendored) AppendText(b []byte) ([]byte, error) {
+	u := v.Semver
+	if v.Vendor != "" {
+		u.Prerelease = v.Vendor
+	}
+	return u.AppendText(b)
+}
+
+// MarshalText encodes the version as its String form, vendor suffix
+// included.
+//
+// Vendored embeds Semver, so without its own encoders it would inherit
+// Semver's, which drop the vendor suffix. It implements every encoding that
+// Semver does, each encoding the version as a string.
+func (v Vendored) MarshalText() ([]byte, error) {
+	return v.AppendText(nil)
+}
+
+// UnmarshalText parses a version with ParseVendored and DefaultVendors,
+// leaving v unchanged on error. Suffixes of other vendors are decoded as
+// prereleases, though the version still formats the same.
+func (v *Vendored) UnmarshalText(arr []byte) error {
+	vv, err := ParseVendored(string(arr))
+	if err == nil {
+		*v = vv
+	}
+	return err
+}
+
+// MarshalJSON encodes the version as a JSON string.
+func (v Vendored) MarshalJSON() ([]byte, error) {
+	return marshalTextJSON(v)
+}
+
+// UnmarshalJSON decodes a JSON string with UnmarshalText. null leaves v
+// unchanged.
+func (v *Vendored) UnmarshalJSON(arr []byte) error {
+	return unmarshalTextJSON(v, "vendored version", arr)
+}
+
+// MarshalYAML encodes the version as a YAML scalar.
+func (v Vendored) MarshalYAML() (interface{}, error) {
+	return marshalTextYAML(v)
+}
+
+// UnmarshalYAML decodes a YAML scalar with UnmarshalText.
+func (v *Vendored) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalTextYAML(v, unmarshal)
+}
+
+// UnmarshalTOML decodes a TOML string with UnmarshalText.
+func (v *Vendored) UnmarshalTOML(data interface{}) error {
+	return unmarshalTextTOML(v, "vendored version", data)
+}
+
+// MarshalBSONValue encodes the version as a BSON string.
+func (v Vendored) MarshalBSONValue() (byte, []byte, error) {
+	return marshalTextBSON(v)
+}
+
+// UnmarshalBSONValue decodes a BSON string with UnmarshalText.
+func (v *Vendored) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalTextBSON(v, "vendored version", typ, data)
+}
+
+// MarshalMsgpack encodes the version as a MessagePack string.
+func (v Vendored) MarshalMsgpack() ([]byte, error) {
+	return marshalTextMsgpack(v)
+}
+
+// UnmarshalMsgpack decodes a MessagePack string with UnmarshalText.
+func (v *Vendored) UnmarshalMsgpack(data []byte) error {
+	return unmarshalTextMsgpack(v, "vendored version", data)
+}
+
+// MarshalCBOR encodes the version as a CBOR text string.
+func (v Vendored) MarshalCBOR() ([]byte, error) {
+	return marshalTextCBOR(v)
+}
+
+// UnmarshalCBOR decodes a CBOR text string with UnmarshalText.
+func (v *Vendored) UnmarshalCBOR(data []byte) error {
+	return unmarshalTextCBOR(v, data)
+}
+
+// MarshalBinary encodes the version as its String form, so that
+// encoding/gob keeps the vendor suffix.
+func (v Vendored) MarshalBinary() ([]byte, error) {
+	return v.MarshalText()
+}
+
+// UnmarshalBinary decodes the output of MarshalBinary.
+func (v *Vendored) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalText(data)
+}
+
+// Value stores the version as TEXT.
+func (v Vendored) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// Scan reads a version from a TEXT column.
+func (v *Vendored) Scan(src interface{}) error {
+	return scanText(v, "Vendored", src)
+}
+
+// MarshalGQL writes the version as a GraphQL string.
+func (v Vendored) MarshalGQL(w io.Writer) {
+	marshalTextGQL(v, w)
+}
+
+// UnmarshalGQL parses a custom scalar input value, which must be a string.
+func (v *Vendored) UnmarshalGQL(val interface{}) error {
+	return unmarshalTextGQL(v, "Vendored", val)
+}
+
+// Set parses s with UnmarshalText, for flag.Value.
+func (v *Vendored) Set(s string) error {
+	return v.UnmarshalText([]byte(s))
+}
+
+// Format implements fmt.Formatter, so that %v and %s print the version with
+// its vendor suffix rather than the embedded Semver. The other verbs are as
+// for Semver.
+func (v Vendored) Format(f fmt.State, verb rune) {
+	formatEmbedded(f, verb, v.String(), v.GoString(), v.Semver)
+}
+
+// GoString formats the version as a Go struct literal, which is what %#v
+// prints.
+func (v Vendored) GoString() string {
+	return fmt.Sprintf("semver.Vendored{Semver:%#v, Vendor:%q}", v.Semver, v.Vendor)
+}
+
+// Cmp compares two vendored versions, upstream version first. Builds of the
+// same upstream version sort after the upstream release itself, then by
+// vendor suffix using the same rules as prerelease identifiers, so
+// 1.28.3 < 1.28.3-gke.99 < 1.28.3-gke.100 < 1.28.4-gke.1.
+func (a Vendored) Cmp(b Vendored) int {
+	if c := a.Semver.Cmp(b.Semver); c != 0 {
+		return c
+	}
+	if a.Vendor == "" || b.Vendor == "" {
+		return len(a.Vendor) - len(b.Vendor)
+	}
+	return Semver{Prerelease: a.Vendor}.Cmp(Semver{Prerelease: b.Vendor})
+}
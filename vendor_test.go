@@ -0,0 +1,65 @@
+package semver
+
+import "testing"
+
+type vendorTest struct {
+	given    string
+	upstream Semver
+	vendor   string
+	reason   string
+}
+
+func TestParseVendored(t *testing.T) {
+	tests := []vendorTest{
+		{"v1.28.3-gke.100", Semver{Major: 1, Minor: 28, Patch: 3}, "gke.100", "gke"},
+		{"v1.27.9-eks-5e0fdde", Semver{Major: 1, Minor: 27, Patch: 9}, "eks-5e0fdde", "eks"},
+		{"v1.27.9-EKS-5e0fdde", Semver{Major: 1, Minor: 27, Patch: 9}, "EKS-5e0fdde", "case-insensitive"},
+		{"v1.29.0-rc.1", Semver{Major: 1, Minor: 29, Prerelease: "rc.1"}, "", "upstream prerelease"},
+		{"v1.27.9+k3s1", Semver{Major: 1, Minor: 27, Patch: 9, Build: "k3s1"}, "", "build metadata"},
+	}
+
+	for _, test := range tests {
+		v, err := ParseVendored(test.given)
+		if err != nil {
+			t.Errorf("%s: error parsing: %s", test.reason, err)
+			continue
+		}
+		if v.Semver != test.upstream || v.Vendor != test.vendor {
+			t.Errorf("%s: %+v != {%+v %s}", test.reason, v, test.upstream, test.vendor)
+		}
+		if s := v.String(); "v"+s != test.given {
+			t.Errorf("%s: %s doesn't round trip", test.reason, s)
+		}
+	}
+
+	if v, _ := ParseVendored("1.28.3-acme.1", "acme"); v.VendorName() != "acme" {
+		t.Errorf("custom vendor not recognized: %+v", v)
+	}
+}
+
+func TestVendoredCmp(t *testing.T) {
+	ordered := []string{"1.28.2-gke.5", "1.28.3-rc.1", "1.28.3", "1.28.3-eks-1", "1.28.3-gke.99", "1.28.3-gke.100", "1.28.4-gke.1"}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := ParseVendored(ordered[i-1])
+		b, _ := ParseVendored(ordered[i])
+		if a.Cmp(b) >= 0 || b.Cmp(a) <= 0 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+}
+
+func TestVendoredEncoding(t *testing.T) {
+	for _, s := range []string{"1.28.3", "1.28.3-gke.100", "1.27.9-eks-5e0fdde+build.5", "1.29.0-rc.1"} {
+		v, err := ParseVendored(s)
+		if err != nil {
+			t.Errorf("%s: error parsing: %s", s, err)
+			continue
+		}
+		testEmbeddedEncodings(t, v, s, func() embeddedDecoder { return new(Vendored) })
+	}
+
+	var v Vendored
+	if err := v.UnmarshalText([]byte("1.28")); err == nil {
+		t.Errorf("expected error, got %+v", v)
+	}
+}
@@ -0,0 +1,115 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// VersionedDoc is meant to be embedded in a document type, such as a config
+// file, that records the version of its schema:
+//
+//	type Config struct {
+//		semver.VersionedDoc
+//		Listen string `json:"listen"`
+//	}
+//
+// UnmarshalVersioned checks the schema_version before decoding the rest.
+type VersionedDoc struct {
+	SchemaVersion Semver `json:"schema_version"`
+}
+
+// SchemaVersionError is returned by UnmarshalVersioned for a document whose
+// schema version the reader doesn't support.
+type SchemaVersionError struct {
+	Version   Semver
+	Supported Constraint
+
+	// Missing is set if the document has no schema_version at all.
+	Missing bool
+
+	direction int
+}
+
+func (e *SchemaVersionError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("Missing schema_version; supported: %s", e.Supported)
+	}
+	switch {
+	case e.TooNew():
+		return fmt.Sprintf("Unsupported schema_version %s: newer than supported (%s)", e.Version, e.Supported)
+	case e.TooOld():
+		return fmt.Sprintf("Unsupported schema_version %s: older than supported (%s)", e.Version, e.Supported)
+	}
+	return fmt.Sprintf("Unsupported schema_version %s: supported: %s", e.Version, e.Supported)
+}
+
+// TooNew reports whether the document was written for a newer schema than
+// the reader supports, which usually means the reader needs upgrading.
+func (e *SchemaVersionError) TooNew() bool {
+	return e.direction > 0
+}
+
+// TooOld reports whether the document was written for an older schema than
+// the reader supports, which usually means it needs migrating.
+func (e *SchemaVersionError) TooOld() bool {
+	return e.direction < 0
+}
+
+// UnmarshalVersioned decodes the JSON document data into v, which usually
+// embeds VersionedDoc, after checking that its schema_version satisfies
+// supported. An unsupported or missing schema_version is reported as a
+// *SchemaVersionError, and v is left untouched.
+func UnmarshalVersioned(data []byte, supported Constraint, v interface{}) error {
+	var probe struct {
+		SchemaVersion *Semver `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	if probe.SchemaVersion == nil {
+		return &SchemaVersionError{Supported: supported, Missing: true}
+	}
+	ver := *probe.SchemaVersion
+	if !supported.Check(ver) {
+		return &SchemaVersionError{Version: ver, Supported: supported, direction: supported.direction(ver)}
+	}
+	return json.Unmarshal(data, v)
+}
+
+// direction reports whether v, which doesn't satisfy c, is above the range
+// of every comparator set (> 0), below the range of every set (< 0), or
+// neither, e.g. in a gap between alternatives or excluded as a prerelease.
+func (c Constraint) direction(v Semver) int {
+	above, below := true, true
+	for _, set := range c.sets {
+		var setAbove, setBelow bool
+		for _, comp := range set {
+			if comp.check(v) {
+				continue
+			}
+			switch comp.op {
+			case opLT, opLTE:
+				setAbove = true
+			case opGT, opGTE:
+				setBelow = true
+			default:
+				if v.Cmp(comp.v) > 0 {
+					setAbove = true
+				} else {
+					setBelow = true
+				}
+			}
+		}
+		above = above && setAbove
+		below = below && setBelow
+	}
+	switch {
+	case c.sets == nil:
+		return 0
+	case above && !below:
+		return 1
+	case below && !above:
+		return -1
+	}
+	return 0
+}
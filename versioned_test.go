@@ -0,0 +1,65 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+type config struct {
+	VersionedDoc
+	Listen string `json:"listen"`
+}
+
+type versionedTest struct {
+	given   string
+	tooNew  bool
+	tooOld  bool
+	missing bool
+	reason  string
+}
+
+func TestUnmarshalVersioned(t *testing.T) {
+	supported := MustParseConstraint(">=1.2 <3")
+
+	var cfg config
+	if err := UnmarshalVersioned([]byte(`{"schema_version": "2.1.0", "listen": ":80"}`), supported, &cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cfg.SchemaVersion != MustParse("2.1.0") || cfg.Listen != ":80" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	tests := []versionedTest{
+		{`{"schema_version": "3.0.0"}`, true, false, false, "too new"},
+		{`{"schema_version": "1.1.9"}`, false, true, false, "too old"},
+		{`{"schema_version": "2.2.0-draft.1"}`, false, false, false, "prerelease"},
+		{`{"listen": ":80"}`, false, false, true, "missing"},
+	}
+
+	for _, test := range tests {
+		cfg := config{Listen: "unchanged"}
+		err := UnmarshalVersioned([]byte(test.given), supported, &cfg)
+		var verr *SchemaVersionError
+		if !errors.As(err, &verr) {
+			t.Errorf("%s: expected a SchemaVersionError, got %v", test.reason, err)
+			continue
+		}
+		if verr.TooNew() != test.tooNew || verr.TooOld() != test.tooOld || verr.Missing != test.missing {
+			t.Errorf("%s: unexpected error %+v: %s", test.reason, verr, verr)
+		}
+		if cfg.Listen != "unchanged" {
+			t.Errorf("%s: document should not be decoded", test.reason)
+		}
+	}
+
+	if err := UnmarshalVersioned([]byte(`{"schema_version": "2"}`), supported, &cfg); err == nil {
+		t.Errorf("expected error for an invalid schema_version")
+	}
+
+	// a version in a gap between alternatives is neither too new nor too old
+	err := UnmarshalVersioned([]byte(`{"schema_version": "2.0.0"}`), MustParseConstraint("^1 || ^3"), &cfg)
+	var verr *SchemaVersionError
+	if !errors.As(err, &verr) || verr.TooNew() || verr.TooOld() {
+		t.Errorf("unexpected error for a gap: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package semver
+
+import "sort"
+
+var _ sort.Interface = Versions(nil)
+
+// Versions is a slice of versions that sorts in ascending precedence order.
+type Versions []Semver
+
+func (vs Versions) Len() int           { return len(vs) }
+func (vs Versions) Less(i, j int) bool { return vs[i].Cmp(vs[j]) < 0 }
+func (vs Versions) Swap(i, j int)      { vs[i], vs[j] = vs[j], vs[i] }
+
+// Sort sorts the versions in ascending order. Versions that differ only in
+// build metadata have equal precedence, and keep their relative order.
+func (vs Versions) Sort() {
+	sort.Stable(vs)
+}
+
+// Strings returns the String form of each version.
+func (vs Versions) Strings() []string {
+	ss := make([]string, len(vs))
+	for i, v := range vs {
+		ss[i] = v.String()
+	}
+	return ss
+}
+
+// ParseVersions parses every string in semvers, in order, reporting failures
+// as ParseAll does.
+func ParseVersions(semvers []string) (Versions, error) {
+	vs, err := ParseAll(semvers)
+	return Versions(vs), err
+}
+
+// SortStrings sorts version strings in place by ascending precedence, keeping
+// each string as written, "v" prefix and all. Strings with equal precedence
+// keep their relative order. If any string doesn't parse, ss is left
+// unchanged and the error is a ParseErrors.
+func SortStrings(ss []string) error {
+	return sortStrings(ss, false)
+}
+
+// SortStringsDesc is like SortStrings, but sorts newest first.
+func SortStringsDesc(ss []string) error {
+	return sortStrings(ss, true)
+}
+
+func sortStrings(ss []string, desc bool) error {
+	vs, err := ParseAll(ss)
+	if err != nil {
+		return err
+	}
+	idx := make([]int, len(ss))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		if desc {
+			return vs[idx[i]].Cmp(vs[idx[j]]) > 0
+		}
+		return vs[idx[i]].Cmp(vs[idx[j]]) < 0
+	})
+	sorted := make([]string, len(ss))
+	for i, j := range idx {
+		sorted[i] = ss[j]
+	}
+	copy(ss, sorted)
+	return nil
+}
+
+// SortStable sorts versions in ascending order, breaking ties between
+// versions of equal precedence by their build metadata, so the result is
+// deterministic. Build metadata is compared identifier by identifier like a
+// prerelease, numerically where both are numeric, and no build metadata
+// sorts first. Versions that are still equal keep their input order.
+func SortStable(versions []Semver) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		if c := versions[i].Cmp(versions[j]); c != 0 {
+			return c < 0
+		}
+		return compareBuild(versions[i].Build, versions[j].Build) < 0
+	})
+}
+
+func compareBuild(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+	return compareIdentifiers(a, b)
+}
@@ -0,0 +1,113 @@
+package semver
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var (
+	_ json.Marshaler           = Versions(nil)
+	_ json.Unmarshaler         = (*Versions)(nil)
+	_ encoding.TextMarshaler   = Versions(nil)
+	_ encoding.TextUnmarshaler = (*Versions)(nil)
+)
+
+// validate checks every version, reporting failures as ParseErrors.
+func (vs Versions) validate() error {
+	var errs ParseErrors
+	for i, v := range vs {
+		if err := v.Validate(); err != nil {
+			errs = append(errs, &ParseError{Index: i, Input: v.String(), Err: err})
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// MarshalJSON encodes the versions as a JSON array of strings. A nil Versions
+// is encoded as null.
+func (vs Versions) MarshalJSON() ([]byte, error) {
+	if vs == nil {
+		return []byte("null"), nil
+	}
+	if err := vs.validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(vs.Strings())
+}
+
+// UnmarshalJSON decodes a JSON array of version strings. Every element is
+// checked, and failures are reported together as ParseErrors with their
+// indexes; vs is left unchanged on error. null leaves vs unchanged.
+func (vs *Versions) UnmarshalJSON(arr []byte) error {
+	if string(bytes.TrimSpace(arr)) == "null" {
+		return nil
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(arr, &elems); err != nil {
+		return fmt.Errorf("Invalid versions JSON: %s", err)
+	}
+	out := make(Versions, len(elems))
+	var errs ParseErrors
+	for i, elem := range elems {
+		var s string
+		if err := json.Unmarshal(elem, &s); err != nil {
+			errs = append(errs, &ParseError{Index: i, Input: string(elem), Err: fmt.Errorf("expected a string")})
+			continue
+		}
+		v, err := Parse(s)
+		if err != nil {
+			errs = append(errs, &ParseError{Index: i, Input: s, Err: err})
+			continue
+		}
+		out[i] = v
+	}
+	if errs != nil {
+		return errs
+	}
+	*vs = out
+	return nil
+}
+
+// MarshalText encodes the versions one per line, each followed by a newline.
+func (vs Versions) MarshalText() ([]byte, error) {
+	if err := vs.validate(); err != nil {
+		return nil, err
+	}
+	var b []byte
+	for _, v := range vs {
+		b, _ = v.AppendText(b)
+		b = append(b, '\n')
+	}
+	return b, nil
+}
+
+// UnmarshalText decodes versions one per line. Surrounding whitespace and
+// blank lines are ignored, and failures are reported together as ParseErrors
+// whose Index is the zero-based line number.
+func (vs *Versions) UnmarshalText(arr []byte) error {
+	var out Versions
+	var errs ParseErrors
+	for i, line := range strings.Split(string(arr), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		v, err := Parse(line)
+		if err != nil {
+			errs = append(errs, &ParseError{Index: i, Input: line, Err: err})
+			continue
+		}
+		out = append(out, v)
+	}
+	if errs != nil {
+		return errs
+	}
+	*vs = out
+	return nil
+}
@@ -0,0 +1,61 @@
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestVersionsJson(t *testing.T) {
+	vs := Versions{MustParse("1.0.0"), MustParse("v2.0.0-rc.1+build")}
+	b, err := json.Marshal(vs)
+	if err != nil || string(b) != `["1.0.0","2.0.0-rc.1+build"]` {
+		t.Errorf("unexpected encoding: %s, %v", b, err)
+	}
+	var back Versions
+	if err := json.Unmarshal(b, &back); err != nil || len(back) != 2 || back[1] != vs[1] {
+		t.Errorf("round trip: %v, %v", back, err)
+	}
+
+	if b, _ := json.Marshal(Versions(nil)); string(b) != "null" {
+		t.Errorf("nil should be null: %s", b)
+	}
+	if b, _ := json.Marshal(Versions{}); string(b) != "[]" {
+		t.Errorf("empty should be []: %s", b)
+	}
+	if _, err := json.Marshal(Versions{{Major: -1}}); err == nil {
+		t.Errorf("expected error marshaling an invalid version")
+	}
+
+	back = Versions{MustParse("9.9.9")}
+	err = json.Unmarshal([]byte(`["1.0.0", "1.2", 3, "2.0.0"]`), &back)
+	var errs ParseErrors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Index != 1 || errs[1].Index != 2 {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(back) != 1 || back[0] != MustParse("9.9.9") {
+		t.Errorf("should be unchanged on error: %v", back)
+	}
+	if err := json.Unmarshal([]byte(`{"a": "1.0.0"}`), &back); err == nil {
+		t.Errorf("expected error for an object")
+	}
+}
+
+func TestVersionsText(t *testing.T) {
+	vs := Versions{MustParse("1.0.0"), MustParse("2.0.0-rc.1")}
+	b, err := vs.MarshalText()
+	if err != nil || string(b) != "1.0.0\n2.0.0-rc.1\n" {
+		t.Errorf("unexpected encoding: %q, %v", b, err)
+	}
+
+	var back Versions
+	if err := back.UnmarshalText([]byte("  1.0.0\r\n\n2.0.0-rc.1\n")); err != nil || len(back) != 2 || back[1] != vs[1] {
+		t.Errorf("unexpected decoding: %v, %v", back, err)
+	}
+
+	err = back.UnmarshalText([]byte("1.0.0\nbogus\n"))
+	var errs ParseErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Index != 1 || errs[0].Input != "bogus" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestVersionsSort(t *testing.T) {
+	vs, err := ParseVersions([]string{"1.10.0", "v1.2.0", "1.0.0-rc.1", "1.0.0+b", "1.0.0", "1.0.0-alpha", "1.0.0+a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vs.Sort()
+	exp := []string{"1.0.0-alpha", "1.0.0-rc.1", "1.0.0+b", "1.0.0", "1.0.0+a", "1.2.0", "1.10.0"}
+	if got := vs.Strings(); !reflect.DeepEqual(got, exp) {
+		t.Errorf("%q != %q", got, exp)
+	}
+
+	// and through sort.Interface directly
+	sort.Sort(sort.Reverse(vs))
+	if vs[0] != MustParse("1.10.0") || vs[len(vs)-1] != MustParse("1.0.0-alpha") {
+		t.Errorf("unexpected reverse order: %q", vs.Strings())
+	}
+
+	if got := (Versions{}).Strings(); got == nil || len(got) != 0 {
+		t.Errorf("empty Strings should be an empty slice: %#v", got)
+	}
+}
+
+func TestParseVersionsInvalid(t *testing.T) {
+	vs, err := ParseVersions([]string{"1.0.0", "bogus"})
+	var errs ParseErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Index != 1 {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(vs) != 2 || vs[0] != MustParse("1.0.0") {
+		t.Errorf("unexpected versions: %v", vs)
+	}
+}
+
+func TestSortStrings(t *testing.T) {
+	tags := []string{"v1.10.0", "1.2.0", "v1.2.0-rc.1", "v0.9.0", "v1.2.0+meta"}
+	if err := SortStrings(tags); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp := []string{"v0.9.0", "v1.2.0-rc.1", "1.2.0", "v1.2.0+meta", "v1.10.0"}; !reflect.DeepEqual(tags, exp) {
+		t.Errorf("%q != %q", tags, exp)
+	}
+
+	if err := SortStringsDesc(tags); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp := []string{"v1.10.0", "1.2.0", "v1.2.0+meta", "v1.2.0-rc.1", "v0.9.0"}; !reflect.DeepEqual(tags, exp) {
+		t.Errorf("%q != %q", tags, exp)
+	}
+
+	bad := []string{"v2.0.0", "latest", "v1.0.0"}
+	if err := SortStrings(bad); err == nil {
+		t.Errorf("expected error for an invalid version")
+	}
+	if exp := []string{"v2.0.0", "latest", "v1.0.0"}; !reflect.DeepEqual(bad, exp) {
+		t.Errorf("should be unchanged on error: %q", bad)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	vs, _ := ParseVersions([]string{"1.0.0+build.10", "1.0.0+build.9", "0.9.0", "1.0.0", "1.0.0+build.09", "1.0.0+sha.abc", "1.0.0+build.9.1", "1.0.0-rc.1+z"})
+	SortStable(vs)
+	exp := []string{"0.9.0", "1.0.0-rc.1+z", "1.0.0", "1.0.0+build.9", "1.0.0+build.09", "1.0.0+build.9.1", "1.0.0+build.10", "1.0.0+sha.abc"}
+	if got := Versions(vs).Strings(); !reflect.DeepEqual(got, exp) {
+		t.Errorf("%q != %q", got, exp)
+	}
+}
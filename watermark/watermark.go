@@ -0,0 +1,147 @@
+// Package watermark records the highest version ever seen, such as the
+// newest schema version that has touched a database, so that a program can
+// refuse to run a version older than one that has already been there.
+package watermark
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/beatgammit/semver"
+)
+
+// Store persists a watermark.
+type Store interface {
+	// Load returns the stored version, or false if none has been stored.
+	Load() (semver.Semver, bool, error)
+	// Save stores v, replacing any version already stored. It must not
+	// return until v is durable, and a failed Save must leave the stored
+	// version as it was.
+	Save(v semver.Semver) error
+}
+
+// FileStore stores a watermark in a file, as a version string on a line of
+// its own. Saves write a temporary file in the same directory, sync it and
+// rename it over the old one, so that after a crash the file holds either
+// the old version or the new one.
+type FileStore struct {
+	Path string
+}
+
+// Load reads the file, reporting false if it doesn't exist.
+func (s FileStore) Load() (semver.Semver, bool, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return semver.Semver{}, false, nil
+	} else if err != nil {
+		return semver.Semver{}, false, err
+	}
+	v, err := semver.Parse(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return semver.Semver{}, false, fmt.Errorf("Invalid watermark file %s: %s", s.Path, err)
+	}
+	return v, true, nil
+}
+
+// Save replaces the file with one holding v.
+func (s FileStore) Save(v semver.Semver) error {
+	dir := filepath.Dir(s.Path)
+	f, err := os.CreateTemp(dir, "."+filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	_, err = f.WriteString(v.String() + "\n")
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.Path)
+	}
+	if err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// Watermark is the highest version observed, kept in a Store. It is safe
+// for concurrent use, but only one Watermark should use a Store at a time.
+type Watermark struct {
+	mu      sync.Mutex
+	store   Store
+	current semver.Semver
+	set     bool
+}
+
+// New returns a Watermark kept in store, starting from the version stored
+// there.
+func New(store Store) (*Watermark, error) {
+	v, ok, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Watermark{store: store, current: v, set: ok}, nil
+}
+
+// Open returns a Watermark kept in the file at path, which is created the
+// first time a version is observed.
+func Open(path string) (*Watermark, error) {
+	return New(FileStore{path})
+}
+
+// Current returns the highest version observed, or false if there hasn't
+// been one.
+func (w *Watermark) Current() (semver.Semver, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current, w.set
+}
+
+// Observe raises the watermark to v if v is higher, saving it before
+// returning, and reports whether it did. Versions are compared by
+// precedence, so a version differing from the watermark only in build
+// metadata doesn't raise it. If the save fails, the watermark is unchanged.
+func (w *Watermark) Observe(v semver.Semver) (bool, error) {
+	if err := v.Validate(); err != nil {
+		return false, err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.set && v.Cmp(w.current) <= 0 {
+		return false, nil
+	}
+	if err := w.store.Save(v); err != nil {
+		return false, err
+	}
+	w.current, w.set = v, true
+	return true, nil
+}
+
+// Check returns an error if v is older than the watermark, for enforcing
+// that a program is never downgraded.
+func (w *Watermark) Check(v semver.Semver) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.set && v.Cmp(w.current) < 0 {
+		return fmt.Errorf("Version %s is older than %s, which has already been used", v, w.current)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package watermark
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/beatgammit/semver"
+)
+
+func TestWatermark(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watermark")
+	w, err := Open(path)
+	if err != nil {
+		t.Fatalf("error opening: %s", err)
+	}
+	if _, ok := w.Current(); ok {
+		t.Errorf("expected no watermark yet")
+	}
+
+	steps := []struct {
+		given  string
+		raised bool
+		exp    string
+	}{
+		{"1.2.0", true, "1.2.0"},
+		{"1.1.0", false, "1.2.0"},
+		{"1.3.0-rc.1", true, "1.3.0-rc.1"},
+		{"1.3.0-rc.1+build.2", false, "1.3.0-rc.1"},
+		{"1.3.0", true, "1.3.0"},
+	}
+	for _, step := range steps {
+		raised, err := w.Observe(semver.MustParse(step.given))
+		if err != nil {
+			t.Fatalf("%s: error observing: %s", step.given, err)
+		}
+		if cur, _ := w.Current(); raised != step.raised || cur.String() != step.exp {
+			t.Errorf("%s: raised %t, current %s", step.given, raised, cur)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "1.3.0\n" {
+		t.Errorf("file contents %q, %v", data, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("error reopening: %s", err)
+	}
+	if cur, ok := reopened.Current(); !ok || cur.String() != "1.3.0" {
+		t.Errorf("reopened at %s, %t", cur, ok)
+	}
+	if err := reopened.Check(semver.MustParse("1.2.9")); err == nil {
+		t.Errorf("expected an error checking an older version")
+	}
+	if err := reopened.Check(semver.MustParse("1.3.0")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestWatermarkConcurrent(t *testing.T) {
+	w, err := Open(filepath.Join(t.TempDir(), "watermark"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(minor int) {
+			defer wg.Done()
+			if _, err := w.Observe(semver.Semver{Major: 1, Minor: minor}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if cur, _ := w.Current(); cur.String() != "1.19.0" {
+		t.Errorf("current %s != 1.19.0", cur)
+	}
+}
+
+type failingStore struct{ saved *semver.Semver }
+
+func (s *failingStore) Load() (semver.Semver, bool, error) {
+	if s.saved == nil {
+		return semver.Semver{}, false, nil
+	}
+	return *s.saved, true, nil
+}
+
+func (s *failingStore) Save(v semver.Semver) error {
+	if v.Major >= 2 {
+		return errors.New("read-only")
+	}
+	s.saved = &v
+	return nil
+}
+
+func TestWatermarkStoreErrors(t *testing.T) {
+	w, err := New(&failingStore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Observe(semver.MustParse("1.0.0")); err != nil {
+		t.Fatal(err)
+	}
+	if raised, err := w.Observe(semver.MustParse("2.0.0")); err == nil || raised {
+		t.Errorf("expected the save error, got %t, %v", raised, err)
+	}
+	if cur, _ := w.Current(); cur.String() != "1.0.0" {
+		t.Errorf("watermark changed by a failed save: %s", cur)
+	}
+
+	path := filepath.Join(t.TempDir(), "watermark")
+	os.WriteFile(path, []byte("not a version\n"), 0644)
+	if _, err := Open(path); err == nil {
+		t.Errorf("expected an error for a corrupt file")
+	}
+}
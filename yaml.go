@@ -0,0 +1,22 @@
+package semver
+
+// MarshalYAML encodes the version as a YAML scalar.
+func (ver Semver) MarshalYAML() (interface{}, error) {
+	return ver.String(), nil
+}
+
+// UnmarshalYAML decodes a YAML scalar with Parse, so that versions are
+// validated as a config file is loaded.
+//
+// It uses the callback form of the interface, which gopkg.in/yaml.v2 calls
+// directly and gopkg.in/yaml.v3 still calls when a type has no node form of
+// UnmarshalYAML; that keeps this package free of a dependency on either. The
+// semveryaml package has a Version type with the node form, whose errors
+// give the position of the version in the document.
+func (ver *Semver) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return ver.UnmarshalText([]byte(s))
+}
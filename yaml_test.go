@@ -0,0 +1,36 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+// scalar returns an unmarshal callback that decodes the given YAML scalar.
+func scalar(s string) func(interface{}) error {
+	return func(v interface{}) error {
+		p, ok := v.(*string)
+		if !ok {
+			return errors.New("not a *string")
+		}
+		*p = s
+		return nil
+	}
+}
+
+func TestYAML(t *testing.T) {
+	out, err := MustParse("1.2.3-rc.1").MarshalYAML()
+	if err != nil || out != "1.2.3-rc.1" {
+		t.Errorf("MarshalYAML: %v, %v", out, err)
+	}
+
+	var ver Semver
+	if err := ver.UnmarshalYAML(scalar("v1.2.3")); err != nil || ver != (Semver{Major: 1, Minor: 2, Patch: 3}) {
+		t.Errorf("UnmarshalYAML: %+v, %v", ver, err)
+	}
+	if err := ver.UnmarshalYAML(scalar("1.2")); err == nil {
+		t.Errorf("expected validation error, got %+v", ver)
+	}
+	if err := ver.UnmarshalYAML(func(interface{}) error { return errors.New("mapping") }); err == nil {
+		t.Errorf("expected decoder error to be returned")
+	}
+}